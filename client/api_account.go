@@ -8,10 +8,12 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
 	evmostypes "github.com/evmos/evmos/v12/types"
 	paymentTypes "github.com/evmos/evmos/v12/x/payment/types"
 	"github.com/zkMeLabs/mechain-go-sdk/types"
+	"google.golang.org/grpc"
 )
 
 // IAccountClient - Client APIs for operating Mechain accounts.
@@ -21,6 +23,7 @@ type IAccountClient interface {
 	MustGetDefaultAccount() *types.Account
 
 	GetAccount(ctx context.Context, address string) (authTypes.AccountI, error)
+	GetMultisigAccount(ctx context.Context, address string) (*types.MultisigAccount, error)
 	GetAccountBalance(ctx context.Context, address string) (*sdk.Coin, error)
 	GetPaymentAccount(ctx context.Context, address string) (*paymentTypes.PaymentAccount, error)
 	GetModuleAccounts(ctx context.Context) ([]authTypes.ModuleAccountI, error)
@@ -40,7 +43,7 @@ type IAccountClient interface {
 // - account: The account to be set as the default account, should be created using a private key or a mnemonic phrase.
 func (c *Client) SetDefaultAccount(account *types.Account) {
 	c.defaultAccount = account
-	c.chainClient.SetKeyManager(account.GetKeyManager())
+	c.endpoints.setKeyManager(account.GetKeyManager())
 }
 
 // GetDefaultAccount - Get the default account of the Client.
@@ -65,6 +68,69 @@ func (c *Client) MustGetDefaultAccount() *types.Account {
 	return c.defaultAccount
 }
 
+// accountContextKey is the context key of the account set by WithAccount.
+type accountContextKey struct{}
+
+// WithAccount - Return a copy of ctx which makes the API calls act as the given account instead of the default
+// account of the Client.
+//
+// Both the transactions and the requests to the SPs made with the returned context are signed by the account, so that
+// a Client can be shared by concurrent callers acting as different accounts without calling SetDefaultAccount.
+//
+// - ctx: The parent context.
+//
+// - account: The account to act as, should be created using a private key, a mnemonic phrase or a signer.
+//
+// - ret: The context carrying the account.
+func WithAccount(ctx context.Context, account *types.Account) context.Context {
+	return context.WithValue(ctx, accountContextKey{}, account)
+}
+
+// AccountFromContext - Get the account set by WithAccount.
+//
+// - ctx: The context of the API call.
+//
+// - ret1: The account set by WithAccount.
+//
+// - ret2: Whether an account has been set.
+func AccountFromContext(ctx context.Context) (*types.Account, bool) {
+	account, ok := ctx.Value(accountContextKey{}).(*types.Account)
+	return account, ok && account != nil
+}
+
+// getCallAccount returns the account the API call acts as, the account set by WithAccount takes precedence over the
+// default account.
+func (c *Client) getCallAccount(ctx context.Context) (*types.Account, error) {
+	if account, ok := AccountFromContext(ctx); ok {
+		return account, nil
+	}
+	return c.GetDefaultAccount()
+}
+
+// mustGetCallAccount is like getCallAccount but panics when neither account is set.
+func (c *Client) mustGetCallAccount(ctx context.Context) *types.Account {
+	if account, ok := AccountFromContext(ctx); ok {
+		return account
+	}
+	return c.MustGetDefaultAccount()
+}
+
+// withCallAccount returns the tx option which signs the tx by the account set by WithAccount, the option of the
+// caller is not modified and an explicit OverrideKeyManager is respected.
+func withCallAccount(ctx context.Context, txOpt *gnfdSdkTypes.TxOption) *gnfdSdkTypes.TxOption {
+	account, ok := AccountFromContext(ctx)
+	if !ok || (txOpt != nil && txOpt.OverrideKeyManager != nil) {
+		return txOpt
+	}
+	opt := gnfdSdkTypes.TxOption{}
+	if txOpt != nil {
+		opt = *txOpt
+	}
+	km := account.GetKeyManager()
+	opt.OverrideKeyManager = &km
+	return &opt
+}
+
 // GetAccount - Retrieve on-chain account information for a given address.
 //
 // - ctx: Context variables for the current API call.
@@ -80,7 +146,7 @@ func (c *Client) GetAccount(ctx context.Context, address string) (authTypes.Acco
 		return nil, err
 	}
 	// Call the DefaultAccount method of the chain Client with a QueryAccountRequest containing the address.
-	response, err := c.chainClient.Account(ctx, &authTypes.QueryAccountRequest{Address: accAddress.String()})
+	response, err := queryChain(ctx, c, "Account", (*sdkclient.MechainClient).Account, &authTypes.QueryAccountRequest{Address: accAddress.String()})
 	if err != nil {
 		// Return an error if there was an issue retrieving the account.
 		return nil, err
@@ -88,7 +154,7 @@ func (c *Client) GetAccount(ctx context.Context, address string) (authTypes.Acco
 
 	// Unmarshal the raw account data from the response into a BaseAccount object.
 	baseAccount := evmostypes.EthAccount{}
-	err = c.chainClient.GetCodec().Unmarshal(response.Account.GetValue(), &baseAccount)
+	err = c.chain().GetCodec().Unmarshal(response.Account.GetValue(), &baseAccount)
 	if err != nil {
 		// Return an error if there was an issue unmarshalling the account data.
 		return nil, err
@@ -137,13 +203,13 @@ func (c *Client) CreatePaymentAccount(ctx context.Context, address string, txOpt
 //
 // - ret2: Return error when getting failed, otherwise return nil.
 func (c *Client) GetModuleAccountByName(ctx context.Context, name string) (authTypes.ModuleAccountI, error) {
-	response, err := c.chainClient.ModuleAccountByName(ctx, &authTypes.QueryModuleAccountByNameRequest{Name: name})
+	response, err := queryChain(ctx, c, "ModuleAccountByName", (*sdkclient.MechainClient).ModuleAccountByName, &authTypes.QueryModuleAccountByNameRequest{Name: name})
 	if err != nil {
 		return nil, err
 	}
 	// Unmarshal the raw account data from the response into a BaseAccount object.
 	moduleAccount := authTypes.ModuleAccount{}
-	err = c.chainClient.GetCodec().Unmarshal(response.Account.GetValue(), &moduleAccount)
+	err = c.chain().GetCodec().Unmarshal(response.Account.GetValue(), &moduleAccount)
 	if err != nil {
 		// Return an error if there was an issue unmarshalling the account data.
 		return nil, err
@@ -161,14 +227,14 @@ func (c *Client) GetModuleAccountByName(ctx context.Context, name string) (authT
 //
 // - ret2: Return error when getting failed, otherwise return nil.
 func (c *Client) GetModuleAccounts(ctx context.Context) ([]authTypes.ModuleAccountI, error) {
-	response, err := c.chainClient.ModuleAccounts(ctx, &authTypes.QueryModuleAccountsRequest{})
+	response, err := queryChain(ctx, c, "ModuleAccounts", (*sdkclient.MechainClient).ModuleAccounts, &authTypes.QueryModuleAccountsRequest{})
 	if err != nil {
 		return nil, err
 	}
 	var accounts []authTypes.ModuleAccountI
 	for _, accValue := range response.Accounts {
 		moduleAccount := authTypes.ModuleAccount{}
-		err = c.chainClient.GetCodec().Unmarshal(accValue.Value, &moduleAccount)
+		err = c.chain().GetCodec().Unmarshal(accValue.Value, &moduleAccount)
 		if err != nil {
 			// Return an error if there was an issue unmarshalling the account data.
 			return nil, err
@@ -178,10 +244,33 @@ func (c *Client) GetModuleAccounts(ctx context.Context) ([]authTypes.ModuleAccou
 	return accounts, err
 }
 
-// GetAccountBalance - Get the bank balance for the given address.
+// GetMultisigAccount - Retrieve the multisig account of the given address according to its public key on chain.
+//
+// The public key of an account is stored on chain after it has sent its first transaction. To get the address of a
+// multisig account before that, use types.NewMultisigAccount with the public keys of its members.
 //
 // - ctx: Context variables for the current API call.
 //
+// - address: The HEX-encoded address of the multisig account.
+//
+// - ret1: The multisig account, which includes the threshold and the public keys of its members.
+//
+// - ret2: Return error when the account is not found or it is not a multisig account, otherwise return nil.
+func (c *Client) GetMultisigAccount(ctx context.Context, address string) (*types.MultisigAccount, error) {
+	account, err := c.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if account.GetPubKey() == nil {
+		return nil, fmt.Errorf("the public key of the account %s is not on chain yet, it has not sent any transaction", address)
+	}
+	return types.NewMultisigAccountFromPubKey(address, account.GetPubKey())
+}
+
+// GetAccountBalance - Get the bank balance for the given address.
+//
+// - ctx: Context variables for the current API call, the state at a past block height is queried if it is set by WithHeight.
+//
 // - address: The given address for retrieving.
 //
 // - ret1: The balance info for the given address, in sdk.Coin format.
@@ -192,7 +281,11 @@ func (c *Client) GetAccountBalance(ctx context.Context, address string) (*sdk.Co
 	if err != nil {
 		return nil, err
 	}
-	response, err := c.chainClient.BankQueryClient.Balance(ctx, &bankTypes.QueryBalanceRequest{Address: accAddress.String(), Denom: gnfdSdkTypes.Denom})
+	response, err := queryChain(ctx, c, "Balance", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *bankTypes.QueryBalanceRequest, opts ...grpc.CallOption,
+	) (*bankTypes.QueryBalanceResponse, error) {
+		return chain.BankQueryClient.Balance(ctx, req, opts...)
+	}, &bankTypes.QueryBalanceRequest{Address: accAddress.String(), Denom: gnfdSdkTypes.Denom})
 	if err != nil {
 		return nil, err
 	}
@@ -214,7 +307,7 @@ func (c *Client) GetPaymentAccount(ctx context.Context, address string) (*paymen
 	if err != nil {
 		return nil, err
 	}
-	pa, err := c.chainClient.PaymentAccount(ctx, &paymentTypes.QueryPaymentAccountRequest{Addr: accAddress.String()})
+	pa, err := queryChain(ctx, c, "PaymentAccount", (*sdkclient.MechainClient).PaymentAccount, &paymentTypes.QueryPaymentAccountRequest{Addr: accAddress.String()})
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +329,7 @@ func (c *Client) GetPaymentAccountsByOwner(ctx context.Context, owner string) ([
 		return nil, err
 	}
 	// Call the GetPaymentAccountsByOwner method of the chain Client with a QueryGetPaymentAccountsByOwnerRequest containing the owner address.
-	accountsByOwnerResponse, err := c.chainClient.PaymentAccountsByOwner(ctx, &paymentTypes.QueryPaymentAccountsByOwnerRequest{Owner: ownerAcc.String()})
+	accountsByOwnerResponse, err := queryChain(ctx, c, "PaymentAccountsByOwner", (*sdkclient.MechainClient).PaymentAccountsByOwner, &paymentTypes.QueryPaymentAccountsByOwnerRequest{Owner: ownerAcc.String()})
 	if err != nil {
 		return nil, err
 	}
@@ -277,7 +370,7 @@ func (c *Client) Transfer(ctx context.Context, toAddress string, amount math.Int
 	if err != nil {
 		return "", err
 	}
-	msgSend := bankTypes.NewMsgSend(c.MustGetDefaultAccount().GetAddress(), toAddr, sdk.Coins{sdk.Coin{Denom: gnfdSdkTypes.Denom, Amount: amount}})
+	msgSend := bankTypes.NewMsgSend(c.mustGetCallAccount(ctx).GetAddress(), toAddr, sdk.Coins{sdk.Coin{Denom: gnfdSdkTypes.Denom, Amount: amount}})
 	tx, err := c.BroadcastTx(ctx, []sdk.Msg{msgSend}, &txOption)
 	if err != nil {
 		return "", err
@@ -315,7 +408,7 @@ func (c *Client) MultiTransfer(ctx context.Context, details []types.TransferDeta
 		sum = sum.Add(details[i].Amount)
 	}
 	in := bankTypes.Input{
-		Address: c.MustGetDefaultAccount().GetAddress().String(),
+		Address: c.mustGetCallAccount(ctx).GetAddress().String(),
 		Coins:   []sdk.Coin{{Denom: denom, Amount: sum}},
 	}
 	msg := &bankTypes.MsgMultiSend{
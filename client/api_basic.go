@@ -16,8 +16,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	"github.com/evmos/evmos/v12/sdk/types"
 	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
 	gosdktypes "github.com/zkMeLabs/mechain-go-sdk/types"
@@ -35,6 +37,7 @@ type IBasicClient interface {
 	GetSyncing(ctx context.Context) (bool, error)
 	GetBlockByHeight(ctx context.Context, height int64) (*bfttypes.Block, error)
 	GetBlockResultByHeight(ctx context.Context, height int64) (*ctypes.ResultBlockResults, error)
+	GetBlockHeightByTime(ctx context.Context, t time.Time) (int64, error)
 
 	GetValidatorSet(ctx context.Context) (int64, []*bfttypes.Validator, error)
 	GetValidatorsByHeight(ctx context.Context, height int64) ([]*bfttypes.Validator, error)
@@ -48,6 +51,11 @@ type IBasicClient interface {
 	SimulateRawTx(ctx context.Context, txBytes []byte, opts ...grpc.CallOption) (*tx.SimulateResponse, error)
 	BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error)
 	BroadcastRawTx(ctx context.Context, txBytes []byte, sync bool) (*sdk.TxResponse, error)
+	DryRunTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption) (*gosdktypes.DryRunResult, error)
+	DryRunRawTx(ctx context.Context, txBytes []byte) (*gosdktypes.DryRunResult, error)
+
+	BuildUnsignedTx(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption) ([]byte, *gosdktypes.TxSignerData, error)
+	BroadcastSignedTx(ctx context.Context, signedTx []byte, sync bool) (*sdk.TxResponse, error)
 
 	BroadcastVote(ctx context.Context, vote votepool.Vote) error
 	QueryVote(ctx context.Context, eventType int, eventHash []byte) (*ctypes.ResultQueryVote, error)
@@ -76,7 +84,11 @@ func (c *Client) EnableTrace(output io.Writer, onlyTraceErr bool) {
 //
 // - ret3: Return error when the request failed, otherwise return nil.
 func (c *Client) GetNodeInfo(ctx context.Context) (*p2p.DefaultNodeInfo, *tmservice.VersionInfo, error) {
-	nodeInfoResponse, err := c.chainClient.TmClient.GetNodeInfo(ctx, &tmservice.GetNodeInfoRequest{})
+	nodeInfoResponse, err := queryChain(ctx, c, "GetNodeInfo", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *tmservice.GetNodeInfoRequest, opts ...grpc.CallOption,
+	) (*tmservice.GetNodeInfoResponse, error) {
+		return chain.TmClient.GetNodeInfo(ctx, req, opts...)
+	}, &tmservice.GetNodeInfoRequest{})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -91,7 +103,9 @@ func (c *Client) GetNodeInfo(ctx context.Context) (*p2p.DefaultNodeInfo, *tmserv
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetStatus(ctx context.Context) (*ctypes.ResultStatus, error) {
-	return c.chainClient.GetStatus(ctx)
+	return callChain(ctx, c, "GetStatus", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultStatus, error) {
+		return endpoint.client.GetStatus(ctx)
+	})
 }
 
 // GetCommit - Get the block commit detail.
@@ -104,7 +118,9 @@ func (c *Client) GetStatus(ctx context.Context) (*ctypes.ResultStatus, error) {
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetCommit(ctx context.Context, height int64) (*ctypes.ResultCommit, error) {
-	return c.chainClient.GetCommit(ctx, height)
+	return callChain(ctx, c, "GetCommit", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultCommit, error) {
+		return endpoint.client.GetCommit(ctx, height)
+	})
 }
 
 // BroadcastRawTx - Broadcast raw transaction bytes to a Tendermint node.
@@ -119,13 +135,24 @@ func (c *Client) GetCommit(ctx context.Context, height int64) (*ctypes.ResultCom
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) BroadcastRawTx(ctx context.Context, txBytes []byte, sync bool) (*sdk.TxResponse, error) {
+	if c.dryRun {
+		result, err := c.dryRunRawTx(ctx, txBytes)
+		if err != nil {
+			return nil, err
+		}
+		return dryRunTxResponse(result), nil
+	}
 	var mode tx.BroadcastMode
 	if sync {
 		mode = tx.BroadcastMode_BROADCAST_MODE_SYNC
 	} else {
 		mode = tx.BroadcastMode_BROADCAST_MODE_ASYNC
 	}
-	broadcastTxResponse, err := c.chainClient.TxClient.BroadcastTx(ctx, &tx.BroadcastTxRequest{TxBytes: txBytes, Mode: mode})
+	broadcastTxResponse, err := queryChain(ctx, c, "BroadcastRawTx", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *tx.BroadcastTxRequest, opts ...grpc.CallOption,
+	) (*tx.BroadcastTxResponse, error) {
+		return chain.TxClient.BroadcastTx(ctx, req, opts...)
+	}, &tx.BroadcastTxRequest{TxBytes: txBytes, Mode: mode})
 	if err != nil {
 		return nil, err
 	}
@@ -144,8 +171,11 @@ func (c *Client) BroadcastRawTx(ctx context.Context, txBytes []byte, sync bool)
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) SimulateRawTx(ctx context.Context, txBytes []byte, opts ...grpc.CallOption) (*tx.SimulateResponse, error) {
-	simulateResponse, err := c.chainClient.TxClient.Simulate(
-		ctx,
+	simulateResponse, err := queryChain(ctx, c, "SimulateRawTx", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *tx.SimulateRequest, opts ...grpc.CallOption,
+	) (*tx.SimulateResponse, error) {
+		return chain.TxClient.Simulate(ctx, req, opts...)
+	},
 		&tx.SimulateRequest{
 			TxBytes: txBytes,
 		},
@@ -165,7 +195,9 @@ func (c *Client) SimulateRawTx(ctx context.Context, txBytes []byte, opts ...grpc
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetLatestBlock(ctx context.Context) (*bfttypes.Block, error) {
-	res, err := c.chainClient.GetBlock(ctx, nil)
+	res, err := callChain(ctx, c, "GetBlock", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultBlock, error) {
+		return endpoint.client.GetBlock(ctx, nil)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +212,7 @@ func (c *Client) GetLatestBlock(ctx context.Context) (*bfttypes.Block, error) {
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetLatestBlockHeight(ctx context.Context) (int64, error) {
-	resp, err := c.chainClient.GetStatus(ctx)
+	resp, err := c.GetStatus(ctx)
 	if err != nil {
 		return 0, nil
 	}
@@ -245,8 +277,21 @@ func (c *Client) WaitForNBlocks(ctx context.Context, n int64) error {
 // - ret1: The transaction result details.
 //
 // - ret2: Return error when the request failed, otherwise return nil.
-func (c *Client) WaitForTx(ctx context.Context, hash string) (*ctypes.ResultTx, error) {
-	for {
+func (c *Client) WaitForTx(ctx context.Context, hash string) (_ *ctypes.ResultTx, err error) {
+	if err := c.checkDryRunWait(hash); err != nil {
+		return nil, err
+	}
+	var (
+		start   = time.Now()
+		retries int
+	)
+	ctx, span := c.startSpan(ctx, "WaitForTx", AttrTxHash.String(hash))
+	defer func() {
+		endSpan(span, err)
+		c.recordWaitForTx(start, retries, err)
+	}()
+
+	for ; ; retries++ {
 		var (
 			txResponse *ctypes.ResultTx
 			err        error
@@ -256,11 +301,11 @@ func (c *Client) WaitForTx(ctx context.Context, hash string) (*ctypes.ResultTx,
 
 		// when websocket conn is used, use a short timeout context to achieve the retry mechanism
 		if c.useWebsocketConn {
-			waitTxCtx, cancelFunc = context.WithTimeout(context.Background(), gosdktypes.WaitTxContextTimeOut)
-			txResponse, err = c.chainClient.Tx(waitTxCtx, hash)
+			waitTxCtx, cancelFunc = context.WithTimeout(trace.ContextWithSpan(context.Background(), span), gosdktypes.WaitTxContextTimeOut)
+			txResponse, err = c.getTx(waitTxCtx, hash)
 			cancelFunc()
 		} else {
-			txResponse, err = c.chainClient.Tx(ctx, hash)
+			txResponse, err = c.getTx(ctx, hash)
 		}
 		if err != nil {
 			// Tx not found, wait for next block and try again
@@ -284,24 +329,34 @@ func (c *Client) WaitForTx(ctx context.Context, hash string) (*ctypes.ResultTx,
 			continue
 		}
 		// Tx found
+		span.SetAttributes(AttrTxCode.Int64(int64(txResponse.TxResult.Code)))
 		return txResponse, nil
 	}
 }
 
+// getTx queries the transaction by its hash from the chain.
+func (c *Client) getTx(ctx context.Context, hash string) (*ctypes.ResultTx, error) {
+	return callChain(ctx, c, "Tx", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultTx, error) {
+		return endpoint.client.Tx(ctx, hash)
+	})
+}
+
 // BroadcastTx - Broadcast a transaction containing the provided message(s) to the chain.
 //
 // - ctx: Context variables for the current API call.
 //
 // - msgs: Message(s) to be broadcast to blockchain.
 //
-// - txOpt: txOpt contains options for customizing the transaction.
+// - txOpt: txOpt contains options for customizing the transaction. The sequence of the transaction is handed out by
+// the Client unless it is set by WithSequence or txOpt.Nonce, the transactions rejected for the sequence mismatch are
+// resent with the sequence expected by the chain.
 //
 // - opts: The grpc option(s) if Client is using grpc connection.
 //
 // - ret1: transaction response, it can indicate both success and failed transaction.
 //
 // - ret2: Return error when the request failed, otherwise return nil.
-func (c *Client) BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
+func (c *Client) BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption, opts ...grpc.CallOption) (_ *tx.BroadcastTxResponse, err error) {
 	if len(msgs) == 0 {
 		return nil, fmt.Errorf("msg is not provided in the transaction")
 	}
@@ -310,12 +365,32 @@ func (c *Client) BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.T
 			return nil, err
 		}
 	}
-	resp, err := c.chainClient.BroadcastTx(ctx, msgs, txOpt, opts...)
+	ctx, span := c.startChainSpan(ctx, "BroadcastTx", AttrMsgCount.Int(len(msgs)))
+	defer func() { endSpan(span, err) }()
+
+	if c.dryRun {
+		result, err := c.dryRunTx(ctx, msgs, withCallAccount(ctx, txOpt))
+		if err != nil {
+			return nil, err
+		}
+		return &tx.BroadcastTxResponse{TxResponse: dryRunTxResponse(result)}, nil
+	}
+
+	resp, err := c.broadcastTx(ctx, msgs, withCallAccount(ctx, txOpt), opts...)
 	if err != nil {
+		c.recordTxBroadcast(nil)
 		return nil, err
 	}
+	c.recordTxBroadcast(resp.TxResponse)
+	span.SetAttributes(AttrTxHash.String(resp.TxResponse.TxHash), AttrTxCode.Int64(int64(resp.TxResponse.Code)))
 	if resp.TxResponse.Code != 0 {
-		return resp, fmt.Errorf("the tx has failed with response code: %d, codespace:%s", resp.TxResponse.Code, resp.TxResponse.Codespace)
+		return resp, &gosdktypes.TxFailedError{
+			Op:        "tx",
+			TxHash:    resp.TxResponse.TxHash,
+			Code:      resp.TxResponse.Code,
+			Codespace: resp.TxResponse.Codespace,
+			Log:       resp.TxResponse.RawLog,
+		}
 	}
 	return resp, nil
 }
@@ -334,7 +409,10 @@ func (c *Client) BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.T
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) SimulateTx(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption, opts ...grpc.CallOption) (*tx.SimulateResponse, error) {
-	return c.chainClient.SimulateTx(ctx, msgs, &txOpt, opts...)
+	ctx, span := c.startChainSpan(ctx, "SimulateTx", AttrMsgCount.Int(len(msgs)))
+	resp, err := c.chain().SimulateTx(ctx, msgs, withCallAccount(ctx, &txOpt), opts...)
+	endSpan(span, err)
+	return resp, err
 }
 
 // GetSyncing - Retrieve the syncing status of the node.
@@ -345,7 +423,7 @@ func (c *Client) SimulateTx(ctx context.Context, msgs []sdk.Msg, txOpt types.TxO
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetSyncing(ctx context.Context) (bool, error) {
-	syncing, err := c.chainClient.GetSyncing(ctx, &tmservice.GetSyncingRequest{})
+	syncing, err := queryChain(ctx, c, "GetSyncing", (*sdkclient.MechainClient).GetSyncing, &tmservice.GetSyncingRequest{})
 	if err != nil {
 		return false, err
 	}
@@ -362,7 +440,9 @@ func (c *Client) GetSyncing(ctx context.Context) (bool, error) {
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetBlockByHeight(ctx context.Context, height int64) (*bfttypes.Block, error) {
-	blockByHeight, err := c.chainClient.GetBlock(ctx, &height)
+	blockByHeight, err := callChain(ctx, c, "GetBlock", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultBlock, error) {
+		return endpoint.client.GetBlock(ctx, &height)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -379,7 +459,9 @@ func (c *Client) GetBlockByHeight(ctx context.Context, height int64) (*bfttypes.
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetBlockResultByHeight(ctx context.Context, height int64) (*ctypes.ResultBlockResults, error) {
-	return c.chainClient.GetBlockResults(ctx, &height)
+	return callChain(ctx, c, "GetBlockResults", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultBlockResults, error) {
+		return endpoint.client.GetBlockResults(ctx, &height)
+	})
 }
 
 // GetValidatorSet - Retrieve the latest validator set from the chain.
@@ -392,7 +474,9 @@ func (c *Client) GetBlockResultByHeight(ctx context.Context, height int64) (*cty
 //
 // - ret3: Return error when the request failed, otherwise return nil.
 func (c *Client) GetValidatorSet(ctx context.Context) (int64, []*bfttypes.Validator, error) {
-	validatorSetResponse, err := c.chainClient.GetValidators(ctx, nil)
+	validatorSetResponse, err := callChain(ctx, c, "GetValidators", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultValidators, error) {
+		return endpoint.client.GetValidators(ctx, nil)
+	})
 	if err != nil {
 		return 0, nil, err
 	}
@@ -409,7 +493,9 @@ func (c *Client) GetValidatorSet(ctx context.Context) (int64, []*bfttypes.Valida
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetValidatorsByHeight(ctx context.Context, height int64) ([]*bfttypes.Validator, error) {
-	validatorSetResponse, err := c.chainClient.GetValidators(ctx, &height)
+	validatorSetResponse, err := callChain(ctx, c, "GetValidators", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultValidators, error) {
+		return endpoint.client.GetValidators(ctx, &height)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -424,7 +510,13 @@ func (c *Client) GetValidatorsByHeight(ctx context.Context, height int64) ([]*bf
 //
 // - ret: Return error when the request failed, otherwise return nil.
 func (c *Client) BroadcastVote(ctx context.Context, vote votepool.Vote) error {
-	return c.chainClient.BroadcastVote(ctx, vote)
+	if c.dryRun {
+		return fmt.Errorf("%w: the vote is not broadcast", gosdktypes.ErrDryRun)
+	}
+	ctx, span := c.startChainSpan(ctx, "BroadcastVote")
+	err := c.chain().BroadcastVote(ctx, vote)
+	endSpan(span, err)
+	return err
 }
 
 // QueryVote - Query a vote from the Node's VotePool, it is used by Mechain relayer and challengers by now.
@@ -439,7 +531,9 @@ func (c *Client) BroadcastVote(ctx context.Context, vote votepool.Vote) error {
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) QueryVote(ctx context.Context, eventType int, eventHash []byte) (*ctypes.ResultQueryVote, error) {
-	return c.chainClient.QueryVote(ctx, eventType, eventHash)
+	return callChain(ctx, c, "QueryVote", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultQueryVote, error) {
+		return endpoint.client.QueryVote(ctx, eventType, eventHash)
+	})
 }
 
 // SetTag - Set tag for a given existing resource GRN (a bucket, a object or a group)
@@ -458,7 +552,7 @@ func (c *Client) QueryVote(ctx context.Context, eventType int, eventHash []byte)
 //
 // - ret2: Return error if SetTag failed, otherwise return nil.
 func (c *Client) SetTag(ctx context.Context, resourceGRN string, tags storageTypes.ResourceTags, opts gosdktypes.SetTagsOptions) (string, error) {
-	msgSetTag := storageTypes.NewMsgSetTag(c.MustGetDefaultAccount().GetAddress(), resourceGRN, &tags)
+	msgSetTag := storageTypes.NewMsgSetTag(c.mustGetCallAccount(ctx).GetAddress(), resourceGRN, &tags)
 	resp, err := c.BroadcastTx(ctx, []sdk.Msg{msgSetTag}, opts.TxOpts)
 	if err != nil {
 		return "", err
@@ -19,11 +19,11 @@ import (
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
-	"github.com/rs/zerolog/log"
 
 	"github.com/zkMeLabs/mechain-go-sdk/pkg/utils"
 	"github.com/zkMeLabs/mechain-go-sdk/types"
 
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdsdk "github.com/evmos/evmos/v12/sdk/types"
 	gnfdTypes "github.com/evmos/evmos/v12/types"
 	"github.com/evmos/evmos/v12/types/s3util"
@@ -36,6 +36,7 @@ import (
 type IBucketClient interface {
 	GetCreateBucketApproval(ctx context.Context, createBucketMsg *storageTypes.MsgCreateBucket) (*storageTypes.MsgCreateBucket, error)
 	CreateBucket(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (string, error)
+	CreateBucketWithResult(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (*types.CreateBucketResult, error)
 	DeleteBucket(ctx context.Context, bucketName string, opt types.DeleteBucketOption) (string, error)
 	UpdateBucketVisibility(ctx context.Context, bucketName string, visibility storageTypes.VisibilityType, opt types.UpdateVisibilityOption) (string, error)
 	UpdateBucketInfo(ctx context.Context, bucketName string, opts types.UpdateBucketOptions) (string, error)
@@ -97,7 +98,7 @@ func (c *Client) GetCreateBucketApproval(ctx context.Context, createBucketMsg *s
 	primarySPAddr := createBucketMsg.GetPrimarySpAddress()
 	endpoint, err := c.getSPUrlByAddr(primarySPAddr)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("route endpoint by addr: %s failed, err: %s", primarySPAddr, err.Error()))
+		c.logger.Error("route endpoint by sp address failed", "sp_address", primarySPAddr, "error", err)
 		return nil, err
 	}
 
@@ -139,9 +140,41 @@ func (c *Client) GetCreateBucketApproval(ctx context.Context, createBucketMsg *s
 //
 // - ret2: Return error if create bucket failed, otherwise return nil.
 func (c *Client) CreateBucket(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (string, error) {
+	result, err := c.CreateBucketWithResult(ctx, bucketName, primaryAddr, opts)
+	if result == nil {
+		return "", err
+	}
+	return result.TxHash, err
+}
+
+// CreateBucketWithResult - Create a new bucket in mechain, and return the event of creating the bucket.
+//
+// It is the same as CreateBucket, except that the ID, the owner and the payment address of the new bucket are
+// decoded from the transaction result, so that no HeadBucket query is needed.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The name of the bucket to be created.
+//
+// - primaryAddr: The primary SP address to which the bucket will be created on.
+//
+// - opts: The Options indicates the meta to construct createBucket msg and the way to send transaction
+//
+// - ret1: The transaction hash and the event of creating the bucket, the event is nil in asynchronous mode.
+//
+// - ret2: Return error if create bucket failed, otherwise return nil.
+func (c *Client) CreateBucketWithResult(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (result *types.CreateBucketResult, err error) {
+	ctx, span := c.startSpan(ctx, "CreateBucket", bucketAttrs(bucketName, "")...)
+	defer func() {
+		if result != nil {
+			span.SetAttributes(AttrTxHash.String(result.TxHash))
+		}
+		endSpan(span, err)
+	}()
+
 	address, err := sdk.AccAddressFromHexUnsafe(primaryAddr)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var visibility storageTypes.VisibilityType
@@ -155,34 +188,34 @@ func (c *Client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 	if opts.PaymentAddress != "" {
 		paymentAddr, err = sdk.AccAddressFromHexUnsafe(opts.PaymentAddress)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 
-	createBucketMsg := storageTypes.NewMsgCreateBucket(c.MustGetDefaultAccount().GetAddress(), bucketName, visibility, address, paymentAddr, 0, nil, opts.ChargedQuota)
+	createBucketMsg := storageTypes.NewMsgCreateBucket(c.mustGetCallAccount(ctx).GetAddress(), bucketName, visibility, address, paymentAddr, 0, nil, opts.ChargedQuota)
 
 	err = createBucketMsg.ValidateBasic()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	accAddress, err := sdk.AccAddressFromHexUnsafe(primaryAddr)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	sp, err := c.GetStorageProviderInfo(ctx, accAddress)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	familyID, err := c.GetRecommendedVirtualGroupFamilyIDBySPID(ctx, sp.Id)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("failed to query sp vgf:  %s", err.Error()))
+		c.logger.Error("query sp virtual group family failed", "sp_id", sp.Id, "error", err)
 		var signedMsg *storageTypes.MsgCreateBucket
 		signedMsg, err = c.GetCreateBucketApproval(ctx, createBucketMsg)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		familyID = signedMsg.PrimarySpApproval.GlobalVirtualGroupFamilyId
 	}
@@ -199,26 +232,38 @@ func (c *Client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 	if opts.Tags != nil {
 		// Set tag
 		grn := gnfdTypes.NewBucketGRN(bucketName)
-		msgSetTag := storageTypes.NewMsgSetTag(c.MustGetDefaultAccount().GetAddress(), grn.String(), opts.Tags)
+		msgSetTag := storageTypes.NewMsgSetTag(c.mustGetCallAccount(ctx).GetAddress(), grn.String(), opts.Tags)
 		msgs = append(msgs, msgSetTag)
 	}
 	resp, err := c.BroadcastTx(ctx, msgs, opts.TxOpts)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	txnHash := resp.TxResponse.TxHash
-	if !opts.IsAsyncMode {
+	result = &types.CreateBucketResult{TxHash: txnHash}
+	if !opts.IsAsyncMode && !c.dryRun {
 		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
 		defer cancel()
 		txnResponse, err := c.WaitForTx(ctxTimeout, txnHash)
 		if err != nil {
-			return txnHash, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
+			return result, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
 		}
 		if txnResponse.TxResult.Code != 0 {
-			return txnHash, fmt.Errorf("the createBucket txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
+			return result, &types.TxFailedError{
+				Op:        "createBucket txn",
+				TxHash:    txnHash,
+				Code:      txnResponse.TxResult.Code,
+				Codespace: txnResponse.TxResult.Codespace,
+				Log:       txnResponse.TxResult.Log,
+			}
 		}
+		txResult, err := DecodeTxResult(txnResponse)
+		if err != nil {
+			return result, err
+		}
+		result.Event, _ = types.FindTxEvent[*storageTypes.EventCreateBucket](txResult)
 	}
-	return txnHash, nil
+	return result, nil
 }
 
 // DeleteBucket - Send DeleteBucket msg to mechain chain and return txn hash.
@@ -232,11 +277,17 @@ func (c *Client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 // - ret1: Transaction hash return from blockchain.
 //
 // - ret2: Return error if delete bucket failed, otherwise return nil.
-func (c *Client) DeleteBucket(ctx context.Context, bucketName string, opt types.DeleteBucketOption) (string, error) {
+func (c *Client) DeleteBucket(ctx context.Context, bucketName string, opt types.DeleteBucketOption) (txHash string, err error) {
+	ctx, span := c.startSpan(ctx, "DeleteBucket", bucketAttrs(bucketName, "")...)
+	defer func() {
+		span.SetAttributes(AttrTxHash.String(txHash))
+		endSpan(span, err)
+	}()
+
 	if err := s3util.CheckValidBucketName(bucketName); err != nil {
 		return "", err
 	}
-	delBucketMsg := storageTypes.NewMsgDeleteBucket(c.MustGetDefaultAccount().GetAddress(), bucketName)
+	delBucketMsg := storageTypes.NewMsgDeleteBucket(c.mustGetCallAccount(ctx).GetAddress(), bucketName)
 	return c.sendTxn(ctx, delBucketMsg, opt.TxOpts)
 }
 
@@ -266,7 +317,7 @@ func (c *Client) UpdateBucketVisibility(ctx context.Context, bucketName string,
 		return "", err
 	}
 
-	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.MustGetDefaultAccount().GetAddress(), bucketName, &bucketInfo.ChargedReadQuota, paymentAddr, visibility)
+	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.mustGetCallAccount(ctx).GetAddress(), bucketName, &bucketInfo.ChargedReadQuota, paymentAddr, visibility)
 	return c.sendTxn(ctx, updateBucketMsg, opt.TxOpts)
 }
 
@@ -291,7 +342,7 @@ func (c *Client) UpdateBucketPaymentAddr(ctx context.Context, bucketName string,
 		return "", err
 	}
 
-	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.MustGetDefaultAccount().GetAddress(), bucketName, &bucketInfo.ChargedReadQuota, paymentAddr, bucketInfo.Visibility)
+	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.mustGetCallAccount(ctx).GetAddress(), bucketName, &bucketInfo.ChargedReadQuota, paymentAddr, bucketInfo.Visibility)
 	return c.sendTxn(ctx, updateBucketMsg, opt.TxOpts)
 }
 
@@ -315,7 +366,7 @@ func (c *Client) UpdateBucketPaymentAddr(ctx context.Context, bucketName string,
 func (c *Client) SetBucketFlowRateLimit(ctx context.Context, bucketName string,
 	paymentAddr, bucketOwner sdk.AccAddress, flowRateLimit sdkmath.Int, opt types.SetBucketFlowRateLimitOption,
 ) (string, error) {
-	updateBucketMsg := storageTypes.NewMsgSetBucketFlowRateLimit(c.MustGetDefaultAccount().GetAddress(), bucketOwner, paymentAddr, bucketName, flowRateLimit)
+	updateBucketMsg := storageTypes.NewMsgSetBucketFlowRateLimit(c.mustGetCallAccount(ctx).GetAddress(), bucketOwner, paymentAddr, bucketName, flowRateLimit)
 	return c.sendTxn(ctx, updateBucketMsg, opt.TxOpts)
 }
 
@@ -339,7 +390,7 @@ func (c *Client) GetPaymentAccountFlowRateLimit(ctx context.Context, paymentAddr
 		BucketName:     bucketName,
 	}
 
-	queryFlowRateLimitResp, err := c.chainClient.QueryPaymentAccountBucketFlowRateLimit(ctx, &queryFlowRateLimit)
+	queryFlowRateLimitResp, err := queryChain(ctx, c, "QueryPaymentAccountBucketFlowRateLimit", (*sdkclient.MechainClient).QueryPaymentAccountBucketFlowRateLimit, &queryFlowRateLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -398,7 +449,7 @@ func (c *Client) UpdateBucketInfo(ctx context.Context, bucketName string, opts t
 		chargedReadQuota = bucketInfo.ChargedReadQuota
 	}
 
-	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.MustGetDefaultAccount().GetAddress(), bucketName,
+	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.mustGetCallAccount(ctx).GetAddress(), bucketName,
 		&chargedReadQuota, paymentAddr, visibility)
 
 	// set the default txn broadcast mode as block mode
@@ -416,13 +467,13 @@ func (c *Client) ToggleSPAsDelegatedAgent(ctx context.Context, bucketName string
 	if err != nil {
 		return "", err
 	}
-	msg := storageTypes.NewMsgToggleSPAsDelegatedAgent(c.MustGetDefaultAccount().GetAddress(), bucketName)
+	msg := storageTypes.NewMsgToggleSPAsDelegatedAgent(c.mustGetCallAccount(ctx).GetAddress(), bucketName)
 	return c.sendTxn(ctx, msg, opt.TxOpts)
 }
 
 // HeadBucket - query the bucketInfo on chain by bucket name, return the bucket info if exists.
 //
-// - ctx: Context variables for the current API call.
+// - ctx: Context variables for the current API call, the state at a past block height is queried if it is set by WithHeight.
 //
 // - bucketName: The name of the bucket to query.
 //
@@ -430,14 +481,26 @@ func (c *Client) ToggleSPAsDelegatedAgent(ctx context.Context, bucketName string
 //
 // - ret2: Return error if bucket not exist, otherwise return nil.
 func (c *Client) HeadBucket(ctx context.Context, bucketName string) (*storageTypes.BucketInfo, error) {
+	if c.verifier != nil {
+		var err error
+		if ctx, err = c.verifiedQueryContext(ctx); err != nil {
+			return nil, err
+		}
+	}
 	queryHeadBucketRequest := storageTypes.QueryHeadBucketRequest{
 		BucketName: bucketName,
 	}
-	queryHeadBucketResponse, err := c.chainClient.HeadBucket(ctx, &queryHeadBucketRequest)
+	queryHeadBucketResponse, err := queryChain(ctx, c, "HeadBucket", (*sdkclient.MechainClient).HeadBucket, &queryHeadBucketRequest)
 	if err != nil {
+		if c.verifier != nil {
+			return nil, c.verifyNotFound(ctx, err, bucketName, "")
+		}
 		return nil, err
 	}
 
+	if c.verifier != nil {
+		return c.verifyBucketInfo(ctx, bucketName, queryHeadBucketResponse.BucketInfo)
+	}
 	return queryHeadBucketResponse.BucketInfo, nil
 }
 
@@ -455,7 +518,7 @@ func (c *Client) HeadBucketByID(ctx context.Context, bucketID string) (*storageT
 		BucketId: bucketID,
 	}
 
-	headBucketResponse, err := c.chainClient.HeadBucketById(ctx, headBucketRequest)
+	headBucketResponse, err := queryChain(ctx, c, "HeadBucketById", (*sdkclient.MechainClient).HeadBucketById, headBucketRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -487,7 +550,7 @@ func (c *Client) PutBucketPolicy(ctx context.Context, bucketName string, princip
 		return "", err
 	}
 
-	putPolicyMsg := storageTypes.NewMsgPutPolicy(c.MustGetDefaultAccount().GetAddress(), resource.String(),
+	putPolicyMsg := storageTypes.NewMsgPutPolicy(c.mustGetCallAccount(ctx).GetAddress(), resource.String(),
 		principal, statements, opt.PolicyExpireTime)
 
 	return c.sendPutPolicyTxn(ctx, putPolicyMsg, opt.TxOpts)
@@ -513,7 +576,7 @@ func (c *Client) DeleteBucketPolicy(ctx context.Context, bucketName string, prin
 		return "", err
 	}
 
-	return c.sendDelPolicyTxn(ctx, c.MustGetDefaultAccount().GetAddress(), resource, principal, opt.TxOpts)
+	return c.sendDelPolicyTxn(ctx, c.mustGetCallAccount(ctx).GetAddress(), resource, principal, opt.TxOpts)
 }
 
 // IsBucketPermissionAllowed - Check if the permission of bucket is allowed to the user.
@@ -542,7 +605,7 @@ func (c *Client) IsBucketPermissionAllowed(ctx context.Context, userAddr string,
 		ActionType: action,
 	}
 
-	verifyResp, err := c.chainClient.VerifyPermission(ctx, &verifyReq)
+	verifyResp, err := queryChain(ctx, c, "VerifyPermission", (*sdkclient.MechainClient).VerifyPermission, &verifyReq)
 	if err != nil {
 		return permTypes.EFFECT_DENY, err
 	}
@@ -552,6 +615,8 @@ func (c *Client) IsBucketPermissionAllowed(ctx context.Context, userAddr string,
 
 // GetBucketPolicy - Get the bucket policy info of the user specified by principalAddr.
 //
+// - ctx: Context variables for the current API call, the state at a past block height is queried if it is set by WithHeight.
+//
 // - bucketName: The bucket name identifies the bucket.
 //
 // - principalAddr: The HEX-encoded string of the principal address.
@@ -560,10 +625,15 @@ func (c *Client) IsBucketPermissionAllowed(ctx context.Context, userAddr string,
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetBucketPolicy(ctx context.Context, bucketName string, principalAddr string) (*permTypes.Policy, error) {
-	_, err := sdk.AccAddressFromHexUnsafe(principalAddr)
+	principal, err := sdk.AccAddressFromHexUnsafe(principalAddr)
 	if err != nil {
 		return nil, err
 	}
+	if c.verifier != nil {
+		if ctx, err = c.verifiedQueryContext(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	resource := gnfdTypes.NewBucketGRN(bucketName).String()
 	queryPolicy := storageTypes.QueryPolicyForAccountRequest{
@@ -571,11 +641,22 @@ func (c *Client) GetBucketPolicy(ctx context.Context, bucketName string, princip
 		PrincipalAddress: principalAddr,
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForAccount(ctx, &queryPolicy)
+	queryPolicyResp, err := queryChain(ctx, c, "QueryPolicyForAccount", (*sdkclient.MechainClient).QueryPolicyForAccount, &queryPolicy)
 	if err != nil {
+		if c.verifier != nil {
+			return nil, c.verifyPolicyNotFound(ctx, err, bucketName, principal)
+		}
 		return nil, err
 	}
 
+	if c.verifier != nil {
+		// the bucket is verified at the same height to locate the policy
+		bucketInfo, err := c.HeadBucket(ctx, bucketName)
+		if err != nil {
+			return nil, err
+		}
+		return c.verifyBucketPolicy(ctx, bucketName, bucketInfo, principal, queryPolicyResp.Policy)
+	}
 	return queryPolicyResp.Policy, nil
 }
 
@@ -605,7 +686,7 @@ func (m *listBucketsByIDsResponse) UnmarshalXML(d *xml.Decoder, start xml.StartE
 
 // ListBuckets - Lists the bucket info of the user.
 //
-// If the opts.Account is not set, the user is default set as the sender.
+// If the opts.Account is not set, the account set by WithAccount, or the default account if there is none, is listed.
 //
 // - ctx: Context variables for the current API call.
 //
@@ -620,9 +701,9 @@ func (c *Client) ListBuckets(ctx context.Context, opts types.ListBucketsOptions)
 
 	account := opts.Account
 	if account == "" {
-		acc, err := c.GetDefaultAccount()
+		acc, err := c.getCallAccount(ctx)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("failed to get default account:  %s", err.Error()))
+			c.logger.Error("get call account failed", "error", err)
 			return types.ListBucketsResult{}, err
 		}
 		account = acc.GetAddress().String()
@@ -649,13 +730,13 @@ func (c *Client) ListBuckets(ctx context.Context, opts types.ListBucketsOptions)
 		SPAddress: opts.SPAddress,
 	})
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return types.ListBucketsResult{}, err
 	}
 
 	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
 	if err != nil {
-		log.Error().Msg("the list of user's buckets failed: " + err.Error())
+		c.logger.Error("list user buckets failed", "error", err)
 		return types.ListBucketsResult{}, err
 	}
 	defer utils.CloseResponse(resp)
@@ -665,7 +746,7 @@ func (c *Client) ListBuckets(ctx context.Context, opts types.ListBucketsOptions)
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msg("the list of user's buckets failed: " + err.Error())
+		c.logger.Error("list user buckets failed", "error", err)
 		return types.ListBucketsResult{}, err
 	}
 
@@ -737,7 +818,7 @@ func (c *Client) ListBucketReadRecord(ctx context.Context, bucketName string, op
 
 	endpoint, err := c.getSPUrlByBucket(bucketName)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
+		c.logger.Error("route endpoint by bucket failed", "bucket", bucketName, "error", err)
 		return types.QuotaRecordInfo{}, err
 	}
 
@@ -796,7 +877,7 @@ func (c *Client) GetBucketReadQuota(ctx context.Context, bucketName string) (typ
 
 	endpoint, err := c.getSPUrlByBucket(bucketName)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
+		c.logger.Error("route endpoint by bucket failed", "bucket", bucketName, "error", err)
 		return types.QuotaInfo{}, err
 	}
 
@@ -826,7 +907,7 @@ func (c *Client) GetBucketReadQuota(ctx context.Context, bucketName string) (typ
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetQuotaUpdateTime(ctx context.Context, bucketName string) (int64, error) {
-	resp, err := c.chainClient.QueryQuotaUpdateTime(ctx, &storageTypes.QueryQuoteUpdateTimeRequest{
+	resp, err := queryChain(ctx, c, "QueryQuotaUpdateTime", (*sdkclient.MechainClient).QueryQuotaUpdateTime, &storageTypes.QueryQuoteUpdateTimeRequest{
 		BucketName: bucketName,
 	})
 	if err != nil {
@@ -856,7 +937,7 @@ func (c *Client) BuyQuotaForBucket(ctx context.Context, bucketName string, targe
 	if err != nil {
 		return "", err
 	}
-	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.MustGetDefaultAccount().GetAddress(), bucketName, &targetQuota, paymentAddr, bucketInfo.Visibility)
+	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.mustGetCallAccount(ctx).GetAddress(), bucketName, &targetQuota, paymentAddr, bucketInfo.Visibility)
 
 	resp, err := c.BroadcastTx(ctx, []sdk.Msg{updateBucketMsg}, opt.TxOpts)
 	if err != nil {
@@ -916,7 +997,7 @@ func (c *Client) ListBucketsByBucketID(ctx context.Context, bucketIds []uint64,
 
 	endpoint, err := c.getEndpointByOpt(&opts)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return types.ListBucketsByBucketIDResponse{}, err
 
 	}
@@ -931,7 +1012,7 @@ func (c *Client) ListBucketsByBucketID(ctx context.Context, bucketIds []uint64,
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msgf("the list of buckets in bucket ids:%v failed: %s", bucketIds, err.Error())
+		c.logger.Error("list buckets by ids failed", "bucket_ids", bucketIds, "error", err)
 		return types.ListBucketsByBucketIDResponse{}, err
 	}
 
@@ -939,7 +1020,7 @@ func (c *Client) ListBucketsByBucketID(ctx context.Context, bucketIds []uint64,
 	bufStr := buf.String()
 	err = xml.Unmarshal([]byte(bufStr), (*listBucketsByIDsResponse)(&buckets.Buckets))
 	if err != nil && buckets.Buckets == nil {
-		log.Error().Msgf("the list of buckets in bucket ids:%v failed: %s", bucketIds, err.Error())
+		c.logger.Error("list buckets by ids failed", "bucket_ids", bucketIds, "error", err)
 		return types.ListBucketsByBucketIDResponse{}, err
 	}
 
@@ -980,7 +1061,7 @@ func (c *Client) GetMigrateBucketApproval(ctx context.Context, migrateBucketMsg
 	primarySPID := migrateBucketMsg.DstPrimarySpId
 	endpoint, err := c.getSPUrlByID(primarySPID)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("route endpoint by addr: %d failed, err: %s", primarySPID, err.Error()))
+		c.logger.Error("route endpoint by sp id failed", "sp_id", primarySPID, "error", err)
 		return nil, err
 	}
 	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
@@ -1018,10 +1099,16 @@ func (c *Client) GetMigrateBucketApproval(ctx context.Context, migrateBucketMsg
 // - ret1: Transaction hash return from blockchain.
 //
 // - ret2: Return error when the request of getting approval or sending transaction failed, otherwise return nil.
-func (c *Client) MigrateBucket(ctx context.Context, bucketName string, dstPrimarySPID uint32, opts types.MigrateBucketOptions) (string, error) {
-	migrateBucketMsg := storageTypes.NewMsgMigrateBucket(c.MustGetDefaultAccount().GetAddress(), bucketName, dstPrimarySPID)
+func (c *Client) MigrateBucket(ctx context.Context, bucketName string, dstPrimarySPID uint32, opts types.MigrateBucketOptions) (txHash string, err error) {
+	ctx, span := c.startSpan(ctx, "MigrateBucket", append(bucketAttrs(bucketName, ""), AttrSPID.Int64(int64(dstPrimarySPID)))...)
+	defer func() {
+		span.SetAttributes(AttrTxHash.String(txHash))
+		endSpan(span, err)
+	}()
+
+	migrateBucketMsg := storageTypes.NewMsgMigrateBucket(c.mustGetCallAccount(ctx).GetAddress(), bucketName, dstPrimarySPID)
 
-	err := migrateBucketMsg.ValidateBasic()
+	err = migrateBucketMsg.ValidateBasic()
 	if err != nil {
 		return "", err
 	}
@@ -1041,7 +1128,7 @@ func (c *Client) MigrateBucket(ctx context.Context, bucketName string, dstPrimar
 		return "", err
 	}
 	txnHash := resp.TxResponse.TxHash
-	if !opts.IsAsyncMode {
+	if !opts.IsAsyncMode && !c.dryRun {
 		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
 		defer cancel()
 		txnResponse, err := c.WaitForTx(ctxTimeout, txnHash)
@@ -1049,7 +1136,13 @@ func (c *Client) MigrateBucket(ctx context.Context, bucketName string, dstPrimar
 			return txnHash, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
 		}
 		if txnResponse.TxResult.Code != 0 {
-			return txnHash, fmt.Errorf("the migrateBucket txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
+			return txnHash, &types.TxFailedError{
+				Op:        "migrateBucket txn",
+				TxHash:    txnHash,
+				Code:      txnResponse.TxResult.Code,
+				Codespace: txnResponse.TxResult.Codespace,
+				Log:       txnResponse.TxResult.Log,
+			}
 		}
 	}
 	return txnHash, nil
@@ -1066,10 +1159,16 @@ func (c *Client) MigrateBucket(ctx context.Context, bucketName string, dstPrimar
 // - ret1: Transaction hash return from blockchain.
 //
 // - ret2: Return error when the request of cancel migration failed, otherwise return nil.
-func (c *Client) CancelMigrateBucket(ctx context.Context, bucketName string, opts types.CancelMigrateBucketOptions) (string, error) {
-	cancelMigrateBucketMsg := storageTypes.NewMsgCancelMigrateBucket(c.MustGetDefaultAccount().GetAddress(), bucketName)
+func (c *Client) CancelMigrateBucket(ctx context.Context, bucketName string, opts types.CancelMigrateBucketOptions) (txHash string, err error) {
+	ctx, span := c.startSpan(ctx, "CancelMigrateBucket", bucketAttrs(bucketName, "")...)
+	defer func() {
+		span.SetAttributes(AttrTxHash.String(txHash))
+		endSpan(span, err)
+	}()
+
+	cancelMigrateBucketMsg := storageTypes.NewMsgCancelMigrateBucket(c.mustGetCallAccount(ctx).GetAddress(), bucketName)
 
-	err := cancelMigrateBucketMsg.ValidateBasic()
+	err = cancelMigrateBucketMsg.ValidateBasic()
 	if err != nil {
 		return "", err
 	}
@@ -1087,7 +1186,7 @@ func (c *Client) CancelMigrateBucket(ctx context.Context, bucketName string, opt
 
 	var txnResponse *ctypes.ResultTx
 	txnHash := resp.TxResponse.TxHash
-	if !opts.IsAsyncMode {
+	if !opts.IsAsyncMode && !c.dryRun {
 		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
 		defer cancel()
 
@@ -1097,7 +1196,13 @@ func (c *Client) CancelMigrateBucket(ctx context.Context, bucketName string, opt
 		}
 
 		if txnResponse.TxResult.Code != 0 {
-			return txnHash, fmt.Errorf("the createBucket txn has failed with response code: %d", txnResponse.TxResult.Code)
+			return txnHash, &types.TxFailedError{
+				Op:        "cancelMigrateBucket txn",
+				TxHash:    txnHash,
+				Code:      txnResponse.TxResult.Code,
+				Codespace: txnResponse.TxResult.Codespace,
+				Log:       txnResponse.TxResult.Log,
+			}
 		}
 	}
 
@@ -1142,7 +1247,7 @@ func (c *Client) ListBucketsByPaymentAccount(ctx context.Context, paymentAccount
 		SPAddress: opts.SPAddress,
 	})
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return types.ListBucketsByPaymentAccountResult{}, err
 
 	}
@@ -1234,7 +1339,7 @@ func (c *Client) getMigrationStateFromSP(ctx context.Context, bucketName string,
 func (c *Client) GetRecommendedVirtualGroupFamilyIDBySPID(ctx context.Context, spID uint32) (uint32, error) {
 	endpoint, err := c.getSPUrlByID(spID)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("route endpoint by sp ID: %d failed, err: %s", spID, err.Error()))
+		c.logger.Error("route endpoint by sp id failed", "sp_id", spID, "error", err)
 		return 0, err
 	}
 	return c.getRecommendedVirtualGroupFamilyIDBySPEndpoint(ctx, endpoint)
@@ -14,9 +14,10 @@ import (
 
 	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdsdktypes "github.com/evmos/evmos/v12/sdk/types"
 	challengetypes "github.com/evmos/evmos/v12/x/challenge/types"
-	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
 
 	"github.com/zkMeLabs/mechain-go-sdk/pkg/utils"
 	types "github.com/zkMeLabs/mechain-go-sdk/types"
@@ -109,14 +110,14 @@ func (c *Client) GetChallengeInfo(ctx context.Context, objectID string, pieceInd
 
 		endpoint, err = utils.GetEndpointURL(opts.Endpoint, useHttps)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("fetch endpoint from opts %s fail:%v", opts.Endpoint, err))
+			c.logger.Error("fetch endpoint from options failed", "endpoint", opts.Endpoint, "error", err)
 			return types.ChallengeResult{}, err
 		}
 	} else if opts.SPAddress != "" {
 		// get endpoint from sp address
 		endpoint, err = c.getSPUrlByAddr(opts.SPAddress)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("route endpoint by sp address: %s failed, err: %v", opts.SPAddress, err))
+			c.logger.Error("route endpoint by sp address failed", "sp_address", opts.SPAddress, "error", err)
 			return types.ChallengeResult{}, err
 		}
 	} else {
@@ -130,7 +131,7 @@ func (c *Client) GetChallengeInfo(ctx context.Context, objectID string, pieceInd
 			// get endpoint of primary sp
 			endpoint, err = c.getSPUrlByBucket(objectDetail.ObjectInfo.BucketName)
 			if err != nil {
-				log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %v", objectDetail.ObjectInfo.BucketName, err))
+				c.logger.Error("route endpoint by bucket failed", "bucket", objectDetail.ObjectInfo.BucketName, "error", err)
 				return types.ChallengeResult{}, err
 			}
 		} else {
@@ -138,7 +139,7 @@ func (c *Client) GetChallengeInfo(ctx context.Context, objectID string, pieceInd
 			secondarySPID := objectDetail.GlobalVirtualGroup.SecondarySpIds[redundancyIndex]
 			endpoint, err = c.getSPUrlByID(secondarySPID)
 			if err != nil {
-				log.Error().Msg(fmt.Sprintf("route endpoint by sp address: %d failed, err: %v", secondarySPID, err))
+				c.logger.Error("route endpoint by sp id failed", "sp_id", secondarySPID, "error", err)
 				return types.ChallengeResult{}, err
 			}
 		}
@@ -315,7 +316,7 @@ func (c *Client) AttestChallenge(ctx context.Context, submitterAddress, challeng
 //
 // - ret2: Return error when getting latest attested challenges failed, otherwise return nil.
 func (c *Client) LatestAttestedChallenges(ctx context.Context, req *challengetypes.QueryLatestAttestedChallengesRequest) (*challengetypes.QueryLatestAttestedChallengesResponse, error) {
-	return c.chainClient.LatestAttestedChallenges(ctx, req)
+	return queryChain(ctx, c, "LatestAttestedChallenges", (*sdkclient.MechainClient).LatestAttestedChallenges, req)
 }
 
 // InturnAttestationSubmitter - Query the in-turn validator to submit challenge attestation.
@@ -330,7 +331,7 @@ func (c *Client) LatestAttestedChallenges(ctx context.Context, req *challengetyp
 //
 // - ret2: Return error when getting in-turn attestation submitter failed, otherwise return nil.
 func (c *Client) InturnAttestationSubmitter(ctx context.Context, req *challengetypes.QueryInturnAttestationSubmitterRequest) (*challengetypes.QueryInturnAttestationSubmitterResponse, error) {
-	return c.chainClient.InturnAttestationSubmitter(ctx, req)
+	return queryChain(ctx, c, "InturnAttestationSubmitter", (*sdkclient.MechainClient).InturnAttestationSubmitter, req)
 }
 
 // ChallengeParams - Get challenge module's parameters of Mechain blockchain.
@@ -343,5 +344,9 @@ func (c *Client) InturnAttestationSubmitter(ctx context.Context, req *challenget
 //
 // - ret2: Return error when getting parameters failed, otherwise return nil.
 func (c *Client) ChallengeParams(ctx context.Context, req *challengetypes.QueryParamsRequest) (*challengetypes.QueryParamsResponse, error) {
-	return c.chainClient.ChallengeQueryClient.Params(ctx, req)
+	return queryChain(ctx, c, "ChallengeParams", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *challengetypes.QueryParamsRequest, opts ...grpc.CallOption,
+	) (*challengetypes.QueryParamsResponse, error) {
+		return chain.ChallengeQueryClient.Params(ctx, req, opts...)
+	}, req)
 }
@@ -13,12 +13,15 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"google.golang.org/grpc"
 
 	sdkclient "github.com/evmos/evmos/v12/sdk/client"
@@ -49,12 +52,18 @@ type IClient interface {
 	IFeeGrantClient
 	IVirtualGroupClient
 	IAuthClient
+	IPresignClient
+	IEventClient
+	IWaitClient
+	ITxSearchClient
 }
 
 // Client - The implementation for IClient, implement all Client APIs for Mechain SDK.
 type Client struct {
-	// The chain Client is used to interact with the blockchain
-	chainClient *sdkclient.MechainClient
+	// The chain endpoints route the calls to a healthy node of the blockchain
+	endpoints *endpointPool
+	// The chain ID of the blockchain
+	chainID string
 	// The HTTP Client is used to send HTTP requests to the mechain blockchain and sp
 	httpClient *http.Client
 	// Service provider endpoints
@@ -78,6 +87,27 @@ type Client struct {
 	// forceToUseSpecifiedSpEndpointForDownloadOnly indicates a fixed SP endpoint to which to send the download request
 	// If this option is set, the client can only make download requests, and can only download from the fixed endpoint
 	forceToUseSpecifiedSpEndpointForDownloadOnly *url.URL
+	// tracer creates the spans of the Client operations, it is a no-op tracer unless a TracerProvider is configured.
+	tracer trace.Tracer
+	// propagator injects the trace context into the headers of the requests sent to SP.
+	propagator propagation.TextMapPropagator
+	// logger receives the logs of the Client.
+	logger Logger
+	// metrics receives the counters and histograms of the SP requests and the transactions.
+	metrics MetricsSink
+	// sequences hands out the sequences of the transactions broadcast by the Client.
+	sequences *sequenceManager
+	// autoGas estimates the gas limit and the fee of the transactions, it is nil if AutoGas is not enabled.
+	autoGas *autoGasEstimator
+	// dryRun indicates the transactions are simulated only, and the requests with side effects are not sent to SP.
+	dryRun bool
+	// verifier verifies the results of the chain queries by the light client, it is nil if VerifiedQuery is not enabled.
+	verifier *queryVerifier
+}
+
+// chain returns the chain client of the node to call.
+func (c *Client) chain() *sdkclient.MechainClient {
+	return c.endpoints.get().client
 }
 
 // Option - Configurations for providing optional parameters for the Mechain SDK Client.
@@ -109,6 +139,65 @@ type Option struct {
 	// ForceToUseSpecifiedSpEndpointForDownloadOnly indicates a fixed SP endpoint to which to send the download request
 	// If this option is set, the client can only make download requests, and can only download from the fixed endpoint
 	ForceToUseSpecifiedSpEndpointForDownloadOnly string
+	// TracerProvider is the OpenTelemetry tracer provider used to create spans for the chain calls, the SP requests and
+	// the high-level operations of the Client. If it is not set, tracing is disabled.
+	TracerProvider trace.TracerProvider
+	// Propagator is used to propagate the trace context in the headers of the requests sent to SP.
+	// If it is not set, the W3C trace context and baggage propagators are used when TracerProvider is set.
+	Propagator propagation.TextMapPropagator
+	// Logger receives the structured logs of the Client. If it is not set, the logs are written to the global zerolog
+	// logger. Use NewSlogLogger to write the logs to a log/slog logger, or NopLogger to discard them.
+	Logger Logger
+	// Metrics is the sink of the metrics of the SP requests, the transaction broadcasts and WaitForTx.
+	// If it is not set, no metrics are reported.
+	Metrics MetricsSink
+	// AutoGas enables estimating the gas limit and the fee of the transactions by simulation before broadcasting them,
+	// it applies to the transactions whose TxOption does not specify the GasLimit or NoSimulate.
+	AutoGas *AutoGasOption
+	// DryRun enables the dry-run mode, in which the transactions are simulated instead of being broadcast, and the
+	// requests with side effects are not sent to SP. BroadcastTx and BroadcastRawTx return the response of the
+	// simulation with an empty hash, and the methods built on them return without waiting for the transaction,
+	// DryRunTx returns the estimated fee as well. The rejected requests to SP return an error matching
+	// types.ErrDryRun.
+	DryRun bool
+	// VerifiedQuery enables the verified-query mode, in which the results of HeadBucket, HeadObject and GetBucketPolicy
+	// are verified by the Merkle proofs against the app hashes of the headers verified by a light client. Their
+	// not-found errors are returned only if the absence is proven as well.
+	VerifiedQuery *VerifiedQueryOption
+	// MaxBlockLag is the max number of blocks a node can lag behind the highest node before the calls are routed to
+	// another node, DefaultMaxBlockLag is used if it is not set. It takes effect with NewWithEndpoints.
+	MaxBlockLag int64
+	// HealthCheckInterval is the interval to check the health of the nodes, DefaultHealthCheckInterval is used if it is
+	// not set. It takes effect with NewWithEndpoints.
+	HealthCheckInterval time.Duration
+}
+
+// AutoGasOption - The configurations of estimating the gas limit and the fee of the transactions by simulation.
+type AutoGasOption struct {
+	// GasMultiplier is multiplied by the simulated gas used to get the gas limit, 1.0 is used if it is not set.
+	GasMultiplier float64
+	// MinGasLimit is the floor of the gas limit.
+	MinGasLimit uint64
+	// GasPrice is the gas price to compute the fee, e.g. "5000000000azkme". The minimum gas price of the chain is used
+	// if it is not set.
+	GasPrice string
+}
+
+// VerifiedQueryOption - The configurations of the light client which verifies the results of the chain queries.
+//
+// The light client verifies the headers from a trusted header by bisection, the trusted header should be obtained
+// from a source other than the RPC endpoint, e.g. a block explorer.
+type VerifiedQueryOption struct {
+	// TrustedHeight is the height of the trusted header.
+	TrustedHeight int64
+	// TrustedHash is the HEX-encoded hash of the trusted header.
+	TrustedHash string
+	// TrustingPeriod is the period in which the validators of a verified header are trusted, it should be shorter than
+	// the unbonding period of the chain. DefaultTrustingPeriod is used if it is not set.
+	TrustingPeriod time.Duration
+	// Witnesses is the RPC endpoints of the nodes to cross-check the headers, the other endpoints of the Client are
+	// used if it is empty.
+	Witnesses []string
 }
 
 // OffChainAuthOption - The optional configurations for off-chain-auth.
@@ -155,23 +244,46 @@ type OffChainAuthOptionV2 struct {
 //
 // - ret2: Return error when new Client failed, otherwise return nil.
 func New(chainID string, endpoint string, option Option) (IClient, error) {
-	if endpoint == "" || chainID == "" {
+	if endpoint == "" {
 		return nil, errors.New("fail to get grpcAddress and chainID to construct Client")
 	}
-	var (
-		cc  *sdkclient.MechainClient
-		err error
-	)
-	if option.UseWebSocketConn {
-		cc, err = sdkclient.NewMechainClient(endpoint, chainID, sdkclient.WithWebSocketClient())
-	} else {
-		cc, err = sdkclient.NewMechainClient(endpoint, chainID)
+	return NewWithEndpoints(chainID, []string{endpoint}, option)
+}
+
+// NewWithEndpoints - New Mechain Go SDK Client which interacts with several nodes of the Mechain Blockchain.
+//
+// The calls to the chain are routed to a healthy node, a node is unhealthy if it is unreachable, catching up, or lags
+// behind the highest node by more than Option.MaxBlockLag blocks. The current node is kept as long as it is healthy,
+// and the transactions failing to reach a node are broadcast to another one.
+//
+// - chainID: The Mechain Blockchain's chainID that the Client would interact with.
+//
+// - endpoints: The Mechain Blockchain's RPC URLs that the Client would interact with, the first one is preferred.
+//
+// - option: The optional configurations for the Client.
+//
+// - ret1: The new client that created, in IClient format.
+//
+// - ret2: Return error when new Client failed, otherwise return nil.
+func NewWithEndpoints(chainID string, endpoints []string, option Option) (IClient, error) {
+	if len(endpoints) == 0 || chainID == "" {
+		return nil, errors.New("fail to get grpcAddress and chainID to construct Client")
+	}
+	for _, endpoint := range endpoints {
+		if endpoint == "" {
+			return nil, errors.New("the endpoint of the chain should not be empty")
+		}
+	}
+	var logger Logger = zerologLogger{}
+	if option.Logger != nil {
+		logger = option.Logger
 	}
+	pool, err := newEndpointPool(chainID, endpoints, option, logger)
 	if err != nil {
 		return nil, err
 	}
 	if option.DefaultAccount != nil {
-		cc.SetKeyManager(option.DefaultAccount.GetKeyManager())
+		pool.setKeyManager(option.DefaultAccount.GetKeyManager())
 	}
 
 	if option.ExpireSeconds > httplib.MaxExpiryAgeInSec {
@@ -179,7 +291,8 @@ func New(chainID string, endpoint string, option Option) (IClient, error) {
 	}
 
 	c := Client{
-		chainClient:      cc,
+		endpoints:        pool,
+		chainID:          chainID,
 		httpClient:       &http.Client{Transport: option.Transport},
 		userAgent:        types.UserAgent,
 		defaultAccount:   option.DefaultAccount, // it allows to be nil
@@ -188,6 +301,35 @@ func New(chainID string, endpoint string, option Option) (IClient, error) {
 		storageProviders: make(map[uint32]*types.StorageProvider),
 		useWebsocketConn: option.UseWebSocketConn,
 		expireSeconds:    option.ExpireSeconds,
+		tracer:           noop.NewTracerProvider().Tracer(tracerName),
+		propagator:       propagation.NewCompositeTextMapPropagator(),
+		logger:           logger,
+		metrics:          nopMetrics{},
+		sequences:        newSequenceManager(),
+		dryRun:           option.DryRun,
+	}
+
+	if option.TracerProvider != nil {
+		c.tracer = option.TracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(types.Version))
+		c.propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+	if option.Propagator != nil {
+		c.propagator = option.Propagator
+	}
+	if option.Metrics != nil {
+		c.metrics = option.Metrics
+	}
+	if option.VerifiedQuery != nil {
+		c.verifier, err = newQueryVerifier(chainID, pool, *option.VerifiedQuery)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if option.AutoGas != nil {
+		c.autoGas, err = newAutoGasEstimator(*option.AutoGas)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if option.ForceToUseSpecifiedSpEndpointForDownloadOnly != "" {
@@ -200,7 +342,7 @@ func New(chainID string, endpoint string, option Option) (IClient, error) {
 
 		c.forceToUseSpecifiedSpEndpointForDownloadOnly, err = utils.GetEndpointURL(option.ForceToUseSpecifiedSpEndpointForDownloadOnly, useHttps)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("fetch endpoint from option %s fail:%v", option.ForceToUseSpecifiedSpEndpointForDownloadOnly, err))
+			c.logger.Error("fetch endpoint from option failed", "endpoint", option.ForceToUseSpecifiedSpEndpointForDownloadOnly, "error", err)
 			return nil, err
 		}
 	} else {
@@ -223,9 +365,10 @@ func New(chainID string, endpoint string, option Option) (IClient, error) {
 			for _, sp := range c.storageProviders {
 				registerResult, err := c.RegisterEDDSAPublicKey(sp.OperatorAddress.String(), sp.EndPoint.Scheme+"://"+sp.EndPoint.Host)
 				if err != nil {
-					log.Error().Msg(fmt.Sprintf("Fail to RegisterEDDSAPublicKey for sp : %s", sp.EndPoint))
+					c.logger.Error("register eddsa public key failed", "sp_endpoint", sp.EndPoint.Host, "error", err)
+					continue
 				}
-				log.Info().Msg(fmt.Sprintf("registerResult: %s", registerResult))
+				c.logger.Info("registered eddsa public key", "sp_endpoint", sp.EndPoint.Host, "result", registerResult)
 
 			}
 		}
@@ -244,14 +387,18 @@ func New(chainID string, endpoint string, option Option) (IClient, error) {
 			for _, sp := range c.storageProviders {
 				registerResult, err := c.RegisterEDDSAPublicKeyV2(sp.EndPoint.Scheme + "://" + sp.EndPoint.Host)
 				if err != nil {
-					log.Error().Msg(fmt.Sprintf("Fail to RegisterEDDSAPublicKeyV2 for sp : %s", sp.EndPoint))
+					c.logger.Error("register eddsa public key v2 failed", "sp_endpoint", sp.EndPoint.Host, "error", err)
+					continue
 				}
-				log.Info().Msg(fmt.Sprintf("registerResult: %s", registerResult))
+				c.logger.Info("registered eddsa public key v2", "sp_endpoint", sp.EndPoint.Host, "result", registerResult)
 
 			}
 		}
 	}
 
+	// the nodes are monitored until the Client is unreachable, the monitor does not refer to the Client
+	go pool.monitor()
+	runtime.SetFinalizer(&c, func(c *Client) { c.endpoints.close() })
 	return &c, nil
 }
 
@@ -270,7 +417,7 @@ func (c *Client) pickStorageProviderByBucket(bucketName string) (*types.StorageP
 		return nil, err
 	}
 
-	familyResp, err := c.chainClient.GlobalVirtualGroupFamily(ctx, &types2.QueryGlobalVirtualGroupFamilyRequest{FamilyId: bucketInfo.GlobalVirtualGroupFamilyId})
+	familyResp, err := queryChain(ctx, c, "GlobalVirtualGroupFamily", (*sdkclient.MechainClient).GlobalVirtualGroupFamily, &types2.QueryGlobalVirtualGroupFamilyRequest{FamilyId: bucketInfo.GlobalVirtualGroupFamilyId})
 	if err != nil {
 		return nil, err
 	}
@@ -395,7 +542,7 @@ func (c *Client) newRequest(ctx context.Context, method string, meta requestMeta
 	desURL, err := c.generateURL(meta.bucketName, meta.objectName, meta.urlRelPath,
 		meta.urlValues, adminAPIInfo, endpoint, isVirtualHost)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("generate request url on SP: %s fail, err: %s", endpoint.String(), err))
+		c.logger.Error("generate request url failed", "sp_endpoint", endpoint.Host, "error", err)
 		return nil, err
 	}
 
@@ -513,11 +660,14 @@ func (c *Client) newRequest(ctx context.Context, method string, meta requestMeta
 		return req, err
 	}
 
+	// the trace headers are not part of the signed message, so they are set after signing
+	c.injectTraceContext(ctx, req)
+
 	return
 }
 
-// doAPI call Client.Do() to send request and read response from servers
-func (c *Client) doAPI(ctx context.Context, req *http.Request, meta requestMeta, closeBody bool) (*http.Response, error) {
+// doAPI call Client.Do() to send request and read response from servers, the body of the response is wrapped by body
+func (c *Client) doAPI(ctx context.Context, req *http.Request, meta requestMeta, closeBody bool, body *spanBody) (*http.Response, error) {
 	var cancel context.CancelFunc
 	if closeBody {
 		ctx, cancel = context.WithCancel(ctx)
@@ -525,6 +675,9 @@ func (c *Client) doAPI(ctx context.Context, req *http.Request, meta requestMeta,
 	}
 	req = req.WithContext(ctx)
 
+	if err := c.checkDryRun(req.Method); err != nil {
+		return nil, err
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		// If we got an error, and the context has been canceled,
@@ -536,15 +689,16 @@ func (c *Client) doAPI(ctx context.Context, req *http.Request, meta requestMeta,
 		}
 		if urlErr, ok := err.(*url.Error); ok {
 			if strings.Contains(urlErr.Err.Error(), "EOF") {
-				return nil, &url.Error{
+				return nil, types.WrapError(&url.Error{
 					Op:  urlErr.Op,
 					URL: urlErr.URL,
 					Err: errors.New("Connection closed by foreign host " + urlErr.URL + ". Retry again."),
-				}
+				}, types.ErrSPUnavailable)
 			}
 		}
-		return nil, err
+		return nil, types.WrapError(err, types.ErrSPUnavailable)
 	}
+	body.attach(resp)
 	defer func() {
 		if closeBody {
 			utils.CloseResponse(resp)
@@ -554,6 +708,7 @@ func (c *Client) doAPI(ctx context.Context, req *http.Request, meta requestMeta,
 	// construct err responses and messages
 	err = types.ConstructErrResponse(resp, meta.bucketName, meta.objectName)
 	if err != nil {
+		body.err = err
 		// dump error msg
 		if c.isTraceEnabled {
 			c.dumpSPMsg(req, resp)
@@ -573,15 +728,32 @@ func (c *Client) doAPI(ctx context.Context, req *http.Request, meta requestMeta,
 }
 
 // sendReq sends the message via REST and handles the response
-func (c *Client) sendReq(ctx context.Context, metadata requestMeta, opt *sendOptions, endpoint *url.URL) (res *http.Response, err error) {
+func (c *Client) sendReq(ctx context.Context, metadata requestMeta, opt *sendOptions, endpoint *url.URL) (*http.Response, error) {
+	start := time.Now()
+	ctx, span := c.startSPSpan(ctx, opt.method, metadata, endpoint)
+	// the span is ended when the body of the response is closed, by doAPI or by the caller if the body is returned
+	body := &spanBody{done: func(status int, bytesIn int64, err error) {
+		if status != 0 {
+			span.SetAttributes(AttrHTTPStatus.Int(status), AttrBytesIn.Int64(bytesIn))
+		}
+		endSpan(span, err)
+		c.recordSPRequest(ctx, opt.method, endpoint, status, start, metadata.contentLength, bytesIn)
+	}}
+
 	req, err := c.newRequest(ctx, opt.method, metadata, opt.body, opt.txnHash, opt.adminInfo, endpoint)
 	if err != nil {
+		body.err = err
+		body.finish()
 		return nil, err
 	}
+	span.SetAttributes(AttrHTTPURL.String(req.URL.String()))
 
-	resp, err := c.doAPI(ctx, req, metadata, !opt.disableCloseBody)
+	resp, err := c.doAPI(ctx, req, metadata, !opt.disableCloseBody, body)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("do API error, url: %s, err: %s", req.URL.String(), err))
+		// the body has been closed by doAPI if there is a response, otherwise the request is finished here
+		body.err = err
+		body.finish()
+		c.logger.Error("do API error", "method", req.Method, "sp_endpoint", req.URL.Host, "path", req.URL.Path, "error", err)
 		return nil, err
 	}
 	return resp, nil
@@ -661,6 +833,12 @@ func (c *Client) generateURL(bucketName string, objectName string, relativePath
 
 // signRequest signs the request and set authorization before send to server
 func (c *Client) signRequest(req *http.Request) error {
+	// the off-chain auth keys belong to the default account, the requests acting as another account are signed by
+	// the account itself
+	if _, ok := AccountFromContext(req.Context()); ok {
+		return c.signRequestByAccount(req)
+	}
+
 	// use offChainAuth if OffChainAuthOption is set
 	if c.offChainAuthOption != nil {
 		req.Header.Set("X-Gnfd-User-Address", c.defaultAccount.GetAddress().String())
@@ -684,23 +862,37 @@ func (c *Client) signRequest(req *http.Request) error {
 		return nil
 	}
 
+	return c.signRequestByAccount(req)
+}
+
+// signRequestByAccount signs the request with the account of the call and set the GNFD1-ECDSA authorization
+func (c *Client) signRequestByAccount(req *http.Request) error {
 	unsignedMsg := httplib.GetMsgToSignInGNFD1Auth(req)
 
 	// sign the request header info, generate the signature
-	signature, err := c.MustGetDefaultAccount().Sign(unsignedMsg)
+	authStr, err := c.gnfd1EcdsaAuth(req.Context(), unsignedMsg)
 	if err != nil {
 		return err
 	}
 
+	// set auth header
+	req.Header.Set(types.HTTPHeaderAuthorization, authStr)
+
+	return nil
+}
+
+// gnfd1EcdsaAuth signs the message with the account of the call and returns the GNFD1-ECDSA authorization string
+func (c *Client) gnfd1EcdsaAuth(ctx context.Context, unsignedMsg []byte) (string, error) {
+	signature, err := c.mustGetCallAccount(ctx).SignRequest(unsignedMsg)
+	if err != nil {
+		return "", err
+	}
+
 	authStr := []string{
 		httplib.Gnfd1Ecdsa,
 		"Signature=" + hex.EncodeToString(signature),
 	}
-
-	// set auth header
-	req.Header.Set(types.HTTPHeaderAuthorization, strings.Join(authStr, ", "))
-
-	return nil
+	return strings.Join(authStr, ", "), nil
 }
 
 // returns true if virtual hosted style requests are to be used.
@@ -724,7 +916,7 @@ func (c *Client) dumpSPMsg(req *http.Request, resp *http.Response) {
 	var err error
 	defer func() {
 		if err != nil {
-			log.Error().Msg("dump msg err:" + err.Error())
+			c.logger.Error("dump msg failed", "error", err)
 		}
 	}()
 	_, err = fmt.Fprintln(c.traceOutput, "---------TRACE REQUEST---------")
@@ -822,9 +1014,13 @@ func (c *Client) sendTxn(ctx context.Context, msg sdk.Msg, opt *gnfdSdkTypes.TxO
 
 	resp, err := c.BroadcastTx(ctx, []sdk.Msg{msg}, opt)
 	if err != nil {
+		// the tx hash is returned along with the TxFailedError if the tx has been rejected by the chain
+		if resp != nil && resp.TxResponse != nil {
+			return resp.TxResponse.TxHash, err
+		}
 		return "", err
 	}
-	return resp.TxResponse.TxHash, err
+	return resp.TxResponse.TxHash, nil
 }
 
 // getEndpointByOpt return the SP endpoint by listOptions
@@ -837,7 +1033,7 @@ func (c *Client) getEndpointByOpt(opts *types.EndPointOptions) (*url.URL, error)
 	if opts == nil || (opts.Endpoint == "" && opts.SPAddress == "") {
 		endpoint, err = c.getInServiceSP()
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("get in-service SP fail %s", err.Error()))
+			c.logger.Error("get in-service sp failed", "error", err)
 			return nil, err
 		}
 	} else if opts.Endpoint != "" {
@@ -849,14 +1045,14 @@ func (c *Client) getEndpointByOpt(opts *types.EndPointOptions) (*url.URL, error)
 
 		endpoint, err = utils.GetEndpointURL(opts.Endpoint, useHttps)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("fetch endpoint from opts %s fail:%v", opts.Endpoint, err))
+			c.logger.Error("fetch endpoint from options failed", "endpoint", opts.Endpoint, "error", err)
 			return nil, err
 		}
 	} else if opts.SPAddress != "" {
 		// get endpoint from sp address
 		endpoint, err = c.getSPUrlByAddr(opts.SPAddress)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("route endpoint by sp address: %s failed, err: %v", opts.SPAddress, err))
+			c.logger.Error("route endpoint by sp address failed", "sp_address", opts.SPAddress, "error", err)
 			return nil, err
 		}
 	}
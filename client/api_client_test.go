@@ -0,0 +1,241 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	bfttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = "mechain_5151-1"
+
+// testChain is a node of the chain in the tests, it serves the JSON-RPC calls of the Client by the handlers of the
+// RPC methods and of the ABCI query paths.
+type testChain struct {
+	srv *httptest.Server
+
+	mu      sync.Mutex
+	height  int64
+	methods map[string]func(params map[string]json.RawMessage) (interface{}, error)
+	queries map[string]func(data []byte) (proto.Message, error)
+	calls   map[string]int
+	// blocks are the blocks from height 1, they are served once a block is added.
+	blocks []testBlock
+}
+
+// testBlock is a block of the testChain.
+type testBlock struct {
+	time time.Time
+	// txs are the transactions of the block, and txResults their results.
+	txs       []bfttypes.Tx
+	txResults []*abci.ResponseDeliverTx
+	// beginEvents and endEvents are the events emitted out of the transactions.
+	beginEvents []abci.Event
+	endEvents   []abci.Event
+}
+
+// newTestChain starts a node which serves no call until the handlers are set.
+func newTestChain(t *testing.T) *testChain {
+	c := &testChain{
+		height:  1,
+		methods: make(map[string]func(params map[string]json.RawMessage) (interface{}, error)),
+		queries: make(map[string]func(data []byte) (proto.Message, error)),
+		calls:   make(map[string]int),
+	}
+	c.srv = httptest.NewServer(c)
+	t.Cleanup(c.srv.Close)
+	return c
+}
+
+// newClient returns a Client of the node.
+func (c *testChain) newClient(t *testing.T, option Option) *Client {
+	cli, err := New(testChainID, c.srv.URL, option)
+	require.NoError(t, err)
+	return cli.(*Client)
+}
+
+// handle sets the handler of the RPC method, the result is encoded as the RPC of the node does.
+func (c *testChain) handle(method string, handler func(params map[string]json.RawMessage) (interface{}, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.methods[method] = handler
+}
+
+// handleQuery sets the handler of the ABCI query path, e.g. "/cosmos.auth.v1beta1.Query/Account". The handler gets
+// the encoded request, the registered errors it returns are answered with their codespaces and codes.
+func (c *testChain) handleQuery(path string, handler func(data []byte) (proto.Message, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries[path] = handler
+}
+
+// addBlock appends the block to the chain and returns its height, the status, block and block_results methods serve
+// the blocks.
+func (c *testChain) addBlock(block testBlock) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.blocks) == 0 {
+		c.methods["status"] = c.status
+		c.methods["block"] = c.block
+		c.methods["block_results"] = c.blockResults
+	}
+	c.blocks = append(c.blocks, block)
+	return int64(len(c.blocks))
+}
+
+// status returns the status of the node which keeps all the blocks.
+func (c *testChain) status(map[string]json.RawMessage) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{
+		EarliestBlockHeight: 1,
+		EarliestBlockTime:   c.blocks[0].time,
+		LatestBlockHeight:   int64(len(c.blocks)),
+		LatestBlockTime:     c.blocks[len(c.blocks)-1].time,
+	}}, nil
+}
+
+// blockAt returns the block of the height param, or the latest block if it is absent.
+func (c *testChain) blockAt(params map[string]json.RawMessage) (int64, testBlock, error) {
+	height, ok, err := paramInt64(params, "height")
+	if err != nil {
+		return 0, testBlock{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !ok {
+		height = int64(len(c.blocks))
+	}
+	if height < 1 || height > int64(len(c.blocks)) {
+		return 0, testBlock{}, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", height, len(c.blocks))
+	}
+	return height, c.blocks[height-1], nil
+}
+
+func (c *testChain) block(params map[string]json.RawMessage) (interface{}, error) {
+	height, block, err := c.blockAt(params)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlock{Block: &bfttypes.Block{
+		Header: bfttypes.Header{ChainID: testChainID, Height: height, Time: block.time},
+		Data:   bfttypes.Data{Txs: block.txs},
+	}}, nil
+}
+
+func (c *testChain) blockResults(params map[string]json.RawMessage) (interface{}, error) {
+	height, block, err := c.blockAt(params)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlockResults{
+		Height:           height,
+		TxsResults:       block.txResults,
+		BeginBlockEvents: block.beginEvents,
+		EndBlockEvents:   block.endEvents,
+	}, nil
+}
+
+// callCount returns the number of the calls of the RPC method or the ABCI query path.
+func (c *testChain) callCount(name string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[name]
+}
+
+// ServeHTTP answers the JSON-RPC requests.
+func (c *testChain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage            `json:"id"`
+		Method string                     `json:"method"`
+		Params map[string]json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	result, err := c.serve(req.Method, req.Params)
+	if err == nil {
+		var raw []byte
+		if raw, err = cmtjson.Marshal(result); err == nil {
+			resp["result"] = json.RawMessage(raw)
+		}
+	}
+	if err != nil {
+		resp["error"] = map[string]interface{}{"code": -32603, "message": "Internal error", "data": err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (c *testChain) serve(method string, params map[string]json.RawMessage) (interface{}, error) {
+	if method == "abci_query" {
+		return c.query(params)
+	}
+	c.mu.Lock()
+	handler := c.methods[method]
+	c.calls[method]++
+	c.mu.Unlock()
+	if handler == nil {
+		return nil, fmt.Errorf("method %s not found", method)
+	}
+	return handler(params)
+}
+
+func (c *testChain) query(params map[string]json.RawMessage) (*ctypes.ResultABCIQuery, error) {
+	var (
+		path string
+		data cmtbytes.HexBytes
+	)
+	if err := json.Unmarshal(params["path"], &path); err != nil {
+		return nil, err
+	}
+	if err := cmtjson.Unmarshal(params["data"], &data); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	handler := c.queries[path]
+	height := c.height
+	c.calls[path]++
+	c.mu.Unlock()
+
+	var (
+		resp proto.Message
+		err  = fmt.Errorf("unknown query path %s", path)
+	)
+	if handler != nil {
+		resp, err = handler(data)
+	}
+	if err != nil {
+		codespace, code, log := errorsmod.ABCIInfo(err, true)
+		return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Codespace: codespace, Code: code, Log: log, Height: height}}, nil
+	}
+	value, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: value, Height: height}}, nil
+}
+
+// paramInt64 returns the integer param of the RPC call, ok is false if the param is absent.
+func paramInt64(params map[string]json.RawMessage, name string) (value int64, ok bool, err error) {
+	raw, ok := params[name]
+	if !ok || string(raw) == "null" {
+		return 0, false, nil
+	}
+	err = cmtjson.Unmarshal(raw, &value)
+	return value, true, err
+}
@@ -6,11 +6,13 @@ import (
 	"cosmossdk.io/math"
 	crosschaintypes "github.com/cosmos/cosmos-sdk/x/crosschain/types"
 	oracletypes "github.com/cosmos/cosmos-sdk/x/oracle/types"
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
 	bridgetypes "github.com/evmos/evmos/v12/x/bridge/types"
 	storagetypes "github.com/evmos/evmos/v12/x/storage/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc"
 )
 
 type ICrossChainClient interface {
@@ -41,7 +43,7 @@ type ICrossChainClient interface {
 //
 // - ret2: Return error if transaction failed, otherwise return nil.
 func (c *Client) TransferOut(ctx context.Context, toAddress string, amount math.Int, txOption gnfdSdkTypes.TxOption) (*sdk.TxResponse, error) {
-	msgTransferOut := bridgetypes.NewMsgTransferOut(c.MustGetDefaultAccount().GetAddress().String(),
+	msgTransferOut := bridgetypes.NewMsgTransferOut(c.mustGetCallAccount(ctx).GetAddress().String(),
 		toAddress,
 		&sdk.Coin{Denom: gnfdSdkTypes.Denom, Amount: amount},
 	)
@@ -79,7 +81,7 @@ func (c *Client) Claims(ctx context.Context, srcChainId, destChainId uint32, seq
 	timestamp uint64, payload []byte, voteAddrSet []uint64, aggSignature []byte, txOption gnfdSdkTypes.TxOption,
 ) (*sdk.TxResponse, error) {
 	msg := oracletypes.NewMsgClaim(
-		c.MustGetDefaultAccount().GetAddress().String(),
+		c.mustGetCallAccount(ctx).GetAddress().String(),
 		srcChainId,
 		destChainId,
 		sequence,
@@ -107,8 +109,11 @@ func (c *Client) Claims(ctx context.Context, srcChainId, destChainId uint32, seq
 //
 // - ret2: Return error if the query failed, otherwise return nil.
 func (c *Client) GetChannelSendSequence(ctx context.Context, destChainId sdk.ChainID, channelId uint32) (uint64, error) {
-	resp, err := c.chainClient.CrosschainQueryClient.SendSequence(
-		ctx,
+	resp, err := queryChain(ctx, c, "SendSequence", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *crosschaintypes.QuerySendSequenceRequest, opts ...grpc.CallOption,
+	) (*crosschaintypes.QuerySendSequenceResponse, error) {
+		return chain.CrosschainQueryClient.SendSequence(ctx, req, opts...)
+	},
 		&crosschaintypes.QuerySendSequenceRequest{
 			DestChainId: uint32(destChainId),
 			ChannelId:   channelId,
@@ -132,8 +137,11 @@ func (c *Client) GetChannelSendSequence(ctx context.Context, destChainId sdk.Cha
 //
 // - ret2: Return error if the query failed, otherwise return nil.
 func (c *Client) GetChannelReceiveSequence(ctx context.Context, destChainId sdk.ChainID, channelId uint32) (uint64, error) {
-	resp, err := c.chainClient.CrosschainQueryClient.ReceiveSequence(
-		ctx,
+	resp, err := queryChain(ctx, c, "ReceiveSequence", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *crosschaintypes.QueryReceiveSequenceRequest, opts ...grpc.CallOption,
+	) (*crosschaintypes.QueryReceiveSequenceResponse, error) {
+		return chain.CrosschainQueryClient.ReceiveSequence(ctx, req, opts...)
+	},
 		&crosschaintypes.QueryReceiveSequenceRequest{
 			DestChainId: uint32(destChainId),
 			ChannelId:   channelId,
@@ -155,7 +163,7 @@ func (c *Client) GetChannelReceiveSequence(ctx context.Context, destChainId sdk.
 //
 // - ret2: Return error if the query failed, otherwise return nil.
 func (c *Client) GetInturnRelayer(ctx context.Context, req *oracletypes.QueryInturnRelayerRequest) (*oracletypes.QueryInturnRelayerResponse, error) {
-	return c.chainClient.InturnRelayer(ctx, req)
+	return queryChain(ctx, c, "InturnRelayer", (*sdkclient.MechainClient).InturnRelayer, req)
 }
 
 // GetCrossChainPackage - Get the cross-chain package by sequence.
@@ -172,8 +180,7 @@ func (c *Client) GetInturnRelayer(ctx context.Context, req *oracletypes.QueryInt
 //
 // - ret2: Return error if the query failed, otherwise return nil.
 func (c *Client) GetCrossChainPackage(ctx context.Context, destChainId sdk.ChainID, channelId uint32, sequence uint64) ([]byte, error) {
-	resp, err := c.chainClient.CrossChainPackage(
-		ctx,
+	resp, err := queryChain(ctx, c, "CrossChainPackage", (*sdkclient.MechainClient).CrossChainPackage,
 		&crosschaintypes.QueryCrossChainPackageRequest{
 			DestChainId: uint32(destChainId),
 			ChannelId:   channelId,
@@ -202,7 +209,7 @@ func (c *Client) GetCrossChainPackage(ctx context.Context, destChainId sdk.Chain
 //
 // - ret2: Return error if the transaction failed, otherwise return nil.
 func (c *Client) MirrorGroup(ctx context.Context, destChainId sdk.ChainID, groupId math.Uint, groupName string, txOption gnfdSdkTypes.TxOption) (*sdk.TxResponse, error) {
-	msgMirrorGroup := storagetypes.NewMsgMirrorGroup(c.MustGetDefaultAccount().GetAddress(), destChainId, groupId, groupName)
+	msgMirrorGroup := storagetypes.NewMsgMirrorGroup(c.mustGetCallAccount(ctx).GetAddress(), destChainId, groupId, groupName)
 	txResp, err := c.BroadcastTx(ctx, []sdk.Msg{msgMirrorGroup}, &txOption)
 	if err != nil {
 		return nil, err
@@ -226,7 +233,7 @@ func (c *Client) MirrorGroup(ctx context.Context, destChainId sdk.ChainID, group
 //
 // - ret2: Return error if the transaction failed, otherwise return nil.
 func (c *Client) MirrorBucket(ctx context.Context, destChainId sdk.ChainID, bucketId math.Uint, bucketName string, txOption gnfdSdkTypes.TxOption) (*sdk.TxResponse, error) {
-	msgMirrorBucket := storagetypes.NewMsgMirrorBucket(c.MustGetDefaultAccount().GetAddress(), destChainId, bucketId, bucketName)
+	msgMirrorBucket := storagetypes.NewMsgMirrorBucket(c.mustGetCallAccount(ctx).GetAddress(), destChainId, bucketId, bucketName)
 	txResp, err := c.BroadcastTx(ctx, []sdk.Msg{msgMirrorBucket}, &txOption)
 	if err != nil {
 		return nil, err
@@ -252,7 +259,7 @@ func (c *Client) MirrorBucket(ctx context.Context, destChainId sdk.ChainID, buck
 //
 // - ret2: Return error if the transaction failed, otherwise return nil.
 func (c *Client) MirrorObject(ctx context.Context, destChainId sdk.ChainID, objectId math.Uint, bucketName, objectName string, txOption gnfdSdkTypes.TxOption) (*sdk.TxResponse, error) {
-	msgMirrorObject := storagetypes.NewMsgMirrorObject(c.MustGetDefaultAccount().GetAddress(), destChainId, objectId, bucketName, objectName)
+	msgMirrorObject := storagetypes.NewMsgMirrorObject(c.mustGetCallAccount(ctx).GetAddress(), destChainId, objectId, bucketName, objectName)
 	txResp, err := c.BroadcastTx(ctx, []sdk.Msg{msgMirrorObject}, &txOption)
 	if err != nil {
 		return nil, err
@@ -32,7 +32,7 @@ func (c *Client) SetWithdrawAddress(ctx context.Context, withdrawAddr string, tx
 	if err != nil {
 		return "", err
 	}
-	msg := distrtypes.NewMsgSetWithdrawAddress(c.MustGetDefaultAccount().GetAddress(), withdraw)
+	msg := distrtypes.NewMsgSetWithdrawAddress(c.mustGetCallAccount(ctx).GetAddress(), withdraw)
 	resp, err := c.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
@@ -50,7 +50,7 @@ func (c *Client) SetWithdrawAddress(ctx context.Context, withdrawAddr string, tx
 //
 // - ret2: Return error if the transaction failed, otherwise return nil.
 func (c *Client) WithdrawValidatorCommission(ctx context.Context, txOption gnfdsdktypes.TxOption) (string, error) {
-	msg := distrtypes.NewMsgWithdrawValidatorCommission(c.MustGetDefaultAccount().GetAddress())
+	msg := distrtypes.NewMsgWithdrawValidatorCommission(c.mustGetCallAccount(ctx).GetAddress())
 	resp, err := c.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
@@ -74,7 +74,7 @@ func (c *Client) WithdrawDelegatorReward(ctx context.Context, validatorAddr stri
 	if err != nil {
 		return "", err
 	}
-	msg := distrtypes.NewMsgWithdrawDelegatorReward(c.MustGetDefaultAccount().GetAddress(), validator)
+	msg := distrtypes.NewMsgWithdrawDelegatorReward(c.mustGetCallAccount(ctx).GetAddress(), validator)
 	resp, err := c.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
@@ -94,7 +94,7 @@ func (c *Client) WithdrawDelegatorReward(ctx context.Context, validatorAddr stri
 //
 // - ret2: Return error if the transaction failed, otherwise return nil.
 func (c *Client) FundCommunityPool(ctx context.Context, amount math.Int, txOption gnfdsdktypes.TxOption) (string, error) {
-	msg := distrtypes.NewMsgFundCommunityPool(sdk.Coins{sdk.Coin{Denom: gnfdsdktypes.Denom, Amount: amount}}, c.MustGetDefaultAccount().GetAddress())
+	msg := distrtypes.NewMsgFundCommunityPool(sdk.Coins{sdk.Coin{Denom: gnfdsdktypes.Denom, Amount: amount}}, c.mustGetCallAccount(ctx).GetAddress())
 	resp, err := c.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
@@ -0,0 +1,462 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	bfttypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/gogoproto/proto"
+
+	// register the typed events of the modules, so that they can be decoded by sdk.ParseTypedEvent
+	_ "github.com/evmos/evmos/v12/x/payment/types"
+	_ "github.com/evmos/evmos/v12/x/permission/types"
+	_ "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+const (
+	// eventSubscriber is the name of the subscriber of the event subscriptions.
+	eventSubscriber = "mechain-go-sdk"
+	// defaultEventBufferSize is the capacity of the event channel if SubscribeOptions.BufferSize is not set.
+	defaultEventBufferSize = 100
+	// eventStallTimeout is the max time to wait for a new block, the subscription is reconnected after it.
+	eventStallTimeout = 30 * time.Second
+	// maxEventReconnectBackoff is the max interval between the attempts to reconnect the subscription.
+	maxEventReconnectBackoff = 30 * time.Second
+)
+
+// supportedEventTypes is the types of the events which can be delivered by Subscribe.
+var supportedEventTypes = map[string]bool{
+	types.EventTypeCreateBucket:       true,
+	types.EventTypeCreateObject:       true,
+	types.EventTypeSealObject:         true,
+	types.EventTypeCreateGroup:        true,
+	types.EventTypeDeleteObject:       true,
+	types.EventTypeUpdateGroupMember:  true,
+	types.EventTypePutPolicy:          true,
+	types.EventTypeStreamRecordUpdate: true,
+
+	types.EventTypeRejectSealObject:          true,
+	types.EventTypeCancelUpdateObjectContent: true,
+	types.EventTypeCompleteMigrationBucket:   true,
+	types.EventTypeRejectMigrateBucket:       true,
+	types.EventTypeCancelMigrationBucket:     true,
+}
+
+// IEventClient interface defines functions related to the events of the chain.
+type IEventClient interface {
+	Subscribe(ctx context.Context, opts types.SubscribeOptions) (<-chan *types.Event, error)
+}
+
+// Subscribe - Subscribe to the typed events emitted by the chain, e.g. the events of creating buckets and sealing
+// objects.
+//
+// The subscription receives the new blocks over the websocket of the RPC endpoint, and decodes the events from the
+// results of the blocks. If the websocket is broken or no block is received for a while, it reconnects and backfills
+// the events of the blocks committed in between, so that no event is missed or delivered twice.
+//
+// - ctx: Context variables of the subscription, the subscription is shut down and the channel is closed when the ctx
+// is done.
+//
+// - opts: The filters of the events and the configurations of the subscription.
+//
+// - ret1: The channel which delivers the events in the order they are emitted, it should be drained by the caller,
+// otherwise the subscription is blocked.
+//
+// - ret2: Return error when the subscription can not be opened, otherwise return nil.
+func (c *Client) Subscribe(ctx context.Context, opts types.SubscribeOptions) (<-chan *types.Event, error) {
+	filter, err := newEventFilter(opts)
+	if err != nil {
+		return nil, err
+	}
+	conn, headers, err := c.subscribeNewBlockHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lastHeight := opts.FromHeight - 1
+	if opts.FromHeight <= 0 {
+		status, err := c.GetStatus(ctx)
+		if err != nil {
+			stopEventConn(conn)
+			return nil, err
+		}
+		lastHeight = status.SyncInfo.LatestBlockHeight
+	}
+	bufferSize := opts.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultEventBufferSize
+	}
+
+	s := &subscription{
+		client:     c,
+		filter:     filter,
+		out:        make(chan *types.Event, bufferSize),
+		conn:       conn,
+		headers:    headers,
+		lastHeight: lastHeight,
+	}
+	go s.run(ctx)
+	return s.out, nil
+}
+
+// pollEvents delivers the events matching opts of the blocks from opts.FromHeight by polling the latest height with
+// the interval, it is the fallback of Subscribe if the websocket of the RPC endpoint is unavailable. The channel is
+// closed when the ctx is done.
+func (c *Client) pollEvents(ctx context.Context, opts types.SubscribeOptions, interval time.Duration) (<-chan *types.Event, error) {
+	filter, err := newEventFilter(opts)
+	if err != nil {
+		return nil, err
+	}
+	bufferSize := opts.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultEventBufferSize
+	}
+
+	s := &subscription{
+		client:     c,
+		filter:     filter,
+		out:        make(chan *types.Event, bufferSize),
+		lastHeight: opts.FromHeight - 1,
+	}
+	go func() {
+		defer close(s.out)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.catchUpLatest(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return s.out, nil
+}
+
+// subscribeNewBlockHeader opens a websocket connection to the RPC endpoint and subscribes to the new block headers.
+func (c *Client) subscribeNewBlockHeader(ctx context.Context) (*rpchttp.HTTP, <-chan ctypes.ResultEvent, error) {
+	conn, err := rpchttp.New(c.endpoints.get().url, "/websocket")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = conn.Start(); err != nil {
+		return nil, nil, err
+	}
+	query := bfttypes.QueryForEvent(bfttypes.EventNewBlockHeader).String()
+	headers, err := conn.Subscribe(ctx, eventSubscriber, query, defaultEventBufferSize)
+	if err != nil {
+		stopEventConn(conn)
+		return nil, nil, err
+	}
+	return conn, headers, nil
+}
+
+// stopEventConn unsubscribes and closes the websocket connection.
+func stopEventConn(conn *rpchttp.HTTP) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = conn.UnsubscribeAll(ctx, eventSubscriber)
+	_ = conn.Stop()
+}
+
+// subscription delivers the events of the blocks after lastHeight.
+type subscription struct {
+	client *Client
+	filter *eventFilter
+	out    chan *types.Event
+
+	conn    *rpchttp.HTTP
+	headers <-chan ctypes.ResultEvent
+	// lastHeight is the height of the last block whose events have been delivered.
+	lastHeight int64
+}
+
+// run delivers the events of the new blocks until the ctx is done.
+func (s *subscription) run(ctx context.Context) {
+	defer close(s.out)
+	defer func() {
+		stopEventConn(s.conn)
+	}()
+
+	// the blocks committed before the subscription is opened are backfilled first
+	s.catchUpLatest(ctx)
+
+	stall := time.NewTimer(eventStallTimeout)
+	defer stall.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case result, ok := <-s.headers:
+			if !ok {
+				s.client.logger.Warn("the event subscription is closed by the node, reconnecting")
+				if !s.reconnect(ctx) {
+					return
+				}
+				resetTimer(stall, eventStallTimeout)
+				continue
+			}
+			resetTimer(stall, eventStallTimeout)
+			header, ok := result.Data.(bfttypes.EventDataNewBlockHeader)
+			if !ok {
+				continue
+			}
+			if err := s.catchUp(ctx, header.Header.Height); err != nil && ctx.Err() == nil {
+				// the blocks are fetched again when the next block is received
+				s.client.logger.Warn("failed to fetch the events of the blocks", "height", s.lastHeight+1, "error", err)
+			}
+		case <-stall.C:
+			s.client.logger.Warn("no block is received by the event subscription, reconnecting", "timeout", eventStallTimeout)
+			if !s.reconnect(ctx) {
+				return
+			}
+			stall.Reset(eventStallTimeout)
+		}
+	}
+}
+
+// reconnect opens the subscription again and backfills the events of the blocks committed in between, it returns
+// false if the ctx is done before the subscription is opened.
+func (s *subscription) reconnect(ctx context.Context) bool {
+	stopEventConn(s.conn)
+	backoff := time.Second
+	for {
+		conn, headers, err := s.client.subscribeNewBlockHeader(ctx)
+		if err == nil {
+			s.conn, s.headers = conn, headers
+			s.catchUpLatest(ctx)
+			return true
+		}
+		s.client.logger.Warn("failed to reconnect the event subscription", "retry_after", backoff, "error", err)
+
+		select {
+		case <-ctx.Done():
+			// the old connection has been stopped, stopping it again in run is harmless
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = nextReconnectBackoff(backoff)
+	}
+}
+
+// nextReconnectBackoff returns the interval before the next attempt to reconnect, the backoff is doubled up to
+// maxEventReconnectBackoff.
+func nextReconnectBackoff(backoff time.Duration) time.Duration {
+	if backoff *= 2; backoff > maxEventReconnectBackoff {
+		return maxEventReconnectBackoff
+	}
+	return backoff
+}
+
+// catchUpLatest delivers the events of the blocks up to the latest block.
+func (s *subscription) catchUpLatest(ctx context.Context) {
+	status, err := s.client.GetStatus(ctx)
+	if err == nil {
+		err = s.catchUp(ctx, status.SyncInfo.LatestBlockHeight)
+	}
+	if err != nil && ctx.Err() == nil {
+		s.client.logger.Warn("failed to backfill the events of the blocks", "height", s.lastHeight+1, "error", err)
+	}
+}
+
+// catchUp delivers the events of the blocks after lastHeight up to the height.
+func (s *subscription) catchUp(ctx context.Context, height int64) error {
+	for s.lastHeight < height {
+		events, err := s.client.blockEvents(ctx, s.lastHeight+1)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if !s.filter.match(event) {
+				continue
+			}
+			select {
+			case s.out <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		s.lastHeight++
+	}
+	return nil
+}
+
+// blockEvents returns the typed events emitted by the block, the events of the failed transactions are skipped.
+func (c *Client) blockEvents(ctx context.Context, height int64) ([]*types.Event, error) {
+	block, err := c.GetBlockByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.GetBlockResultByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	events := appendTypedEvents(nil, height, "", results.BeginBlockEvents)
+	for i, txResult := range results.TxsResults {
+		if txResult.Code != 0 || i >= len(block.Data.Txs) {
+			continue
+		}
+		txHash := fmt.Sprintf("%X", block.Data.Txs[i].Hash())
+		events = appendTypedEvents(events, height, txHash, txResult.Events)
+	}
+	return appendTypedEvents(events, height, "", results.EndBlockEvents), nil
+}
+
+// appendTypedEvents decodes the typed events and appends them to the events, the other events are skipped.
+func appendTypedEvents(events []*types.Event, height int64, txHash string, abciEvents []abci.Event) []*types.Event {
+	for _, abciEvent := range abciEvents {
+		// the typed events are named after their proto messages, e.g. mechain.storage.EventCreateBucket
+		if !strings.Contains(abciEvent.Type, ".") {
+			continue
+		}
+		msg, err := sdk.ParseTypedEvent(abciEvent)
+		if err != nil {
+			continue
+		}
+		name := proto.MessageName(msg)
+		events = append(events, &types.Event{
+			Height: height,
+			TxHash: txHash,
+			Type:   name[strings.LastIndex(name, ".")+1:],
+			Data:   msg,
+		})
+	}
+	return events
+}
+
+// DecodeTxResult - Decode the typed events of the transaction result returned by WaitForTx.
+//
+// - resultTx: The transaction result returned by WaitForTx or GetTx.
+//
+// - ret1: The typed events of the transaction, types.FindTxEvent finds the event of a type in it.
+//
+// - ret2: Return types.TxFailedError if the transaction has failed, otherwise return nil.
+func DecodeTxResult(resultTx *ctypes.ResultTx) (*types.TxResult, error) {
+	txHash := resultTx.Hash.String()
+	if resultTx.TxResult.Code != 0 {
+		return nil, &types.TxFailedError{
+			Op:        "tx",
+			TxHash:    txHash,
+			Code:      resultTx.TxResult.Code,
+			Codespace: resultTx.TxResult.Codespace,
+			Log:       resultTx.TxResult.Log,
+		}
+	}
+	return &types.TxResult{
+		TxHash: txHash,
+		Height: resultTx.Height,
+		Index:  resultTx.Index,
+		Events: appendTypedEvents(nil, resultTx.Height, txHash, resultTx.TxResult.Events),
+	}, nil
+}
+
+// DecodeBroadcastTxResponse - Decode the typed events of the response returned by BroadcastTx.
+//
+// The response carries the events only if the transaction has been included in a block when it returns, the events
+// of a transaction broadcast in sync mode are decoded from the result of WaitForTx instead.
+//
+// - resp: The response returned by BroadcastTx.
+//
+// - ret1: The typed events of the transaction, types.FindTxEvent finds the event of a type in it.
+//
+// - ret2: Return types.TxFailedError if the transaction has failed, otherwise return nil.
+func DecodeBroadcastTxResponse(resp *tx.BroadcastTxResponse) (*types.TxResult, error) {
+	txResp := resp.TxResponse
+	if txResp == nil {
+		return nil, errors.New("the broadcast response has no tx response")
+	}
+	if txResp.Code != 0 {
+		return nil, &types.TxFailedError{
+			Op:        "tx",
+			TxHash:    txResp.TxHash,
+			Code:      txResp.Code,
+			Codespace: txResp.Codespace,
+			Log:       txResp.RawLog,
+		}
+	}
+	return &types.TxResult{
+		TxHash: txResp.TxHash,
+		Height: txResp.Height,
+		Events: appendTypedEvents(nil, txResp.Height, txResp.TxHash, txResp.Events),
+	}, nil
+}
+
+// eventFilter matches the events with the filters of SubscribeOptions.
+type eventFilter struct {
+	eventTypes   map[string]bool
+	bucketName   string
+	owner        string
+	objectPrefix string
+}
+
+func newEventFilter(opts types.SubscribeOptions) (*eventFilter, error) {
+	f := &eventFilter{
+		eventTypes:   supportedEventTypes,
+		bucketName:   opts.BucketName,
+		objectPrefix: opts.ObjectPrefix,
+	}
+	if len(opts.EventTypes) > 0 {
+		f.eventTypes = make(map[string]bool, len(opts.EventTypes))
+		for _, eventType := range opts.EventTypes {
+			if !supportedEventTypes[eventType] {
+				return nil, fmt.Errorf("unsupported event type %s", eventType)
+			}
+			f.eventTypes[eventType] = true
+		}
+	}
+	if opts.Owner != "" {
+		owner, err := sdk.AccAddressFromHexUnsafe(opts.Owner)
+		if err != nil {
+			return nil, err
+		}
+		f.owner = owner.String()
+	}
+	return f, nil
+}
+
+func (f *eventFilter) match(event *types.Event) bool {
+	if !f.eventTypes[event.Type] {
+		return false
+	}
+	if f.bucketName != "" {
+		e, ok := event.Data.(interface{ GetBucketName() string })
+		if !ok || e.GetBucketName() != f.bucketName {
+			return false
+		}
+	}
+	if f.objectPrefix != "" {
+		e, ok := event.Data.(interface{ GetObjectName() string })
+		if !ok || !strings.HasPrefix(e.GetObjectName(), f.objectPrefix) {
+			return false
+		}
+	}
+	if f.owner != "" {
+		e, ok := event.Data.(interface{ GetOwner() string })
+		if !ok || !strings.EqualFold(e.GetOwner(), f.owner) {
+			return false
+		}
+	}
+	return true
+}
+
+// resetTimer resets the timer which may have fired, its channel is drained.
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
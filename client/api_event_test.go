@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	bfttypes "github.com/cometbft/cometbft/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// recordedEvent returns the typed event as it is recorded in the results of the blocks, the attributes are pairs of
+// the field names and the JSON values.
+func recordedEvent(eventType string, attrs ...string) abci.Event {
+	event := abci.Event{Type: eventType}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		event.Attributes = append(event.Attributes, abci.EventAttribute{Key: attrs[i], Value: attrs[i+1], Index: true})
+	}
+	return event
+}
+
+func createBucketEvent(bucketName, owner string) abci.Event {
+	return recordedEvent("mechain.storage.EventCreateBucket",
+		"bucket_name", `"`+bucketName+`"`, "bucket_id", `"1"`, "owner", `"`+owner+`"`, "visibility", `"VISIBILITY_TYPE_PRIVATE"`)
+}
+
+func createObjectEvent(bucketName, objectName, owner string) abci.Event {
+	return recordedEvent("mechain.storage.EventCreateObject",
+		"bucket_name", `"`+bucketName+`"`, "object_name", `"`+objectName+`"`, "owner", `"`+owner+`"`, "object_id", `"2"`)
+}
+
+func sealObjectEvent(bucketName, objectName string) abci.Event {
+	return recordedEvent("mechain.storage.EventSealObject",
+		"bucket_name", `"`+bucketName+`"`, "object_name", `"`+objectName+`"`, "status", `"OBJECT_STATUS_SEALED"`)
+}
+
+// newTestOwners returns the addresses of two accounts.
+func newTestOwners(t *testing.T) (string, string) {
+	a, _, err := types.NewAccount("a")
+	require.NoError(t, err)
+	b, _, err := types.NewAccount("b")
+	require.NoError(t, err)
+	return a.GetAddress().String(), b.GetAddress().String()
+}
+
+// eventNames returns the type, bucket and object names of the events.
+func eventNames(events []*types.Event) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		name := event.Type
+		if e, ok := event.Data.(interface{ GetBucketName() string }); ok {
+			name += " " + e.GetBucketName()
+		}
+		if e, ok := event.Data.(interface{ GetObjectName() string }); ok {
+			name += "/" + e.GetObjectName()
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestAppendTypedEvents(t *testing.T) {
+	owner, _ := newTestOwners(t)
+	events := appendTypedEvents(nil, 10, "ABCD", []abci.Event{
+		recordedEvent("message", "action", "/mechain.storage.MsgCreateBucket", "sender", owner),
+		createBucketEvent("photos", owner),
+		recordedEvent("mechain.storage.EventUnknown", "bucket_name", `"photos"`),
+		recordedEvent("mechain.storage.EventCreateObject", "bucket_name", "not json"),
+		createObjectEvent("photos", "a.jpg", owner),
+	})
+	require.Equal(t, []string{"EventCreateBucket photos", "EventCreateObject photos/a.jpg"}, eventNames(events),
+		"the untyped and undecodable events are skipped")
+
+	require.Equal(t, int64(10), events[0].Height)
+	require.Equal(t, "ABCD", events[0].TxHash)
+	bucket, ok := events[0].Data.(*storageTypes.EventCreateBucket)
+	require.True(t, ok)
+	require.Equal(t, owner, bucket.Owner)
+	require.Equal(t, uint64(1), bucket.BucketId.Uint64())
+	require.Equal(t, storageTypes.VISIBILITY_TYPE_PRIVATE, bucket.Visibility)
+	object, ok := events[1].Data.(*storageTypes.EventCreateObject)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), object.ObjectId.Uint64())
+}
+
+func TestEventFilter(t *testing.T) {
+	a, b := newTestOwners(t)
+	events := appendTypedEvents(nil, 1, "", []abci.Event{
+		createBucketEvent("photos", a),
+		createObjectEvent("photos", "2024/a.jpg", a),
+		createObjectEvent("docs", "2024/b.txt", b),
+		sealObjectEvent("photos", "tmp/c.jpg"),
+	})
+	require.Len(t, events, 4)
+
+	for _, tc := range []struct {
+		opts     types.SubscribeOptions
+		expected []string
+	}{
+		{types.SubscribeOptions{}, eventNames(events)},
+		{types.SubscribeOptions{EventTypes: []string{types.EventTypeCreateObject}},
+			[]string{"EventCreateObject photos/2024/a.jpg", "EventCreateObject docs/2024/b.txt"}},
+		{types.SubscribeOptions{BucketName: "photos"},
+			[]string{"EventCreateBucket photos", "EventCreateObject photos/2024/a.jpg", "EventSealObject photos/tmp/c.jpg"}},
+		// the events without object names do not match the prefix
+		{types.SubscribeOptions{ObjectPrefix: "2024/"},
+			[]string{"EventCreateObject photos/2024/a.jpg", "EventCreateObject docs/2024/b.txt"}},
+		// the owner is matched regardless of the case
+		{types.SubscribeOptions{Owner: strings.ToLower(a)},
+			[]string{"EventCreateBucket photos", "EventCreateObject photos/2024/a.jpg"}},
+		{types.SubscribeOptions{BucketName: "photos", ObjectPrefix: "tmp/"}, []string{"EventSealObject photos/tmp/c.jpg"}},
+		{types.SubscribeOptions{BucketName: "videos"}, []string{}},
+	} {
+		filter, err := newEventFilter(tc.opts)
+		require.NoError(t, err)
+		var matched []*types.Event
+		for _, event := range events {
+			if filter.match(event) {
+				matched = append(matched, event)
+			}
+		}
+		require.Equal(t, tc.expected, eventNames(matched), fmt.Sprintf("%+v", tc.opts))
+	}
+
+	_, err := newEventFilter(types.SubscribeOptions{EventTypes: []string{"EventUnknown"}})
+	require.ErrorContains(t, err, "unsupported event type")
+	_, err = newEventFilter(types.SubscribeOptions{Owner: "0xnot-an-address"})
+	require.Error(t, err)
+}
+
+// drainEvents returns the events delivered to the channel so far.
+func drainEvents(out chan *types.Event) []*types.Event {
+	var events []*types.Event
+	for {
+		select {
+		case event := <-out:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestSubscriptionCatchUp(t *testing.T) {
+	owner, _ := newTestOwners(t)
+	chain := newTestChain(t)
+	txs := []bfttypes.Tx{[]byte("tx1"), []byte("tx2"), []byte("tx3")}
+	chain.addBlock(testBlock{
+		txs:       txs[:1],
+		txResults: []*abci.ResponseDeliverTx{{Events: []abci.Event{createBucketEvent("photos", owner)}}},
+	})
+	chain.addBlock(testBlock{
+		txs: txs[1:3],
+		txResults: []*abci.ResponseDeliverTx{
+			// the events of the failed transactions are skipped
+			{Code: 1, Events: []abci.Event{createObjectEvent("photos", "failed.jpg", owner)}},
+			{Events: []abci.Event{createObjectEvent("photos", "a.jpg", owner)}},
+		},
+	})
+	chain.addBlock(testBlock{endEvents: []abci.Event{sealObjectEvent("photos", "a.jpg")}})
+	chain.addBlock(testBlock{})
+	cli := chain.newClient(t, Option{})
+	ctx := context.Background()
+
+	filter, err := newEventFilter(types.SubscribeOptions{BucketName: "photos"})
+	require.NoError(t, err)
+	s := &subscription{client: cli, filter: filter, out: make(chan *types.Event, defaultEventBufferSize), lastHeight: 1}
+
+	// the blocks after the last height are fetched
+	require.NoError(t, s.catchUp(ctx, 4))
+	events := drainEvents(s.out)
+	require.Equal(t, []string{"EventCreateObject photos/a.jpg", "EventSealObject photos/a.jpg"}, eventNames(events))
+	require.Equal(t, int64(2), events[0].Height)
+	require.Equal(t, fmt.Sprintf("%X", txs[2].Hash()), events[0].TxHash)
+	require.Equal(t, int64(3), events[1].Height)
+	require.Empty(t, events[1].TxHash)
+	require.Equal(t, int64(4), s.lastHeight)
+	require.Equal(t, 3, chain.callCount("block_results"))
+
+	// a block which fails to be fetched is fetched again by the next catch up, no event is delivered twice
+	chain.addBlock(testBlock{txs: txs[:1], txResults: []*abci.ResponseDeliverTx{{Events: []abci.Event{createObjectEvent("photos", "b.jpg", owner)}}}})
+	chain.addBlock(testBlock{txs: txs[1:2], txResults: []*abci.ResponseDeliverTx{{Events: []abci.Event{createObjectEvent("docs", "c.txt", owner)}}}})
+	chain.addBlock(testBlock{txs: txs[2:], txResults: []*abci.ResponseDeliverTx{{Events: []abci.Event{createObjectEvent("photos", "d.jpg", owner)}}}})
+	failed := false
+	chain.handle("block_results", func(params map[string]json.RawMessage) (interface{}, error) {
+		if height, _, _ := paramInt64(params, "height"); height == 7 && !failed {
+			failed = true
+			return nil, errors.New("unavailable")
+		}
+		return chain.blockResults(params)
+	})
+	require.Error(t, s.catchUp(ctx, 7))
+	require.Equal(t, []string{"EventCreateObject photos/b.jpg"}, eventNames(drainEvents(s.out)))
+	require.Equal(t, int64(6), s.lastHeight)
+
+	s.catchUpLatest(ctx)
+	require.Equal(t, []string{"EventCreateObject photos/d.jpg"}, eventNames(drainEvents(s.out)))
+	require.Equal(t, int64(7), s.lastHeight)
+	s.catchUpLatest(ctx)
+	require.Empty(t, drainEvents(s.out))
+}
+
+func TestNextReconnectBackoff(t *testing.T) {
+	backoff := time.Second
+	var backoffs []time.Duration
+	for i := 0; i < 7; i++ {
+		backoff = nextReconnectBackoff(backoff)
+		backoffs = append(backoffs, backoff)
+	}
+	require.Equal(t, []time.Duration{
+		2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second,
+		maxEventReconnectBackoff, maxEventReconnectBackoff, maxEventReconnectBackoff,
+	}, backoffs)
+}
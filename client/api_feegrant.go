@@ -7,7 +7,9 @@ import (
 	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdsdktypes "github.com/evmos/evmos/v12/sdk/types"
+	"google.golang.org/grpc"
 )
 
 type IFeeGrantClient interface {
@@ -33,7 +35,7 @@ func (c *Client) GrantBasicAllowance(ctx context.Context, granteeAddr string, fe
 		SpendLimit: azkme,
 		Expiration: expiration,
 	}
-	msg, err := feegrant.NewMsgGrantAllowance(&allowance, c.defaultAccount.GetAddress(), grantee)
+	msg, err := feegrant.NewMsgGrantAllowance(&allowance, c.mustGetCallAccount(ctx).GetAddress(), grantee)
 	if err != nil {
 		return "", err
 	}
@@ -50,7 +52,7 @@ func (c *Client) GrantAllowance(ctx context.Context, granteeAddr string, allowan
 	if err != nil {
 		return "", err
 	}
-	msg, err := feegrant.NewMsgGrantAllowance(allowance, c.defaultAccount.GetAddress(), grantee)
+	msg, err := feegrant.NewMsgGrantAllowance(allowance, c.mustGetCallAccount(ctx).GetAddress(), grantee)
 	if err != nil {
 		return "", err
 	}
@@ -67,7 +69,7 @@ func (c *Client) RevokeAllowance(ctx context.Context, granteeAddr string, txOpti
 	if err != nil {
 		return "", err
 	}
-	msg := feegrant.NewMsgRevokeAllowance(c.defaultAccount.GetAddress(), grantee)
+	msg := feegrant.NewMsgRevokeAllowance(c.mustGetCallAccount(ctx).GetAddress(), grantee)
 	if err != nil {
 		return "", err
 	}
@@ -85,7 +87,7 @@ func (c *Client) QueryBasicAllowance(ctx context.Context, granterAddr, granteeAd
 		return nil, err
 	}
 	basicAllowance := &feegrant.BasicAllowance{}
-	if err = c.chainClient.GetCodec().Unmarshal(allowance.Allowance.GetValue(), basicAllowance); err != nil {
+	if err = c.chain().GetCodec().Unmarshal(allowance.Allowance.GetValue(), basicAllowance); err != nil {
 		return nil, err
 	}
 	return basicAllowance, nil
@@ -104,7 +106,11 @@ func (c *Client) QueryAllowance(ctx context.Context, granterAddr, granteeAddr st
 		Granter: granterAddr,
 		Grantee: granteeAddr,
 	}
-	response, err := c.chainClient.FeegrantQueryClient.Allowance(ctx, req)
+	response, err := queryChain(ctx, c, "Allowance", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *feegrant.QueryAllowanceRequest, opts ...grpc.CallOption,
+	) (*feegrant.QueryAllowanceResponse, error) {
+		return chain.FeegrantQueryClient.Allowance(ctx, req, opts...)
+	}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +125,11 @@ func (c *Client) QueryAllowances(ctx context.Context, granteeAddr string) ([]*fe
 	req := &feegrant.QueryAllowancesRequest{
 		Grantee: granteeAddr,
 	}
-	response, err := c.chainClient.FeegrantQueryClient.Allowances(ctx, req)
+	response, err := queryChain(ctx, c, "Allowances", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *feegrant.QueryAllowancesRequest, opts ...grpc.CallOption,
+	) (*feegrant.QueryAllowancesResponse, error) {
+		return chain.FeegrantQueryClient.Allowances(ctx, req, opts...)
+	}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +144,11 @@ func (c *Client) QueryGranterAllowances(ctx context.Context, granterAddr string)
 	req := &feegrant.QueryAllowancesByGranterRequest{
 		Granter: granterAddr,
 	}
-	response, err := c.chainClient.FeegrantQueryClient.AllowancesByGranter(ctx, req)
+	response, err := queryChain(ctx, c, "AllowancesByGranter", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *feegrant.QueryAllowancesByGranterRequest, opts ...grpc.CallOption,
+	) (*feegrant.QueryAllowancesByGranterResponse, error) {
+		return chain.FeegrantQueryClient.AllowancesByGranter(ctx, req, opts...)
+	}, req)
 	if err != nil {
 		return nil, err
 	}
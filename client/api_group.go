@@ -13,10 +13,11 @@ import (
 	"time"
 
 	sdkmath "cosmossdk.io/math"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
-	"github.com/rs/zerolog/log"
 
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdsdk "github.com/evmos/evmos/v12/sdk/types"
 	gnfdTypes "github.com/evmos/evmos/v12/types"
 	permTypes "github.com/evmos/evmos/v12/x/permission/types"
@@ -28,6 +29,7 @@ import (
 // IGroupClient interface defines functions related to Group.
 type IGroupClient interface {
 	CreateGroup(ctx context.Context, groupName string, opt types.CreateGroupOptions) (string, error)
+	CreateGroupWithResult(ctx context.Context, groupName string, opt types.CreateGroupOptions) (*types.CreateGroupResult, error)
 	DeleteGroup(ctx context.Context, groupName string, opt types.DeleteGroupOption) (string, error)
 	UpdateGroupMember(ctx context.Context, groupName string, groupOwnerAddr string,
 		addAddresses, removeAddresses []string, opts types.UpdateGroupMemberOption) (string, error)
@@ -70,8 +72,23 @@ type IGroupClient interface {
 // - ret1: Transaction hash return from blockchain.
 //
 // - ret3: Return error when the request failed, otherwise return nil.
-func (c *Client) CreateGroup(ctx context.Context, groupName string, opt types.CreateGroupOptions) (string, error) {
-	createGroupMsg := storageTypes.NewMsgCreateGroup(c.MustGetDefaultAccount().GetAddress(), groupName, opt.Extra)
+func (c *Client) CreateGroup(ctx context.Context, groupName string, opt types.CreateGroupOptions) (txHash string, err error) {
+	ctx, span := c.startSpan(ctx, "CreateGroup", AttrGroupName.String(groupName))
+	defer func() {
+		span.SetAttributes(AttrTxHash.String(txHash))
+		endSpan(span, err)
+	}()
+
+	resp, err := c.broadcastCreateGroup(ctx, groupName, opt)
+	if err != nil {
+		return "", err
+	}
+	return resp.TxResponse.TxHash, nil
+}
+
+// broadcastCreateGroup broadcasts the transaction creating the group.
+func (c *Client) broadcastCreateGroup(ctx context.Context, groupName string, opt types.CreateGroupOptions) (*tx.BroadcastTxResponse, error) {
+	createGroupMsg := storageTypes.NewMsgCreateGroup(c.mustGetCallAccount(ctx).GetAddress(), groupName, opt.Extra)
 	// set the default txn broadcast mode as block mode
 	if opt.TxOpts == nil {
 		broadcastMode := tx.BroadcastMode_BROADCAST_MODE_SYNC
@@ -81,18 +98,65 @@ func (c *Client) CreateGroup(ctx context.Context, groupName string, opt types.Cr
 
 	if opt.Tags != nil {
 		// Set tag
-		grn := gnfdTypes.NewGroupGRN(c.MustGetDefaultAccount().GetAddress(), groupName)
-		msgSetTag := storageTypes.NewMsgSetTag(c.MustGetDefaultAccount().GetAddress(), grn.String(), opt.Tags)
+		grn := gnfdTypes.NewGroupGRN(c.mustGetCallAccount(ctx).GetAddress(), groupName)
+		msgSetTag := storageTypes.NewMsgSetTag(c.mustGetCallAccount(ctx).GetAddress(), grn.String(), opt.Tags)
 		msgs = append(msgs, msgSetTag)
 	}
 
-	resp, err := c.BroadcastTx(ctx, msgs, opt.TxOpts)
+	return c.BroadcastTx(ctx, msgs, opt.TxOpts)
+}
+
+// CreateGroupWithResult - Create a new group on Mechain, and return the event of creating the group.
+//
+// It is the same as CreateGroup, except that it waits for the transaction to be included, and the ID and the owner
+// of the new group are decoded from the transaction result, so that no HeadGroup query is needed.
+//
+// - ctx: Context variables for the current API call.
+//
+// - groupName: The group name identifies the group.
+//
+// - opt: The options for customizing a group and transaction.
+//
+// - ret1: The transaction hash and the event of creating the group.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) CreateGroupWithResult(ctx context.Context, groupName string, opt types.CreateGroupOptions) (result *types.CreateGroupResult, err error) {
+	ctx, span := c.startSpan(ctx, "CreateGroup", AttrGroupName.String(groupName))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(AttrTxHash.String(result.TxHash))
+		}
+		endSpan(span, err)
+	}()
+
+	resp, err := c.broadcastCreateGroup(ctx, groupName, opt)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	txnHash := resp.TxResponse.TxHash
-
-	return txnHash, nil
+	result = &types.CreateGroupResult{TxHash: txnHash}
+	if c.dryRun {
+		return result, nil
+	}
+
+	var txResult *types.TxResult
+	if resp.TxResponse.Height > 0 {
+		// the transaction has been included when it is broadcast, its events are in the response
+		txResult, err = DecodeBroadcastTxResponse(resp)
+	} else {
+		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
+		defer cancel()
+		var txnResponse *ctypes.ResultTx
+		if txnResponse, err = c.WaitForTx(ctxTimeout, txnHash); err != nil {
+			return result, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
+		}
+		txResult, err = DecodeTxResult(txnResponse)
+	}
+	if err != nil {
+		return result, err
+	}
+	result.Event, _ = types.FindTxEvent[*storageTypes.EventCreateGroup](txResult)
+	return result, nil
 }
 
 // DeleteGroup - Delete a group on Mechain blockchain. The sender MUST only be the group owner, group members or others would fail to send this transaction.
@@ -108,8 +172,14 @@ func (c *Client) CreateGroup(ctx context.Context, groupName string, opt types.Cr
 // - ret1: Transaction hash return from blockchain.
 //
 // - ret3: Return error when the request failed, otherwise return nil.
-func (c *Client) DeleteGroup(ctx context.Context, groupName string, opt types.DeleteGroupOption) (string, error) {
-	deleteGroupMsg := storageTypes.NewMsgDeleteGroup(c.MustGetDefaultAccount().GetAddress(), groupName)
+func (c *Client) DeleteGroup(ctx context.Context, groupName string, opt types.DeleteGroupOption) (txHash string, err error) {
+	ctx, span := c.startSpan(ctx, "DeleteGroup", AttrGroupName.String(groupName))
+	defer func() {
+		span.SetAttributes(AttrTxHash.String(txHash))
+		endSpan(span, err)
+	}()
+
+	deleteGroupMsg := storageTypes.NewMsgDeleteGroup(c.mustGetCallAccount(ctx).GetAddress(), groupName)
 	return c.sendTxn(ctx, deleteGroupMsg, opt.TxOpts)
 }
 
@@ -178,7 +248,7 @@ func (c *Client) UpdateGroupMember(ctx context.Context, groupName string, groupO
 		removeMembers = append(removeMembers, member)
 	}
 
-	updateGroupMsg := storageTypes.NewMsgUpdateGroupMember(c.MustGetDefaultAccount().GetAddress(), groupOwner, groupName, addMembers, removeMembers)
+	updateGroupMsg := storageTypes.NewMsgUpdateGroupMember(c.mustGetCallAccount(ctx).GetAddress(), groupOwner, groupName, addMembers, removeMembers)
 
 	return c.sendTxn(ctx, updateGroupMsg, opts.TxOpts)
 }
@@ -201,7 +271,7 @@ func (c *Client) LeaveGroup(ctx context.Context, groupName string, groupOwnerAdd
 	if err != nil {
 		return "", err
 	}
-	leaveGroupMsg := storageTypes.NewMsgLeaveGroup(c.MustGetDefaultAccount().GetAddress(), groupOwner, groupName)
+	leaveGroupMsg := storageTypes.NewMsgLeaveGroup(c.mustGetCallAccount(ctx).GetAddress(), groupOwner, groupName)
 	return c.sendTxn(ctx, leaveGroupMsg, opt.TxOpts)
 }
 
@@ -222,7 +292,7 @@ func (c *Client) HeadGroup(ctx context.Context, groupName string, groupOwnerAddr
 		GroupName:  groupName,
 	}
 
-	headGroupResponse, err := c.chainClient.HeadGroup(ctx, &headGroupRequest)
+	headGroupResponse, err := queryChain(ctx, c, "HeadGroup", (*sdkclient.MechainClient).HeadGroup, &headGroupRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +318,7 @@ func (c *Client) HeadGroupMember(ctx context.Context, groupName string, groupOwn
 		Member:     headMemberAddr,
 	}
 
-	_, err := c.chainClient.HeadGroupMember(ctx, &headGroupRequest)
+	_, err := queryChain(ctx, c, "HeadGroupMember", (*sdkclient.MechainClient).HeadGroupMember, &headGroupRequest)
 	return err == nil
 }
 
@@ -270,7 +340,7 @@ func (c *Client) HeadGroupMember(ctx context.Context, groupName string, groupOwn
 func (c *Client) PutGroupPolicy(ctx context.Context, groupName string, principalAddr string,
 	statements []*permTypes.Statement, opt types.PutPolicyOption,
 ) (string, error) {
-	sender := c.MustGetDefaultAccount().GetAddress()
+	sender := c.mustGetCallAccount(ctx).GetAddress()
 
 	resource := gnfdTypes.NewGroupGRN(sender, groupName)
 
@@ -304,7 +374,7 @@ func (c *Client) GetBucketPolicyOfGroup(ctx context.Context, bucketName string,
 		PrincipalGroupId: sdkmath.NewUint(groupId).String(),
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForGroup(ctx, &queryPolicy)
+	queryPolicyResp, err := queryChain(ctx, c, "QueryPolicyForGroup", (*sdkclient.MechainClient).QueryPolicyForGroup, &queryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -332,7 +402,7 @@ func (c *Client) GetObjectPolicyOfGroup(ctx context.Context, bucketName, objectN
 		PrincipalGroupId: sdkmath.NewUint(groupId).String(),
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForGroup(ctx, &queryPolicy)
+	queryPolicyResp, err := queryChain(ctx, c, "QueryPolicyForGroup", (*sdkclient.MechainClient).QueryPolicyForGroup, &queryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -354,7 +424,7 @@ func (c *Client) GetObjectPolicyOfGroup(ctx context.Context, bucketName, objectN
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) DeleteGroupPolicy(ctx context.Context, groupName string, principalAddr string, opt types.DeletePolicyOption) (string, error) {
-	sender := c.MustGetDefaultAccount().GetAddress()
+	sender := c.mustGetCallAccount(ctx).GetAddress()
 	resource := gnfdTypes.NewGroupGRN(sender, groupName).String()
 
 	addr, err := sdk.AccAddressFromHexUnsafe(principalAddr)
@@ -383,7 +453,7 @@ func (c *Client) GetGroupPolicy(ctx context.Context, groupName string, principal
 	if err != nil {
 		return nil, err
 	}
-	sender := c.MustGetDefaultAccount().GetAddress()
+	sender := c.mustGetCallAccount(ctx).GetAddress()
 	resource := gnfdTypes.NewGroupGRN(sender, groupName).String()
 
 	queryPolicy := storageTypes.QueryPolicyForAccountRequest{
@@ -391,7 +461,7 @@ func (c *Client) GetGroupPolicy(ctx context.Context, groupName string, principal
 		PrincipalAddress: principalAddr,
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForAccount(ctx, &queryPolicy)
+	queryPolicyResp, err := queryChain(ctx, c, "QueryPolicyForAccount", (*sdkclient.MechainClient).QueryPolicyForAccount, &queryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -463,13 +533,13 @@ func (c *Client) ListGroup(ctx context.Context, name, prefix string, opts types.
 		SPAddress: opts.SPAddress,
 	})
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return types.ListGroupsResult{}, err
 	}
 
 	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
 	if err != nil {
-		log.Error().Msg("the list of groups failed: " + err.Error())
+		c.logger.Error("list groups failed", "error", err)
 		return types.ListGroupsResult{}, err
 	}
 	defer utils.CloseResponse(resp)
@@ -478,7 +548,7 @@ func (c *Client) ListGroup(ctx context.Context, name, prefix string, opts types.
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msg("the list of groups failed: " + err.Error())
+		c.logger.Error("list groups failed", "error", err)
 		return types.ListGroupsResult{}, err
 	}
 
@@ -486,7 +556,7 @@ func (c *Client) ListGroup(ctx context.Context, name, prefix string, opts types.
 	bufStr := buf.String()
 	err = xml.Unmarshal([]byte(bufStr), &listGroupsResult)
 	if err != nil {
-		log.Error().Msg("the list of groups failed: " + err.Error())
+		c.logger.Error("list groups failed", "error", err)
 		return types.ListGroupsResult{}, err
 	}
 
@@ -539,7 +609,7 @@ func (c *Client) RenewGroupMember(ctx context.Context, groupOwnerAddr, groupName
 		}
 		renewMembers = append(renewMembers, m)
 	}
-	msg := storageTypes.NewMsgRenewGroupMember(c.MustGetDefaultAccount().GetAddress(), groupOwner, groupName, renewMembers)
+	msg := storageTypes.NewMsgRenewGroupMember(c.mustGetCallAccount(ctx).GetAddress(), groupOwner, groupName, renewMembers)
 	return c.sendTxn(ctx, msg, opts.TxOpts)
 }
 
@@ -572,7 +642,7 @@ func (c *Client) ListGroupMembers(ctx context.Context, groupID int64, opts types
 		SPAddress: opts.SPAddress,
 	})
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return &types.GroupMembersResult{}, err
 	}
 
@@ -586,7 +656,7 @@ func (c *Client) ListGroupMembers(ctx context.Context, groupID int64, opts types
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msgf("get groups info by a user address in group id:%v failed: %s", groupID, err.Error())
+		c.logger.Error("list group members failed", "group_id", groupID, "error", err)
 		return &types.GroupMembersResult{}, err
 	}
 
@@ -595,7 +665,7 @@ func (c *Client) ListGroupMembers(ctx context.Context, groupID int64, opts types
 	// TODO change the format to XML later
 	err = xml.Unmarshal([]byte(bufStr), &groups)
 	if err != nil {
-		log.Error().Msgf("get groups info by a user address in group id:%v failed: %s", groupID, err.Error())
+		c.logger.Error("list group members failed", "group_id", groupID, "error", err)
 		return &types.GroupMembersResult{}, err
 	}
 
@@ -619,9 +689,9 @@ func (c *Client) ListGroupsByAccount(ctx context.Context, opts types.GroupsPagin
 
 	account := opts.Account
 	if account == "" {
-		acc, err := c.GetDefaultAccount()
+		acc, err := c.getCallAccount(ctx)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("failed to get default account:  %s", err.Error()))
+			c.logger.Error("get call account failed", "error", err)
 			return &types.GroupsResult{}, err
 		}
 		account = acc.GetAddress().String()
@@ -643,7 +713,7 @@ func (c *Client) ListGroupsByAccount(ctx context.Context, opts types.GroupsPagin
 		SPAddress: opts.SPAddress,
 	})
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return &types.GroupsResult{}, err
 	}
 
@@ -657,7 +727,7 @@ func (c *Client) ListGroupsByAccount(ctx context.Context, opts types.GroupsPagin
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msgf("get group members by group id in account id:%v failed: %s", account, err.Error())
+		c.logger.Error("list groups by account failed", "account", account, "error", err)
 		return &types.GroupsResult{}, err
 	}
 
@@ -666,7 +736,7 @@ func (c *Client) ListGroupsByAccount(ctx context.Context, opts types.GroupsPagin
 	// TODO change the format to XML later
 	err = xml.Unmarshal([]byte(bufStr), &groups)
 	if err != nil {
-		log.Error().Msgf("get group members by group id in account id:%v failed: %s", account, err.Error())
+		c.logger.Error("list groups by account failed", "account", account, "error", err)
 		return &types.GroupsResult{}, err
 	}
 
@@ -690,9 +760,9 @@ func (c *Client) ListGroupsByOwner(ctx context.Context, opts types.GroupsOwnerPa
 
 	owner := opts.Owner
 	if owner == "" {
-		acc, err := c.GetDefaultAccount()
+		acc, err := c.getCallAccount(ctx)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("get default owner failed %s", err.Error()))
+			c.logger.Error("get call account failed", "error", err)
 			return &types.GroupsResult{}, err
 		}
 		owner = acc.GetAddress().String()
@@ -714,7 +784,7 @@ func (c *Client) ListGroupsByOwner(ctx context.Context, opts types.GroupsOwnerPa
 		SPAddress: opts.SPAddress,
 	})
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return &types.GroupsResult{}, err
 	}
 
@@ -728,7 +798,7 @@ func (c *Client) ListGroupsByOwner(ctx context.Context, opts types.GroupsOwnerPa
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msgf("retrieve groups where the user is the owner in account id:%v failed: %s", owner, err.Error())
+		c.logger.Error("list groups by owner failed", "owner", owner, "error", err)
 		return &types.GroupsResult{}, err
 	}
 
@@ -737,7 +807,7 @@ func (c *Client) ListGroupsByOwner(ctx context.Context, opts types.GroupsOwnerPa
 	bufStr := buf.String()
 	err = xml.Unmarshal([]byte(bufStr), &groups)
 	if err != nil {
-		log.Error().Msgf("retrieve groups where the user is the owner in account id:%v failed: %s", owner, err.Error())
+		c.logger.Error("list groups by owner failed", "owner", owner, "error", err)
 		return &types.GroupsResult{}, err
 	}
 
@@ -819,7 +889,7 @@ func (c *Client) ListGroupsByGroupID(ctx context.Context, groupIDs []uint64, opt
 
 	endpoint, err := c.getEndpointByOpt(&opts)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return types.ListGroupsByGroupIDResponse{}, err
 
 	}
@@ -833,7 +903,7 @@ func (c *Client) ListGroupsByGroupID(ctx context.Context, groupIDs []uint64, opt
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msgf("the list of groups in group ids:%v failed: %s", groupIDs, err.Error())
+		c.logger.Error("list groups by ids failed", "group_ids", groupIDs, "error", err)
 		return types.ListGroupsByGroupIDResponse{}, err
 	}
 
@@ -841,7 +911,7 @@ func (c *Client) ListGroupsByGroupID(ctx context.Context, groupIDs []uint64, opt
 	bufStr := buf.String()
 	err = xml.Unmarshal([]byte(bufStr), (*gfSpListGroupsByGroupIDsResponse)(&groups.Groups))
 	if err != nil && groups.Groups == nil {
-		log.Error().Msgf("the list of groups in group ids:%v failed: %s", groups, err.Error())
+		c.logger.Error("list groups by ids failed", "group_ids", groupIDs, "error", err)
 		return types.ListGroupsByGroupIDResponse{}, err
 	}
 
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// heightContextKey is the context key of the height set by WithHeight.
+type heightContextKey struct{}
+
+// WithHeight - Return a copy of ctx which makes the queries to the chain return the state at the given block height
+// instead of the latest state, e.g. HeadBucket, HeadObject, GetBucketPolicy, GetAccountBalance, GetStreamRecord and
+// GetStoragePrice.
+//
+// The height is sent in the x-cosmos-block-height gRPC metadata, the node should keep the state of the height, which
+// may have been pruned. GetBlockHeightByTime resolves the height of a timestamp.
+//
+// - ctx: The parent context.
+//
+// - height: The block height to query the state at.
+//
+// - ret: The context carrying the height.
+func WithHeight(ctx context.Context, height int64) context.Context {
+	return context.WithValue(ctx, heightContextKey{}, height)
+}
+
+// HeightFromContext - Get the height set by WithHeight.
+//
+// - ctx: The context of the API call.
+//
+// - ret1: The height set by WithHeight.
+//
+// - ret2: Whether a height has been set.
+func HeightFromContext(ctx context.Context) (int64, bool) {
+	height, ok := ctx.Value(heightContextKey{}).(int64)
+	return height, ok && height > 0
+}
+
+// withHeightMetadata returns the context whose outgoing gRPC metadata carries the height set by WithHeight.
+func withHeightMetadata(ctx context.Context) context.Context {
+	height, ok := HeightFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+}
+
+// GetBlockHeightByTime - Get the height of the last block committed at or before the given time, it is found by
+// binary search over the blocks kept by the node.
+//
+// - ctx: Context variables for the current API call.
+//
+// - t: The time to resolve.
+//
+// - ret1: The height of the block, it can be used by WithHeight to query the state at the time.
+//
+// - ret2: Return error if the time is before the earliest block kept by the node, or the request failed.
+func (c *Client) GetBlockHeightByTime(ctx context.Context, t time.Time) (int64, error) {
+	status, err := c.GetStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+	earliest, latest := status.SyncInfo.EarliestBlockHeight, status.SyncInfo.LatestBlockHeight
+	if !status.SyncInfo.LatestBlockTime.After(t) {
+		return latest, nil
+	}
+	if status.SyncInfo.EarliestBlockTime.After(t) {
+		return 0, fmt.Errorf("the time %s is before the earliest block %d at %s kept by the node",
+			t.Format(time.RFC3339), earliest, status.SyncInfo.EarliestBlockTime.Format(time.RFC3339))
+	}
+
+	// find the first block after t in (earliest, latest], the block before it is the result
+	var searchErr error
+	n := sort.Search(int(latest-earliest), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		block, err := c.GetBlockByHeight(ctx, earliest+1+int64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return block.Header.Time.After(t)
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	return earliest + int64(n), nil
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlockHeightByTime(t *testing.T) {
+	chain := newTestChain(t)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// the blocks 1 to 5 are committed every 2 seconds
+	for i := 0; i < 5; i++ {
+		chain.addBlock(testBlock{time: base.Add(time.Duration(i) * 2 * time.Second)})
+	}
+	cli := chain.newClient(t, Option{})
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name     string
+		offset   time.Duration
+		expected int64
+	}{
+		{"earliest block", 0, 1},
+		{"between the earliest blocks", time.Second, 1},
+		{"exact block time", 4 * time.Second, 3},
+		{"just before a block", 6*time.Second - time.Nanosecond, 3},
+		{"between the latest blocks", 7 * time.Second, 4},
+		{"latest block", 8 * time.Second, 5},
+		{"after the latest block", time.Hour, 5},
+	} {
+		height, err := cli.GetBlockHeightByTime(ctx, base.Add(tc.offset))
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, height, tc.name)
+	}
+
+	calls := chain.callCount("block")
+	_, err := cli.GetBlockHeightByTime(ctx, base.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, calls, chain.callCount("block"), "no block is fetched at or after the latest block")
+
+	_, err = cli.GetBlockHeightByTime(ctx, base.Add(-time.Second))
+	require.ErrorContains(t, err, "before the earliest block 1")
+
+	chain.handle("block", func(map[string]json.RawMessage) (interface{}, error) {
+		return nil, errors.New("unavailable")
+	})
+	_, err = cli.GetBlockHeightByTime(ctx, base.Add(time.Second))
+	require.ErrorContains(t, err, "unavailable")
+}
@@ -19,8 +19,9 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
-	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
 
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdsdk "github.com/evmos/evmos/v12/sdk/types"
 	gnfdTypes "github.com/evmos/evmos/v12/types"
 	"github.com/evmos/evmos/v12/types/s3util"
@@ -36,6 +37,7 @@ import (
 type IObjectClient interface {
 	GetCreateObjectApproval(ctx context.Context, createObjectMsg *storageTypes.MsgCreateObject) (*storageTypes.MsgCreateObject, error)
 	CreateObject(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (string, error)
+	CreateObjectWithResult(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (*types.CreateObjectResult, error)
 	UpdateObjectContent(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.UpdateObjectOptions) (string, error)
 	CancelUpdateObjectContent(ctx context.Context, bucketName, objectName string, opts types.CancelUpdateObjectOption) (string, error)
 	PutObject(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader, opts types.PutObjectOptions) error
@@ -69,7 +71,11 @@ type IObjectClient interface {
 // configuration on chain
 func (c *Client) GetRedundancyParams() (uint32, uint32, uint64, error) {
 	query := storageTypes.QueryParamsRequest{}
-	queryResp, err := c.chainClient.StorageQueryClient.Params(context.Background(), &query)
+	queryResp, err := queryChain(context.Background(), c, "StorageParams", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *storageTypes.QueryParamsRequest, opts ...grpc.CallOption,
+	) (*storageTypes.QueryParamsResponse, error) {
+		return chain.StorageQueryClient.Params(ctx, req, opts...)
+	}, &query)
 	if err != nil {
 		return 0, 0, 0, err
 	}
@@ -82,7 +88,11 @@ func (c *Client) GetRedundancyParams() (uint32, uint32, uint64, error) {
 // configuration on chain
 func (c *Client) GetParams() (storageTypes.Params, error) {
 	query := storageTypes.QueryParamsRequest{}
-	queryResp, err := c.chainClient.StorageQueryClient.Params(context.Background(), &query)
+	queryResp, err := queryChain(context.Background(), c, "StorageParams", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *storageTypes.QueryParamsRequest, opts ...grpc.CallOption,
+	) (*storageTypes.QueryParamsResponse, error) {
+		return chain.StorageQueryClient.Params(ctx, req, opts...)
+	}, &query)
 	if err != nil {
 		return storageTypes.Params{}, err
 	}
@@ -108,26 +118,47 @@ func (c *Client) ComputeHashRoots(reader io.Reader, isSerial bool) ([][]byte, in
 func (c *Client) CreateObject(ctx context.Context, bucketName, objectName string,
 	reader io.Reader, opts types.CreateObjectOptions,
 ) (string, error) {
+	result, err := c.CreateObjectWithResult(ctx, bucketName, objectName, reader, opts)
+	if result == nil {
+		return "", err
+	}
+	return result.TxHash, err
+}
+
+// CreateObjectWithResult get approval of creating object and send createObject txn to mechain chain, it returns
+// the transaction hash and the event of creating the object, e.g. the ID, the owner and the checksums of the object.
+// The event is nil in asynchronous mode.
+func (c *Client) CreateObjectWithResult(ctx context.Context, bucketName, objectName string,
+	reader io.Reader, opts types.CreateObjectOptions,
+) (result *types.CreateObjectResult, err error) {
+	ctx, span := c.startSpan(ctx, "CreateObject", bucketAttrs(bucketName, objectName)...)
+	defer func() {
+		if result != nil {
+			span.SetAttributes(AttrTxHash.String(result.TxHash))
+		}
+		endSpan(span, err)
+	}()
+
 	if reader == nil {
-		return "", errors.New("fail to compute hash of payload, reader is nil")
+		return nil, errors.New("fail to compute hash of payload, reader is nil")
 	}
 
 	if err := s3util.CheckValidBucketName(bucketName); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if err := s3util.CheckValidObjectName(objectName); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if !utils.CheckObjectName(objectName) {
-		return "", fmt.Errorf("fail to check object name:%s", objectName)
+		return nil, fmt.Errorf("fail to check object name:%s", objectName)
 	}
 
 	// compute hash root of payload
 	expectCheckSums, size, redundancyType, err := c.ComputeHashRoots(reader, opts.IsSerialComputeMode)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var contentType string
@@ -144,12 +175,12 @@ func (c *Client) CreateObject(ctx context.Context, bucketName, objectName string
 		visibility = opts.Visibility
 	}
 
-	createObjectMsg := storageTypes.NewMsgCreateObject(c.MustGetDefaultAccount().GetAddress(), bucketName, objectName,
+	createObjectMsg := storageTypes.NewMsgCreateObject(c.mustGetCallAccount(ctx).GetAddress(), bucketName, objectName,
 		uint64(size), visibility, expectCheckSums, contentType, redundancyType, math.MaxUint, nil)
 
 	err = createObjectMsg.ValidateBasic()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// set the default txn broadcast mode as block mode
@@ -162,35 +193,53 @@ func (c *Client) CreateObject(ctx context.Context, bucketName, objectName string
 	if opts.Tags != nil {
 		// Set tag
 		grn := gnfdTypes.NewObjectGRN(bucketName, objectName)
-		msgSetTag := storageTypes.NewMsgSetTag(c.MustGetDefaultAccount().GetAddress(), grn.String(), opts.Tags)
+		msgSetTag := storageTypes.NewMsgSetTag(c.mustGetCallAccount(ctx).GetAddress(), grn.String(), opts.Tags)
 		msgs = append(msgs, msgSetTag)
 	}
 
 	resp, err := c.BroadcastTx(ctx, msgs, opts.TxOpts)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	txnHash := resp.TxResponse.TxHash
-	if !opts.IsAsyncMode {
+	result = &types.CreateObjectResult{TxHash: txnHash}
+	if !opts.IsAsyncMode && !c.dryRun {
 		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
 		defer cancel()
 		txnResponse, err := c.WaitForTx(ctxTimeout, txnHash)
 		if err != nil {
-			return txnHash, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
+			return result, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
 		}
 		if txnResponse.TxResult.Code != 0 {
-			return txnHash, fmt.Errorf("the createObject txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
+			return result, &types.TxFailedError{
+				Op:        "createObject txn",
+				TxHash:    txnHash,
+				Code:      txnResponse.TxResult.Code,
+				Codespace: txnResponse.TxResult.Codespace,
+				Log:       txnResponse.TxResult.Log,
+			}
 		}
+		txResult, err := DecodeTxResult(txnResponse)
+		if err != nil {
+			return result, err
+		}
+		result.Event, _ = types.FindTxEvent[*storageTypes.EventCreateObject](txResult)
 	}
-	return txnHash, nil
+	return result, nil
 }
 
 // UpdateObjectContent sends updateObjectContent tx to mechain chain,
 // it returns the transaction hash value and error
 func (c *Client) UpdateObjectContent(ctx context.Context, bucketName, objectName string,
 	reader io.Reader, opts types.UpdateObjectOptions,
-) (string, error) {
+) (txHash string, err error) {
+	ctx, span := c.startSpan(ctx, "UpdateObjectContent", bucketAttrs(bucketName, objectName)...)
+	defer func() {
+		span.SetAttributes(AttrTxHash.String(txHash))
+		endSpan(span, err)
+	}()
+
 	if reader == nil {
 		return "", errors.New("fail to compute hash of payload, reader is nil")
 	}
@@ -199,14 +248,14 @@ func (c *Client) UpdateObjectContent(ctx context.Context, bucketName, objectName
 		return "", err
 	}
 	if object.ObjectInfo.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
-		return "", errors.New("object not sealed can not be updated")
+		return "", types.WrapError(errors.New("object not sealed can not be updated"), types.ErrObjectNotSealed)
 	}
 	// compute hash root of payload
 	expectCheckSums, size, _, err := c.ComputeHashRoots(reader, opts.IsSerialComputeMode)
 	if err != nil {
 		return "", err
 	}
-	updateObjectContentMsg := storageTypes.NewMsgUpdateObjectContent(c.MustGetDefaultAccount().GetAddress(), bucketName, objectName,
+	updateObjectContentMsg := storageTypes.NewMsgUpdateObjectContent(c.mustGetCallAccount(ctx).GetAddress(), bucketName, objectName,
 		uint64(size), expectCheckSums)
 	if opts.TxOpts == nil {
 		broadcastMode := tx.BroadcastMode_BROADCAST_MODE_SYNC
@@ -217,7 +266,7 @@ func (c *Client) UpdateObjectContent(ctx context.Context, bucketName, objectName
 		return "", err
 	}
 	txnHash := resp.TxResponse.TxHash
-	if !opts.IsAsyncMode {
+	if !opts.IsAsyncMode && !c.dryRun {
 		ctxTimeout, cancel := context.WithTimeout(ctx, types.ContextTimeout)
 		defer cancel()
 		txnResponse, err := c.WaitForTx(ctxTimeout, txnHash)
@@ -225,7 +274,13 @@ func (c *Client) UpdateObjectContent(ctx context.Context, bucketName, objectName
 			return txnHash, fmt.Errorf("the transaction has been submitted, please check it later:%v", err)
 		}
 		if txnResponse.TxResult.Code != 0 {
-			return txnHash, fmt.Errorf("the updateObjectContent txn has failed with response code: %d, codespace:%s", txnResponse.TxResult.Code, txnResponse.TxResult.Codespace)
+			return txnHash, &types.TxFailedError{
+				Op:        "updateObjectContent txn",
+				TxHash:    txnHash,
+				Code:      txnResponse.TxResult.Code,
+				Codespace: txnResponse.TxResult.Codespace,
+				Log:       txnResponse.TxResult.Log,
+			}
 		}
 	}
 	return txnHash, nil
@@ -242,7 +297,7 @@ func (c *Client) CancelUpdateObjectContent(ctx context.Context, bucketName, obje
 		return "", err
 	}
 
-	msg := storageTypes.NewMsgCancelUpdateObjectContent(c.MustGetDefaultAccount().GetAddress(), bucketName, objectName)
+	msg := storageTypes.NewMsgCancelUpdateObjectContent(c.mustGetCallAccount(ctx).GetAddress(), bucketName, objectName)
 	return c.sendTxn(ctx, msg, opts.TxOpts)
 }
 
@@ -259,7 +314,13 @@ func (c *Client) CancelUpdateObjectContent(ctx context.Context, bucketName, obje
 // - ret1: Transaction hash return from blockchain.
 //
 // - ret2: Return error if delete bucket failed, otherwise return nil.
-func (c *Client) DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (string, error) {
+func (c *Client) DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (txHash string, err error) {
+	ctx, span := c.startSpan(ctx, "DeleteObject", bucketAttrs(bucketName, objectName)...)
+	defer func() {
+		span.SetAttributes(AttrTxHash.String(txHash))
+		endSpan(span, err)
+	}()
+
 	if err := s3util.CheckValidBucketName(bucketName); err != nil {
 		return "", err
 	}
@@ -268,12 +329,18 @@ func (c *Client) DeleteObject(ctx context.Context, bucketName, objectName string
 		return "", err
 	}
 
-	delObjectMsg := storageTypes.NewMsgDeleteObject(c.MustGetDefaultAccount().GetAddress(), bucketName, objectName)
+	delObjectMsg := storageTypes.NewMsgDeleteObject(c.mustGetCallAccount(ctx).GetAddress(), bucketName, objectName)
 	return c.sendTxn(ctx, delObjectMsg, opt.TxOpts)
 }
 
 // CancelCreateObject send CancelCreateObject txn to mechain chain
-func (c *Client) CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (string, error) {
+func (c *Client) CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (txHash string, err error) {
+	ctx, span := c.startSpan(ctx, "CancelCreateObject", bucketAttrs(bucketName, objectName)...)
+	defer func() {
+		span.SetAttributes(AttrTxHash.String(txHash))
+		endSpan(span, err)
+	}()
+
 	if err := s3util.CheckValidBucketName(bucketName); err != nil {
 		return "", err
 	}
@@ -282,7 +349,7 @@ func (c *Client) CancelCreateObject(ctx context.Context, bucketName, objectName
 		return "", err
 	}
 
-	cancelCreateMsg := storageTypes.NewMsgCancelCreateObject(c.MustGetDefaultAccount().GetAddress(), bucketName, objectName)
+	cancelCreateMsg := storageTypes.NewMsgCancelCreateObject(c.mustGetCallAccount(ctx).GetAddress(), bucketName, objectName)
 	return c.sendTxn(ctx, cancelCreateMsg, opt.TxOpts)
 }
 
@@ -291,6 +358,9 @@ func (c *Client) CancelCreateObject(ctx context.Context, bucketName, objectName
 func (c *Client) PutObject(ctx context.Context, bucketName, objectName string, objectSize int64,
 	reader io.Reader, opts types.PutObjectOptions,
 ) (err error) {
+	ctx, span := c.startSpan(ctx, "PutObject", append(bucketAttrs(bucketName, objectName), AttrBytesOut.Int64(objectSize))...)
+	defer func() { endSpan(span, err) }()
+
 	if objectSize <= 0 {
 		return errors.New("object size should be more than 0")
 	}
@@ -320,7 +390,7 @@ func (c *Client) putObject(ctx context.Context, bucketName, objectName string, o
 ) (err error) {
 	if !opts.Delegated {
 		if err := c.headSPObjectInfo(ctx, bucketName, objectName); err != nil {
-			log.Error().Msg(fmt.Sprintf("fail to head object %s , err %v ", objectName, err))
+			c.logger.Error("head object failed", "bucket", bucketName, "object", objectName, "error", err)
 			return err
 		}
 	}
@@ -367,7 +437,7 @@ func (c *Client) putObject(ctx context.Context, bucketName, objectName string, o
 
 	endpoint, err := c.getSPUrlByBucket(bucketName)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
+		c.logger.Error("route endpoint by bucket failed", "bucket", bucketName, "error", err)
 		return err
 	}
 
@@ -407,7 +477,7 @@ func (c *Client) delegateCreateFolder(ctx context.Context, bucketName, objectNam
 
 	endpoint, err := c.getSPUrlByBucket(bucketName)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
+		c.logger.Error("route endpoint by bucket failed", "bucket", bucketName, "error", err)
 		return err
 	}
 
@@ -470,7 +540,7 @@ func (c *Client) putObjectResumable(ctx context.Context, bucketName, objectName
 			break
 		}
 		// Increment part number.
-		log.Debug().Msg(fmt.Sprintf("skip partNumber:%d, length:%d", partNumber, length))
+		c.logger.Debug("skip uploaded part", "part_number", partNumber, "length", length)
 		// Save successfully uploaded size.
 		totalUploadedSize += int64(length)
 		partNumber++
@@ -492,7 +562,7 @@ func (c *Client) putObjectResumable(ctx context.Context, bucketName, objectName
 			return err
 		}
 
-		log.Debug().Msg(fmt.Sprintf("partNumber:%d, length:%d", partNumber, length))
+		c.logger.Debug("upload part", "part_number", partNumber, "length", length)
 
 		// Update progress reader appropriately to the latest offset
 		// as we read from the source.
@@ -542,7 +612,7 @@ func (c *Client) putObjectResumable(ctx context.Context, bucketName, objectName
 
 		endpoint, err := c.getSPUrlByBucket(bucketName)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
+			c.logger.Error("route endpoint by bucket failed", "bucket", bucketName, "error", err)
 			return err
 		}
 
@@ -576,7 +646,7 @@ func (c *Client) headSPObjectInfo(ctx context.Context, bucketName, objectName st
 			return nil
 		}
 		// if the error is not "no such object", ignore it
-		if !strings.Contains(strings.ToLower(err.Error()), types.NoSuchObjectErr) {
+		if !errors.Is(err, types.ErrNoSuchObject) {
 			return nil
 		}
 
@@ -612,8 +682,15 @@ func (c *Client) FPutObject(ctx context.Context, bucketName, objectName, filePat
 // GetObject download s3 object payload and return the related object info
 func (c *Client) GetObject(ctx context.Context, bucketName, objectName string,
 	opts types.GetObjectOptions,
-) (io.ReadCloser, types.ObjectStat, error) {
-	var err error
+) (_ io.ReadCloser, objStat types.ObjectStat, err error) {
+	ctx, span := c.startSpan(ctx, "GetObject", bucketAttrs(bucketName, objectName)...)
+	defer func() {
+		// the span of a successful download is ended when the returned body is closed
+		if err != nil {
+			endSpan(span, err)
+		}
+	}()
+
 	if err = s3util.CheckValidBucketName(bucketName); err != nil {
 		return nil, types.ObjectStat{}, err
 	}
@@ -644,7 +721,7 @@ func (c *Client) GetObject(ctx context.Context, bucketName, objectName string,
 	} else {
 		endpoint, err = c.getSPUrlByBucket(bucketName)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed,  err: %s", bucketName, err.Error()))
+			c.logger.Error("route endpoint by bucket failed", "bucket", bucketName, "error", err)
 			return nil, types.ObjectStat{}, err
 		}
 	}
@@ -654,17 +731,24 @@ func (c *Client) GetObject(ctx context.Context, bucketName, objectName string,
 		return nil, types.ObjectStat{}, err
 	}
 
-	objStat, err := getObjInfo(objectName, resp.Header)
+	objStat, err = getObjInfo(objectName, resp.Header)
 	if err != nil {
 		utils.CloseResponse(resp)
 		return nil, types.ObjectStat{}, err
 	}
 
-	return resp.Body, objStat, nil
+	body := &spanBody{ReadCloser: resp.Body, done: func(_ int, bytesIn int64, _ error) {
+		span.SetAttributes(AttrBytesIn.Int64(bytesIn))
+		span.End()
+	}}
+	return body, objStat, nil
 }
 
 // FGetObject download s3 object payload adn write the object content into local file specified by filePath
-func (c *Client) FGetObject(ctx context.Context, bucketName, objectName, filePath string, opts types.GetObjectOptions) error {
+func (c *Client) FGetObject(ctx context.Context, bucketName, objectName, filePath string, opts types.GetObjectOptions) (err error) {
+	ctx, span := c.startSpan(ctx, "FGetObject", bucketAttrs(bucketName, objectName)...)
+	defer func() { endSpan(span, err) }()
+
 	// Verify if destination already exists.
 	st, err := os.Stat(filePath)
 	if err == nil {
@@ -704,14 +788,17 @@ func getSegmentEnd(begin int64, total int64, per int64) int64 {
 }
 
 // FGetObjectResumable download s3 object payload with resumable download
-func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName, filePath string, opts types.GetObjectOptions) error {
+func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName, filePath string, opts types.GetObjectOptions) (err error) {
+	ctx, span := c.startSpan(ctx, "FGetObjectResumable", bucketAttrs(bucketName, objectName)...)
+	defer func() { endSpan(span, err) }()
+
 	// Get the object detailed meta for object whole size
 	meta, err := c.HeadObject(ctx, bucketName, objectName)
 	if err != nil {
 		return err
 	}
 
-	tempFilePath := filePath + "_" + c.defaultAccount.GetAddress().String() + opts.Range + types.TempFileSuffix
+	tempFilePath := filePath + "_" + c.mustGetCallAccount(ctx).GetAddress().String() + opts.Range + types.TempFileSuffix
 
 	var (
 		startOffset    int64
@@ -788,7 +875,7 @@ func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName
 			truncateOffset = 0
 			startOffset = 0
 		}
-		log.Debug().Msgf("The file:%s size:%d, startOffset:%d, range:%s\n", tempFilePath, fileSize, startOffset, opts.Range)
+		c.logger.Debug("resume downloading to the temp file", "file", tempFilePath, "size", fileSize, "start_offset", startOffset, "range", opts.Range)
 
 		// truncated file to part size integer multiples
 		if fileSizeWithoutFirstSeg%partSize != 0 {
@@ -802,7 +889,7 @@ func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName
 			if err != nil {
 				return err
 			}
-			log.Debug().Msgf("The file was truncated to the specified size.%d\n", truncateOffset)
+			c.logger.Debug("truncated the temp file", "size", truncateOffset)
 			// TODO(chris): verify file's segment
 		}
 	}
@@ -818,7 +905,7 @@ func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName
 		return err
 	}
 
-	log.Debug().Msg(fmt.Sprintf("get object resumeable begin segment Range: %s, startOffset: %d, endOffset:%d", opts.Range, startOffset, endOffset))
+	c.logger.Debug("get object resumable begin", "range", opts.Range, "start_offset", startOffset, "end_offset", endOffset)
 
 	// 3) Downloading Parts Sequentially based on partSize
 	segNum = startOffset / partSize
@@ -843,10 +930,10 @@ func (c *Client) FGetObjectResumable(ctx context.Context, bucketName, objectName
 		defer rd.Close()
 
 		_, err = io.Copy(fd, rd)
-		log.Debug().Msg(fmt.Sprintf("get object for segment Range: %s, current partStartOffset: %d, segNum: %d", objectOption.Range, partStartOffset, segNum))
+		c.logger.Debug("get object segment", "range", objectOption.Range, "part_start_offset", partStartOffset, "segment", segNum)
 		endT := time.Now().UnixNano() / 1000 / 1000 / 1000
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("get seg error,cost:%d second,seg number:%d,error:%s.\n", endT-startT, segNum, err.Error()))
+			c.logger.Error("get object segment failed", "segment", segNum, "cost_seconds", endT-startT, "error", err)
 			fd.Close()
 		}
 
@@ -895,16 +982,32 @@ func getObjInfo(objectName string, h http.Header) (types.ObjectStat, error) {
 
 // HeadObject query the objectInfo on chain to check th object id, return the object info if exists
 // return err info if object not exist
+// the state at a past block height is queried if it is set by WithHeight in ctx
 func (c *Client) HeadObject(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error) {
+	if c.verifier != nil {
+		var err error
+		if ctx, err = c.verifiedQueryContext(ctx); err != nil {
+			return nil, err
+		}
+	}
 	queryHeadObjectRequest := storageTypes.QueryHeadObjectRequest{
 		BucketName: bucketName,
 		ObjectName: objectName,
 	}
-	queryHeadObjectResponse, err := c.chainClient.HeadObject(ctx, &queryHeadObjectRequest)
+	queryHeadObjectResponse, err := queryChain(ctx, c, "HeadObject", (*sdkclient.MechainClient).HeadObject, &queryHeadObjectRequest)
 	if err != nil {
+		if c.verifier != nil {
+			return nil, c.verifyNotFound(ctx, err, bucketName, objectName)
+		}
 		return nil, err
 	}
 
+	if c.verifier != nil {
+		// only the object info is verified, the global virtual group is returned as it is
+		if queryHeadObjectResponse.ObjectInfo, err = c.verifyObjectInfo(ctx, bucketName, objectName, queryHeadObjectResponse.ObjectInfo); err != nil {
+			return nil, err
+		}
+	}
 	return &types.ObjectDetail{
 		ObjectInfo:         queryHeadObjectResponse.ObjectInfo,
 		GlobalVirtualGroup: queryHeadObjectResponse.GlobalVirtualGroup,
@@ -917,7 +1020,7 @@ func (c *Client) HeadObjectByID(ctx context.Context, objID string) (*types.Objec
 	headObjectRequest := storageTypes.QueryHeadObjectByIdRequest{
 		ObjectId: objID,
 	}
-	queryHeadObjectResponse, err := c.chainClient.HeadObjectById(ctx, &headObjectRequest)
+	queryHeadObjectResponse, err := queryChain(ctx, c, "HeadObjectById", (*sdkclient.MechainClient).HeadObjectById, &headObjectRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -939,7 +1042,7 @@ func (c *Client) PutObjectPolicy(ctx context.Context, bucketName, objectName str
 		return "", err
 	}
 
-	putPolicyMsg := storageTypes.NewMsgPutPolicy(c.MustGetDefaultAccount().GetAddress(), resource.String(),
+	putPolicyMsg := storageTypes.NewMsgPutPolicy(c.mustGetCallAccount(ctx).GetAddress(), resource.String(),
 		principal, statements, opt.PolicyExpireTime)
 
 	return c.sendPutPolicyTxn(ctx, putPolicyMsg, opt.TxOpts)
@@ -953,7 +1056,7 @@ func (c *Client) DeleteObjectPolicy(ctx context.Context, bucketName, objectName
 	}
 
 	resource := gnfdTypes.NewObjectGRN(bucketName, objectName)
-	return c.sendDelPolicyTxn(ctx, c.MustGetDefaultAccount().GetAddress(), resource.String(), principal, opt.TxOpts)
+	return c.sendDelPolicyTxn(ctx, c.mustGetCallAccount(ctx).GetAddress(), resource.String(), principal, opt.TxOpts)
 }
 
 // IsObjectPermissionAllowed check if the permission of the object is allowed to the user
@@ -971,7 +1074,7 @@ func (c *Client) IsObjectPermissionAllowed(ctx context.Context, userAddr string,
 		ActionType: action,
 	}
 
-	verifyResp, err := c.chainClient.VerifyPermission(ctx, &verifyReq)
+	verifyResp, err := queryChain(ctx, c, "VerifyPermission", (*sdkclient.MechainClient).VerifyPermission, &verifyReq)
 	if err != nil {
 		return permTypes.EFFECT_DENY, err
 	}
@@ -992,7 +1095,7 @@ func (c *Client) GetObjectPolicy(ctx context.Context, bucketName, objectName str
 		PrincipalAddress: principalAddr,
 	}
 
-	queryPolicyResp, err := c.chainClient.QueryPolicyForAccount(ctx, &queryPolicy)
+	queryPolicyResp, err := queryChain(ctx, c, "QueryPolicyForAccount", (*sdkclient.MechainClient).QueryPolicyForAccount, &queryPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -1068,7 +1171,7 @@ func (c *Client) ListObjects(ctx context.Context, bucketName string, opts types.
 		SPAddress: opts.SPAddress,
 	})
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return types.ListObjectsResult{}, err
 	}
 
@@ -1082,7 +1185,7 @@ func (c *Client) ListObjects(ctx context.Context, bucketName string, opts types.
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msg("the list of objects in user's bucket:" + bucketName + " failed: " + err.Error())
+		c.logger.Error("list objects failed", "bucket", bucketName, "error", err)
 		return types.ListObjectsResult{}, err
 	}
 
@@ -1091,7 +1194,7 @@ func (c *Client) ListObjects(ctx context.Context, bucketName string, opts types.
 	err = xml.Unmarshal([]byte(bufStr), &listObjectsResult)
 	// TODO(annie) remove tolerance for unmarshal err after structs got stabilized
 	if err != nil && listObjectsResult.Objects == nil {
-		log.Error().Msg("the list of objects in user's bucket:" + bucketName + " failed: " + err.Error())
+		c.logger.Error("list objects failed", "bucket", bucketName, "error", err)
 		return types.ListObjectsResult{}, err
 	}
 
@@ -1141,7 +1244,7 @@ func (c *Client) GetCreateObjectApproval(ctx context.Context, createObjectMsg *s
 	bucketName := createObjectMsg.BucketName
 	endpoint, err := c.getSPUrlByBucket(bucketName)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
+		c.logger.Error("route endpoint by bucket failed", "bucket", bucketName, "error", err)
 		return nil, err
 	}
 
@@ -1220,7 +1323,7 @@ func (c *Client) getObjectResumableUploadOffset(ctx context.Context, bucketName,
 		if err != nil {
 			return 0, errors.New("fail to fetch object uploading offset from sp" + err.Error())
 		}
-		log.Debug().Msgf("get object resumable upload offset %d from sp", uploadOffsetInfo.Offset)
+		c.logger.Debug("get object resumable upload offset from sp", "offset", uploadOffsetInfo.Offset)
 		return uploadOffsetInfo.Offset, nil
 	}
 
@@ -1320,7 +1423,7 @@ func (c *Client) UpdateObjectVisibility(ctx context.Context, bucketName, objectN
 		return "", fmt.Errorf("the visibility of object:%s is already %s \n", objectName, visibility.String())
 	}
 
-	updateObjectMsg := storageTypes.NewMsgUpdateObjectInfo(c.MustGetDefaultAccount().GetAddress(), bucketName, objectName, visibility)
+	updateObjectMsg := storageTypes.NewMsgUpdateObjectInfo(c.mustGetCallAccount(ctx).GetAddress(), bucketName, objectName, visibility)
 
 	// set the default txn broadcast mode as sync mode
 	if opt.TxOpts == nil {
@@ -1405,7 +1508,7 @@ func (c *Client) ListObjectsByObjectID(ctx context.Context, objectIds []uint64,
 
 	endpoint, err := c.getEndpointByOpt(&opts)
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return types.ListObjectsByObjectIDResponse{}, err
 	}
 
@@ -1419,7 +1522,7 @@ func (c *Client) ListObjectsByObjectID(ctx context.Context, objectIds []uint64,
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msgf("the list of objects in object ids:%v failed: %s", objectIds, err.Error())
+		c.logger.Error("list objects by ids failed", "object_ids", objectIds, "error", err)
 		return types.ListObjectsByObjectIDResponse{}, err
 	}
 
@@ -1427,7 +1530,7 @@ func (c *Client) ListObjectsByObjectID(ctx context.Context, objectIds []uint64,
 	bufStr := buf.String()
 	err = xml.Unmarshal([]byte(bufStr), (*listObjectsByIDsResponse)(&objects.Objects))
 	if err != nil && objects.Objects == nil {
-		log.Error().Msgf("the list of objects in object ids:%v failed: %s", objectIds, err.Error())
+		c.logger.Error("list objects by ids failed", "object_ids", objectIds, "error", err)
 		return types.ListObjectsByObjectIDResponse{}, err
 	}
 
@@ -1494,7 +1597,7 @@ func (c *Client) ListObjectPolicies(ctx context.Context, objectName, bucketName
 		SPAddress: opts.SPAddress,
 	})
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return types.ListObjectPoliciesResponse{}, err
 	}
 
@@ -1508,7 +1611,7 @@ func (c *Client) ListObjectPolicies(ctx context.Context, objectName, bucketName
 	buf := new(strings.Builder)
 	_, err = io.Copy(buf, resp.Body)
 	if err != nil {
-		log.Error().Msgf("the list object policies in bucket name:%s, object name:%s failed: %s", bucketName, objectName, err.Error())
+		c.logger.Error("list object policies failed", "bucket", bucketName, "object", objectName, "error", err)
 		return types.ListObjectPoliciesResponse{}, err
 	}
 
@@ -1516,7 +1619,7 @@ func (c *Client) ListObjectPolicies(ctx context.Context, objectName, bucketName
 	bufStr := buf.String()
 	err = xml.Unmarshal([]byte(bufStr), &policies)
 	if err != nil {
-		log.Error().Msgf("the list object policies in bucket name:%s, object name:%s failed: %s", bucketName, objectName, err.Error())
+		c.logger.Error("list object policies failed", "bucket", bucketName, "object", objectName, "error", err)
 		return types.ListObjectPoliciesResponse{}, err
 	}
 
@@ -1526,6 +1629,9 @@ func (c *Client) ListObjectPolicies(ctx context.Context, objectName, bucketName
 func (c *Client) DelegatePutObject(ctx context.Context, bucketName, objectName string, objectSize int64,
 	reader io.Reader, opts types.PutObjectOptions,
 ) (err error) {
+	ctx, span := c.startSpan(ctx, "DelegatePutObject", append(bucketAttrs(bucketName, objectName), AttrBytesOut.Int64(objectSize))...)
+	defer func() { endSpan(span, err) }()
+
 	if objectSize <= 0 {
 		return errors.New("object size should be more than 0")
 	}
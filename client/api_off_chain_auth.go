@@ -21,7 +21,6 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/blake2b"
 
 	httplib "github.com/zkMeLabs/mechain-common/go/http"
@@ -156,6 +155,9 @@ Expiration Time: %s`
 //
 // - ret2: Return error when registering failed, otherwise return nil.
 func (c *Client) RegisterEDDSAPublicKey(spAddress string, spEndpoint string) (string, error) {
+	if err := c.checkDryRun(http.MethodPost); err != nil {
+		return "", err
+	}
 	appDomain := c.offChainAuthOption.Domain
 	eddsaSeed := c.offChainAuthOption.Seed
 	nextNonce, err := c.GetNextNonce(spEndpoint)
@@ -164,7 +166,7 @@ func (c *Client) RegisterEDDSAPublicKey(spAddress string, spEndpoint string) (st
 	}
 	// get the EDDSA private and public key
 	userEddsaPublicKeyStr := getEddsaCompressedPublicKey(eddsaSeed)
-	log.Info().Msg("userEddsaPublicKeyStr is " + userEddsaPublicKeyStr)
+	c.logger.Debug("generated user eddsa public key", "public_key", userEddsaPublicKeyStr)
 
 	IssueDate := time.Now().Format(time.RFC3339)
 	// ExpiryDate format := "2023-06-27T06:35:24Z"
@@ -206,13 +208,16 @@ func (c *Client) RegisterEDDSAPublicKey(spAddress string, spEndpoint string) (st
 //
 // - ret2: Return error when registering failed, otherwise return nil.
 func (c *Client) RegisterEDDSAPublicKeyV2(spEndpoint string) (string, error) {
+	if err := c.checkDryRun(http.MethodPost); err != nil {
+		return "", err
+	}
 	appDomain := c.offChainAuthOptionV2.Domain
 	eddsaSeed := c.offChainAuthOptionV2.Seed
 
 	// get the EDDSA private and public key
 	_, userEddsaPublicKey := GetEd25519PrivateKeyAndPublicKey(eddsaSeed)
 	userEddsaPublicKeyStr := hex.EncodeToString(userEddsaPublicKey)
-	log.Info().Msg("userEddsaPublicKeyStr is " + userEddsaPublicKeyStr)
+	c.logger.Debug("generated user eddsa public key", "public_key", userEddsaPublicKeyStr)
 
 	IssueDate := time.Now().Format(time.RFC3339)
 	// ExpiryDate format := "2023-06-27T06:35:24Z"
@@ -277,6 +282,9 @@ func (c *Client) ListUserPublicKeyV2(spEndpoint string, domain string) ([]string
 //
 // - ret2: Return error when DeleteUserPublicKeyV2 runs into failure.
 func (c *Client) DeleteUserPublicKeyV2(spEndpoint string, domain string, publicKeys []string) (bool, error) {
+	if err := c.checkDryRun(http.MethodPost); err != nil {
+		return false, err
+	}
 	header := make(map[string]string)
 	header["X-Gnfd-User-Address"] = c.defaultAccount.GetAddress().String()
 	header["X-Gnfd-App-Domain"] = domain
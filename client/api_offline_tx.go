@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cosmosclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// txSignMode is the sign mode of the transactions accepted by Mechain.
+const txSignMode = signing.SignMode_SIGN_MODE_EIP_712
+
+// newTxConfig returns the tx config which encodes the transactions of Mechain.
+func newTxConfig(cdc *codec.ProtoCodec) cosmosclient.TxConfig {
+	return authtx.NewTxConfig(cdc, []signing.SignMode{txSignMode})
+}
+
+// BuildUnsignedTx - Build an unsigned transaction of the message(s) which can be signed offline by SignOfflineTx.
+//
+// The account number and the sequence of the signer are queried from the chain, and the gas limit is estimated by
+// simulation unless it is specified in txOpt, the AutoGas option of the Client is applied to the estimation.
+//
+// - ctx: Context variables for the current API call. The sequence set by WithSequence takes precedence over
+// txOpt.Nonce, so that the sequence 0 of a fresh account can be pinned.
+//
+// - msgs: Message(s) of the transaction, e.g. the messages created by storagetypes.NewMsgCreateObject. All the
+// messages should be signed by the same account.
+//
+// - txOpt: The options of the transaction, the GasLimit, FeeAmount, Nonce, Memo, FeePayer and FeeGranter are respected.
+//
+// - ret1: The unsigned transaction in the JSON format of the Cosmos CLI generate-only output.
+//
+// - ret2: The signer data required to sign the transaction offline, which is kept apart from the transaction, e.g. in a
+// sidecar file, or passed to "mechaind tx sign" by --account-number and --sequence.
+//
+// - ret3: Return error when the request failed, otherwise return nil.
+func (c *Client) BuildUnsignedTx(ctx context.Context, msgs []sdk.Msg, txOpt gnfdSdkTypes.TxOption) (_ []byte, _ *types.TxSignerData, err error) {
+	ctx, span := c.startChainSpan(ctx, "BuildUnsignedTx", AttrMsgCount.Int(len(msgs)))
+	defer func() { endSpan(span, err) }()
+
+	signer, err := txSignerOfMsgs(msgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	account, err := c.GetAccount(ctx, signer.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	sequence := account.GetSequence()
+	if seq, ok := SequenceFromContext(ctx); ok {
+		sequence = seq
+	} else if txOpt.Nonce != 0 {
+		sequence = txOpt.Nonce
+	}
+
+	txConfig := newTxConfig(c.chain().GetCodec())
+	txBuilder := txConfig.NewTxBuilder()
+	if err = txBuilder.SetMsgs(msgs...); err != nil {
+		return nil, nil, err
+	}
+	txBuilder.SetMemo(txOpt.Memo)
+	txBuilder.SetFeePayer(txOpt.FeePayer)
+	txBuilder.SetFeeGranter(txOpt.FeeGranter)
+
+	gasLimit, feeAmount := txOpt.GasLimit, txOpt.FeeAmount
+	if gasLimit == 0 {
+		// the signature is not verified in the simulation, an empty one of the signer is enough
+		if err = txBuilder.SetSignatures(signing.SignatureV2{
+			PubKey:   account.GetPubKey(),
+			Data:     &signing.SingleSignatureData{SignMode: txSignMode},
+			Sequence: sequence,
+		}); err != nil {
+			return nil, nil, err
+		}
+		txBytes, err := txConfig.TxEncoder()(txBuilder.GetTx())
+		if err != nil {
+			return nil, nil, err
+		}
+		simulateRes, err := c.SimulateRawTx(ctx, txBytes)
+		if err != nil {
+			return nil, nil, &types.SimulationError{Reason: simulationFailureReason(err), Err: err}
+		}
+		estimator := c.autoGas
+		if estimator == nil {
+			estimator = &autoGasEstimator{multiplier: defaultGasMultiplier}
+		}
+		gasLimit = estimator.gasLimit(simulateRes.GasInfo.GetGasUsed())
+		if feeAmount.IsZero() {
+			if feeAmount, err = estimator.fee(gasLimit, simulateRes.GasInfo.GetMinGasPrice()); err != nil {
+				return nil, nil, err
+			}
+		}
+		if err = txBuilder.SetSignatures(); err != nil {
+			return nil, nil, err
+		}
+	}
+	txBuilder.SetGasLimit(gasLimit)
+	txBuilder.SetFeeAmount(feeAmount)
+
+	txJSON, err := txConfig.TxJSONEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, nil, err
+	}
+	return txJSON, &types.TxSignerData{
+		ChainID:       c.chainID,
+		AccountNumber: account.GetAccountNumber(),
+		Sequence:      sequence,
+		Signer:        signer.String(),
+	}, nil
+}
+
+// SignOfflineTx - Sign the unsigned transaction built by BuildUnsignedTx with the account, it does not access the
+// network so that it can run on an air-gapped machine.
+//
+// - unsignedTx: The unsigned transaction in the JSON format of the Cosmos CLI generate-only output, e.g. the one
+// returned by BuildUnsignedTx.
+//
+// - signerData: The signer data of the unsigned transaction returned by BuildUnsignedTx.
+//
+// - account: The account which signs the transaction, it should be the signer of the unsigned transaction.
+//
+// - ret1: The signed transaction in the JSON format of the Cosmos CLI sign output, it can be broadcast by
+// BroadcastSignedTx.
+//
+// - ret2: Return error when the transaction can not be signed by the account, otherwise return nil.
+func SignOfflineTx(unsignedTx []byte, signerData *types.TxSignerData, account *types.Account) ([]byte, error) {
+	txConfig := newTxConfig(gnfdSdkTypes.Codec())
+	txBuilder, err := decodeUnsignedTx(txConfig, unsignedTx, signerData)
+	if err != nil {
+		return nil, err
+	}
+	if signerData.Signer != account.GetAddress().String() {
+		return nil, fmt.Errorf("the transaction should be signed by %s rather than %s", signerData.Signer, account.GetAddress().String())
+	}
+
+	sig, err := signUnsignedTx(txConfig, signerData, txBuilder, account)
+	if err != nil {
+		return nil, err
+	}
+	if err = txBuilder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	return txConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// decodeUnsignedTx decodes the unsigned transaction in the JSON format of the Cosmos CLI generate-only output.
+func decodeUnsignedTx(txConfig cosmosclient.TxConfig, unsignedTx []byte, signerData *types.TxSignerData) (cosmosclient.TxBuilder, error) {
+	if signerData == nil {
+		return nil, errors.New("the signer data of the unsigned transaction is not provided")
+	}
+	tx, err := txConfig.TxJSONDecoder()(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	return txConfig.WrapTxBuilder(tx)
+}
+
+// signUnsignedTx signs the transaction with the signer data by the account, the signer info of the
+// account is set to the transaction.
+func signUnsignedTx(txConfig cosmosclient.TxConfig, signerData *types.TxSignerData, txBuilder cosmosclient.TxBuilder,
+	account *types.Account,
+) (signing.SignatureV2, error) {
+	// the signer info is part of the sign bytes, so it is set before signing
+	sig := signing.SignatureV2{
+		PubKey:   account.GetPubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: txSignMode},
+		Sequence: signerData.Sequence,
+	}
+	if err := txBuilder.SetSignatures(sig); err != nil {
+		return sig, err
+	}
+	signBytes, err := unsignedTxSignBytes(txConfig, signerData, txBuilder, account.GetPubKey())
+	if err != nil {
+		return sig, err
+	}
+	signature, err := account.SignTx(signBytes)
+	if err != nil {
+		return sig, err
+	}
+	sig.Data = &signing.SingleSignatureData{SignMode: txSignMode, Signature: signature}
+	return sig, nil
+}
+
+// unsignedTxSignBytes returns the bytes to be signed of the transaction with the signer data.
+func unsignedTxSignBytes(txConfig cosmosclient.TxConfig, signerData *types.TxSignerData, txBuilder cosmosclient.TxBuilder,
+	pubKey cryptotypes.PubKey,
+) ([]byte, error) {
+	return txConfig.SignModeHandler().GetSignBytes(txSignMode, authsigning.SignerData{
+		Address:       signerData.Signer,
+		ChainID:       signerData.ChainID,
+		AccountNumber: signerData.AccountNumber,
+		Sequence:      signerData.Sequence,
+		PubKey:        pubKey,
+	}, txBuilder.GetTx())
+}
+
+// BroadcastSignedTx - Broadcast the transaction signed by SignOfflineTx.
+//
+// - ctx: Context variables for the current API call.
+//
+// - signedTx: The signed transaction in the JSON format of the Cosmos CLI sign output.
+//
+// - sync: A flag to specify the transaction mode. If it is true, the transaction is broadcast synchronously. If it is false, the transaction is broadcast asynchronously.
+//
+// - ret1: Transaction response, it can indicate both success and failed transaction.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) BroadcastSignedTx(ctx context.Context, signedTx []byte, sync bool) (*sdk.TxResponse, error) {
+	txConfig := newTxConfig(c.chain().GetCodec())
+	tx, err := txConfig.TxJSONDecoder()(signedTx)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := txConfig.TxEncoder()(tx)
+	if err != nil {
+		return nil, err
+	}
+	return c.BroadcastRawTx(ctx, txBytes, sync)
+}
+
+// txSignerOfMsgs returns the only signer of the messages.
+func txSignerOfMsgs(msgs []sdk.Msg) (sdk.AccAddress, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("msg is not provided in the transaction")
+	}
+	var signer sdk.AccAddress
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+		for _, s := range msg.GetSigners() {
+			if signer == nil {
+				signer = s
+			} else if !signer.Equals(s) {
+				return nil, errors.New("the messages of an offline transaction should be signed by one account")
+			}
+		}
+	}
+	if signer == nil {
+		return nil, errors.New("the messages have no signer")
+	}
+	return signer, nil
+}
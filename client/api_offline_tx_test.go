@@ -0,0 +1,187 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	bfttypes "github.com/cometbft/cometbft/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
+	evmostypes "github.com/evmos/evmos/v12/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+const (
+	testAccountNumber = 7
+	testSequence      = 5
+)
+
+// newOfflineTestClient returns a Client of a node which holds the account, and a transfer of the account.
+func newOfflineTestClient(t *testing.T, account *types.Account) (*testChain, *Client, []sdk.Msg) {
+	chain := newTestChain(t)
+	chain.handleQuery("/cosmos.auth.v1beta1.Query/Account", func(data []byte) (proto.Message, error) {
+		req := &authTypes.QueryAccountRequest{}
+		if err := proto.Unmarshal(data, req); err != nil {
+			return nil, err
+		}
+		if req.Address != account.GetAddress().String() {
+			return nil, sdkerrors.ErrUnknownAddress
+		}
+		accountAny, err := codectypes.NewAnyWithValue(&evmostypes.EthAccount{
+			BaseAccount: authTypes.NewBaseAccount(account.GetAddress(), nil, testAccountNumber, testSequence),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &authTypes.QueryAccountResponse{Account: accountAny}, nil
+	})
+
+	receiver, _, err := types.NewAccount("receiver")
+	require.NoError(t, err)
+	msgs := []sdk.Msg{bankTypes.NewMsgSend(account.GetAddress(), receiver.GetAddress(),
+		sdk.NewCoins(sdk.NewInt64Coin(gnfdSdkTypes.Denom, 1)))}
+	return chain, chain.newClient(t, Option{}), msgs
+}
+
+// testTxOption skips the gas estimation by simulation.
+var testTxOption = gnfdSdkTypes.TxOption{
+	GasLimit:  200000,
+	FeeAmount: sdk.NewCoins(sdk.NewInt64Coin(gnfdSdkTypes.Denom, 1000)),
+	Memo:      "offline",
+}
+
+func TestOfflineTx(t *testing.T) {
+	account, _, err := types.NewAccount("account")
+	require.NoError(t, err)
+	chain, cli, msgs := newOfflineTestClient(t, account)
+	ctx := context.Background()
+
+	unsignedTx, signerData, err := cli.BuildUnsignedTx(ctx, msgs, testTxOption)
+	require.NoError(t, err)
+	require.Equal(t, &types.TxSignerData{
+		ChainID:       testChainID,
+		AccountNumber: testAccountNumber,
+		Sequence:      testSequence,
+		Signer:        account.GetAddress().String(),
+	}, signerData)
+	txConfig := newTxConfig(gnfdSdkTypes.Codec())
+	decodedTx, err := txConfig.TxJSONDecoder()(unsignedTx)
+	require.NoError(t, err)
+	sigs, err := decodedTx.(authsigning.SigVerifiableTx).GetSignaturesV2()
+	require.NoError(t, err)
+	require.Empty(t, sigs, "the transaction is not signed")
+
+	signedTx, err := SignOfflineTx(unsignedTx, signerData, account)
+	require.NoError(t, err)
+
+	// the signature is verified the way the ante handler of the chain does
+	decodedTx, err = txConfig.TxJSONDecoder()(signedTx)
+	require.NoError(t, err)
+	sigTx, ok := decodedTx.(authsigning.SigVerifiableTx)
+	require.True(t, ok)
+	require.Equal(t, "offline", sigTx.(sdk.TxWithMemo).GetMemo())
+	require.Equal(t, uint64(200000), sigTx.(sdk.FeeTx).GetGas())
+	sigs, err = sigTx.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.True(t, sigs[0].PubKey.Equals(account.GetPubKey()))
+	require.Equal(t, uint64(testSequence), sigs[0].Sequence)
+	require.NoError(t, authsigning.VerifySignature(account.GetPubKey(), authsigning.SignerData{
+		Address:       signerData.Signer,
+		ChainID:       signerData.ChainID,
+		AccountNumber: signerData.AccountNumber,
+		Sequence:      signerData.Sequence,
+		PubKey:        account.GetPubKey(),
+	}, sigs[0].Data, txConfig.SignModeHandler(), decodedTx))
+
+	// the signature does not verify with other signer data
+	other := *signerData
+	other.AccountNumber++
+	require.Error(t, authsigning.VerifySignature(account.GetPubKey(), authsigning.SignerData{
+		Address:       other.Signer,
+		ChainID:       other.ChainID,
+		AccountNumber: other.AccountNumber,
+		Sequence:      other.Sequence,
+		PubKey:        account.GetPubKey(),
+	}, sigs[0].Data, txConfig.SignModeHandler(), decodedTx))
+
+	// the signed transaction is broadcast in the protobuf encoding
+	txBytes, err := txConfig.TxEncoder()(decodedTx)
+	require.NoError(t, err)
+	chain.handle("broadcast_tx_sync", func(params map[string]json.RawMessage) (interface{}, error) {
+		var tx bfttypes.Tx
+		if err := cmtjson.Unmarshal(params["tx"], &tx); err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(txBytes, tx) {
+			return nil, errors.New("the broadcast transaction differs from the signed one")
+		}
+		return &ctypes.ResultBroadcastTx{Hash: tmhash.Sum(tx)}, nil
+	})
+	resp, err := cli.BroadcastSignedTx(ctx, signedTx, true)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), resp.Code)
+	require.Equal(t, cmtbytes.HexBytes(tmhash.Sum(txBytes)).String(), resp.TxHash)
+	require.Equal(t, 1, chain.callCount("broadcast_tx_sync"))
+}
+
+func TestSignOfflineTxRejects(t *testing.T) {
+	account, _, err := types.NewAccount("account")
+	require.NoError(t, err)
+	_, cli, msgs := newOfflineTestClient(t, account)
+	unsignedTx, signerData, err := cli.BuildUnsignedTx(context.Background(), msgs, testTxOption)
+	require.NoError(t, err)
+
+	other, _, err := types.NewAccount("other")
+	require.NoError(t, err)
+	_, err = SignOfflineTx(unsignedTx, signerData, other)
+	require.ErrorContains(t, err, "should be signed by "+account.GetAddress().String())
+
+	_, err = SignOfflineTx(unsignedTx, nil, account)
+	require.ErrorContains(t, err, "signer data")
+	_, err = SignOfflineTx([]byte("{"), signerData, account)
+	require.Error(t, err)
+}
+
+func TestBuildUnsignedTxSequence(t *testing.T) {
+	account, _, err := types.NewAccount("account")
+	require.NoError(t, err)
+	_, cli, msgs := newOfflineTestClient(t, account)
+	ctx := context.Background()
+
+	withNonce := testTxOption
+	withNonce.Nonce = 9
+	_, signerData, err := cli.BuildUnsignedTx(ctx, msgs, withNonce)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), signerData.Sequence, "the nonce takes precedence over the sequence of the account")
+
+	// the sequence 0 of a fresh account is pinned by WithSequence, which takes precedence over the nonce
+	_, signerData, err = cli.BuildUnsignedTx(WithSequence(ctx, 0), msgs, withNonce)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), signerData.Sequence)
+
+	// the signatures of the transactions of different sequences differ
+	unsignedTx, signerData, err := cli.BuildUnsignedTx(WithSequence(ctx, 0), msgs, testTxOption)
+	require.NoError(t, err)
+	signedTx, err := SignOfflineTx(unsignedTx, signerData, account)
+	require.NoError(t, err)
+	signerData.Sequence = testSequence
+	signedAtSequence, err := SignOfflineTx(unsignedTx, signerData, account)
+	require.NoError(t, err)
+	require.NotEqual(t, signedTx, signedAtSequence)
+}
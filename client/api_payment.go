@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/xml"
 	"errors"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -12,9 +11,9 @@ import (
 
 	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
 	paymentTypes "github.com/evmos/evmos/v12/x/payment/types"
-	"github.com/rs/zerolog/log"
 
 	"github.com/zkMeLabs/mechain-go-sdk/pkg/utils"
 	"github.com/zkMeLabs/mechain-go-sdk/types"
@@ -31,7 +30,7 @@ type IPaymentClient interface {
 
 // GetStreamRecord - Retrieve stream record information for a given stream address.
 //
-// - ctx: Context variables for the current API call.
+// - ctx: Context variables for the current API call, the state at a past block height is queried if it is set by WithHeight.
 //
 // - streamAddress: The address of the stream record to be queried.
 //
@@ -43,7 +42,7 @@ func (c *Client) GetStreamRecord(ctx context.Context, streamAddress string) (*pa
 	if err != nil {
 		return nil, err
 	}
-	pa, err := c.chainClient.StreamRecord(ctx, &paymentTypes.QueryGetStreamRecordRequest{Account: accAddress.String()})
+	pa, err := queryChain(ctx, c, "StreamRecord", (*sdkclient.MechainClient).StreamRecord, &paymentTypes.QueryGetStreamRecordRequest{Account: accAddress.String()})
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +68,7 @@ func (c *Client) Deposit(ctx context.Context, toAddress string, amount math.Int,
 		return "", err
 	}
 	msgDeposit := &paymentTypes.MsgDeposit{
-		Creator: c.MustGetDefaultAccount().GetAddress().String(),
+		Creator: c.mustGetCallAccount(ctx).GetAddress().String(),
 		To:      accAddress.String(),
 		Amount:  amount,
 	}
@@ -104,7 +103,7 @@ func (c *Client) Withdraw(ctx context.Context, fromAddress string, amount math.I
 		return "", err
 	}
 	msgWithdraw := &paymentTypes.MsgWithdraw{
-		Creator: c.MustGetDefaultAccount().GetAddress().String(),
+		Creator: c.mustGetCallAccount(ctx).GetAddress().String(),
 		From:    accAddress.String(),
 		Amount:  amount,
 	}
@@ -134,7 +133,7 @@ func (c *Client) DisableRefund(ctx context.Context, paymentAddress string, txOpt
 		return "", err
 	}
 	msgDisableRefund := &paymentTypes.MsgDisableRefund{
-		Owner: c.MustGetDefaultAccount().GetAddress().String(),
+		Owner: c.mustGetCallAccount(ctx).GetAddress().String(),
 		Addr:  accAddress.String(),
 	}
 	tx, err := c.BroadcastTx(ctx, []sdk.Msg{msgDisableRefund}, &txOption)
@@ -159,9 +158,9 @@ func (c *Client) ListUserPaymentAccounts(ctx context.Context, opts types.ListUse
 
 	account := opts.Account
 	if account == "" {
-		acc, err := c.GetDefaultAccount()
+		acc, err := c.getCallAccount(ctx)
 		if err != nil {
-			log.Error().Msg(fmt.Sprintf("failed to get default account: %s", err.Error()))
+			c.logger.Error("get call account failed", "error", err)
 			return types.ListUserPaymentAccountsResult{}, err
 		}
 		account = acc.GetAddress().String()
@@ -183,7 +182,7 @@ func (c *Client) ListUserPaymentAccounts(ctx context.Context, opts types.ListUse
 		SPAddress: opts.SPAddress,
 	})
 	if err != nil {
-		log.Error().Msg(fmt.Sprintf("get endpoint by option failed %s", err.Error()))
+		c.logger.Error("get endpoint by option failed", "error", err)
 		return types.ListUserPaymentAccountsResult{}, err
 	}
 
@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/evmos/evmos/v12/types/s3util"
+	httplib "github.com/zkMeLabs/mechain-common/go/http"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// IPresignClient interface defines functions related to presigned urls.
+// A presigned url carries the GNFD1-ECDSA signature and the expiry in its query parameters instead of the headers,
+// so that it can be handed to browsers and third parties which do not hold the private key.
+type IPresignClient interface {
+	PresignGetObject(ctx context.Context, bucketName, objectName string, opts types.PresignGetObjectOptions) (string, error)
+	PresignPutObject(ctx context.Context, bucketName, objectName string, opts types.PresignPutObjectOptions) (string, error)
+}
+
+// PresignGetObject - Generate a presigned url to download the object, the url is signed by the account set by
+// WithAccount, or the default account if there is none.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The name of the bucket.
+//
+// - objectName: The name of the object.
+//
+// - opts: The options to set the expiry and the range of the url.
+//
+// - ret1: The presigned url which can be used to download the object by a plain GET request until it expires.
+//
+// - ret2: Return error when failed to generate the url, otherwise return nil.
+func (c *Client) PresignGetObject(ctx context.Context, bucketName, objectName string, opts types.PresignGetObjectOptions) (string, error) {
+	if err := s3util.CheckValidBucketName(bucketName); err != nil {
+		return "", err
+	}
+	if err := s3util.CheckValidObjectName(objectName); err != nil {
+		return "", err
+	}
+
+	constraints := url.Values{}
+	if opts.Range != "" {
+		if !strings.HasPrefix(opts.Range, "bytes=") {
+			return "", types.ToInvalidArgumentResp(fmt.Sprintf("invalid range: %s", opts.Range))
+		}
+		constraints.Set(types.HTTPHeaderRange, opts.Range)
+	}
+
+	var (
+		endpoint *url.URL
+		err      error
+	)
+	if c.forceToUseSpecifiedSpEndpointForDownloadOnly != nil {
+		endpoint = c.forceToUseSpecifiedSpEndpointForDownloadOnly
+	} else {
+		endpoint, err = c.getSPUrlByBucket(bucketName)
+		if err != nil {
+			c.logger.Error("route endpoint by bucket failed", "bucket", bucketName, "error", err)
+			return "", err
+		}
+	}
+
+	return c.presignURL(ctx, http.MethodGet, bucketName, objectName, constraints, opts.Expires, endpoint)
+}
+
+// PresignPutObject - Generate a presigned url to upload the payload of an object which has been created on chain,
+// the url is signed by the account set by WithAccount, or the default account if there is none.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The name of the bucket.
+//
+// - objectName: The name of the object.
+//
+// - opts: The options to set the expiry and the content type of the url.
+//
+// - ret1: The presigned url which can be used to upload the object by a plain PUT request until it expires.
+//
+// - ret2: Return error when failed to generate the url, otherwise return nil.
+func (c *Client) PresignPutObject(ctx context.Context, bucketName, objectName string, opts types.PresignPutObjectOptions) (string, error) {
+	if err := s3util.CheckValidBucketName(bucketName); err != nil {
+		return "", err
+	}
+	if err := s3util.CheckValidObjectName(objectName); err != nil {
+		return "", err
+	}
+
+	constraints := url.Values{}
+	if opts.ContentType != "" {
+		constraints.Set(types.HTTPHeaderContentType, opts.ContentType)
+	}
+
+	endpoint, err := c.getSPUrlByBucket(bucketName)
+	if err != nil {
+		c.logger.Error("route endpoint by bucket failed", "bucket", bucketName, "error", err)
+		return "", err
+	}
+
+	return c.presignURL(ctx, http.MethodPut, bucketName, objectName, constraints, opts.Expires, endpoint)
+}
+
+// presignURL generates the url of the object on the endpoint and signs it, the expiry, the user address and the
+// constraints are put into the query parameters and covered by the signature.
+func (c *Client) presignURL(ctx context.Context, method, bucketName, objectName string, constraints url.Values,
+	expires time.Duration, endpoint *url.URL,
+) (string, error) {
+	account, err := c.getCallAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if expires == 0 {
+		expires = time.Second * types.DefaultExpireSeconds
+		if c.expireSeconds != 0 {
+			expires = time.Second * time.Duration(c.expireSeconds)
+		}
+	}
+	if expires < 0 || expires > time.Second*time.Duration(httplib.MaxExpiryAgeInSec) {
+		return "", types.ToInvalidArgumentResp(fmt.Sprintf("the expiry of the presigned url should be within %d seconds", httplib.MaxExpiryAgeInSec))
+	}
+
+	query := url.Values{}
+	for k, v := range constraints {
+		query[k] = v
+	}
+	query.Set(types.HTTPHeaderUserAddress, account.GetAddress().String())
+	query.Set(httplib.HTTPHeaderExpiryTimestamp, time.Now().UTC().Add(expires).Format(types.Iso8601DateFormatSecond))
+
+	isVirtualHost := c.isVirtualHostStyleUrl(*endpoint, bucketName)
+	desURL, err := c.generateURL(bucketName, objectName, "", query, AdminAPIInfo{}, endpoint, isVirtualHost)
+	if err != nil {
+		c.logger.Error("generate request url failed", "sp_endpoint", endpoint.Host, "error", err)
+		return "", err
+	}
+
+	host := desURL.Host
+	if c.host != "" {
+		host = c.host
+	}
+	unsignedMsg, err := presignedMsgToSign(method, desURL, host)
+	if err != nil {
+		return "", err
+	}
+	// the url is signed by the account whose address is in the url
+	signature, err := account.SignRequest(unsignedMsg)
+	if err != nil {
+		return "", err
+	}
+
+	query = desURL.Query()
+	query.Set(types.HTTPHeaderAuthorization, httplib.Gnfd1Ecdsa+", Signature="+hex.EncodeToString(signature))
+	desURL.RawQuery = query.Encode()
+
+	return desURL.String(), nil
+}
+
+// presignedMsgToSign returns the GNFD1 message to sign of a presigned url, the message is generated from the method,
+// the host, the path and the query parameters(except the authorization) only, so that the headers sent by the
+// holder of the url do not affect it.
+func presignedMsgToSign(method string, u *url.URL, host string) ([]byte, error) {
+	query := u.Query()
+	query.Del(types.HTTPHeaderAuthorization)
+	req, err := http.NewRequest(method, (&url.URL{Path: u.Path, RawPath: u.RawPath, RawQuery: query.Encode()}).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Host = host
+	return httplib.GetMsgToSignInGNFD1Auth(req), nil
+}
+
+// VerifyPresignedRequest - Verify the presigned url carried by a request received by a storage provider. It is meant to
+// be used by local SP stand-ins and tests to check the urls generated by PresignGetObject and PresignPutObject.
+//
+// - req: The request received by the storage provider.
+//
+// - ret1: The address of the account which signed the url.
+//
+// - ret2: Return error if the signature is invalid, the url has expired or the request violates the constraints of
+// the url, otherwise return nil.
+func VerifyPresignedRequest(req *http.Request) (sdk.AccAddress, error) {
+	query := req.URL.Query()
+
+	authStr := query.Get(types.HTTPHeaderAuthorization)
+	if !strings.HasPrefix(authStr, httplib.Gnfd1Ecdsa) {
+		return nil, errors.New("the url is not presigned with " + httplib.Gnfd1Ecdsa)
+	}
+	sigIndex := strings.Index(authStr, "Signature=")
+	if sigIndex < 0 {
+		return nil, errors.New("the signature of the presigned url is missing")
+	}
+	signature, err := hex.DecodeString(strings.TrimSpace(authStr[sigIndex+len("Signature="):]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature of the presigned url: %v", err)
+	}
+
+	expiry, err := time.Parse(types.Iso8601DateFormatSecond, query.Get(httplib.HTTPHeaderExpiryTimestamp))
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry of the presigned url: %v", err)
+	}
+	now := time.Now().UTC()
+	if now.After(expiry) {
+		return nil, errors.New("the presigned url has expired")
+	}
+	if expiry.Sub(now) > time.Second*time.Duration(httplib.MaxExpiryAgeInSec) {
+		return nil, errors.New("the expiry of the presigned url is too far in the future")
+	}
+
+	for _, header := range []string{types.HTTPHeaderRange, types.HTTPHeaderContentType} {
+		if expected := query.Get(header); expected != "" && req.Header.Get(header) != expected {
+			return nil, fmt.Errorf("the %s header of the request does not match the presigned url", header)
+		}
+	}
+
+	unsignedMsg, err := presignedMsgToSign(req.Method, req.URL, req.Host)
+	if err != nil {
+		return nil, err
+	}
+	if len(unsignedMsg) != crypto.DigestLength {
+		unsignedMsg = crypto.Keccak256(unsignedMsg)
+	}
+	pubKey, err := crypto.SigToPub(unsignedMsg, signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover the signer of the presigned url: %v", err)
+	}
+
+	signer := sdk.AccAddress(crypto.PubkeyToAddress(*pubKey).Bytes())
+	userAddr, err := sdk.AccAddressFromHexUnsafe(query.Get(types.HTTPHeaderUserAddress))
+	if err != nil {
+		return nil, fmt.Errorf("invalid user address of the presigned url: %v", err)
+	}
+	if !signer.Equals(userAddr) {
+		return nil, errors.New("the signature of the presigned url does not match the user address")
+	}
+	return signer, nil
+}
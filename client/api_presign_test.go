@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	httplib "github.com/zkMeLabs/mechain-common/go/http"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// newPresignTestClient creates a Client which downloads from the fixed endpoint, so that no chain is queried.
+func newPresignTestClient(t *testing.T) (*Client, *types.Account) {
+	account, _, err := types.NewAccount("presign")
+	require.NoError(t, err)
+	cli, err := New("mechain_5151-1", "http://127.0.0.1:26750", Option{
+		DefaultAccount: account,
+		ForceToUseSpecifiedSpEndpointForDownloadOnly: "http://127.0.0.1:9033",
+	})
+	require.NoError(t, err)
+	return cli.(*Client), account
+}
+
+// presignedRequest returns the request the holder of the presigned url sends.
+func presignedRequest(t *testing.T, method, rawURL string) *http.Request {
+	req, err := http.NewRequest(method, rawURL, nil)
+	require.NoError(t, err)
+	return req
+}
+
+func TestPresignGetObject(t *testing.T) {
+	c, account := newPresignTestClient(t)
+
+	rawURL, err := c.PresignGetObject(context.Background(), "bucket", "dir/object", types.PresignGetObjectOptions{
+		Expires: time.Minute,
+		Range:   "bytes=0-9",
+	})
+	require.NoError(t, err)
+
+	req := presignedRequest(t, http.MethodGet, rawURL)
+	req.Header.Set(types.HTTPHeaderRange, "bytes=0-9")
+	signer, err := VerifyPresignedRequest(req)
+	require.NoError(t, err)
+	require.True(t, signer.Equals(account.GetAddress()))
+
+	// the range is a constraint of the url
+	req = presignedRequest(t, http.MethodGet, rawURL)
+	req.Header.Set(types.HTTPHeaderRange, "bytes=0-99")
+	_, err = VerifyPresignedRequest(req)
+	require.Error(t, err)
+
+	// the url can not be used by another method
+	_, err = VerifyPresignedRequest(presignedRequest(t, http.MethodDelete, rawURL))
+	require.Error(t, err)
+}
+
+func TestPresignWithAccount(t *testing.T) {
+	c, account := newPresignTestClient(t)
+	other, _, err := types.NewAccount("other")
+	require.NoError(t, err)
+
+	rawURL, err := c.PresignGetObject(WithAccount(context.Background(), other), "bucket", "object", types.PresignGetObjectOptions{})
+	require.NoError(t, err)
+
+	signer, err := VerifyPresignedRequest(presignedRequest(t, http.MethodGet, rawURL))
+	require.NoError(t, err)
+	require.True(t, signer.Equals(other.GetAddress()))
+	require.False(t, signer.Equals(account.GetAddress()))
+}
+
+func TestPresignPutObject(t *testing.T) {
+	c, account := newPresignTestClient(t)
+	endpoint, err := url.Parse("http://127.0.0.1:9033")
+	require.NoError(t, err)
+
+	constraints := url.Values{}
+	constraints.Set(types.HTTPHeaderContentType, "text/plain")
+	rawURL, err := c.presignURL(context.Background(), http.MethodPut, "bucket", "object", constraints, time.Minute, endpoint)
+	require.NoError(t, err)
+
+	req := presignedRequest(t, http.MethodPut, rawURL)
+	req.Header.Set(types.HTTPHeaderContentType, "text/plain")
+	signer, err := VerifyPresignedRequest(req)
+	require.NoError(t, err)
+	require.True(t, signer.Equals(account.GetAddress()))
+}
+
+func TestVerifyPresignedRequestTampered(t *testing.T) {
+	c, _ := newPresignTestClient(t)
+	rawURL, err := c.PresignGetObject(context.Background(), "bucket", "object", types.PresignGetObjectOptions{Expires: time.Minute})
+	require.NoError(t, err)
+
+	tamper := func(modify func(u *url.URL, query url.Values)) *http.Request {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		query := u.Query()
+		modify(u, query)
+		u.RawQuery = query.Encode()
+		return presignedRequest(t, http.MethodGet, u.String())
+	}
+
+	cases := map[string]func(u *url.URL, query url.Values){
+		"object": func(u *url.URL, _ url.Values) {
+			u.Path += "2"
+		},
+		"user address": func(_ *url.URL, query url.Values) {
+			other, _, err := types.NewAccount("other")
+			require.NoError(t, err)
+			query.Set(types.HTTPHeaderUserAddress, other.GetAddress().String())
+		},
+		"extended expiry": func(_ *url.URL, query url.Values) {
+			query.Set(httplib.HTTPHeaderExpiryTimestamp, time.Now().UTC().Add(time.Hour).Format(types.Iso8601DateFormatSecond))
+		},
+		"expired": func(_ *url.URL, query url.Values) {
+			query.Set(httplib.HTTPHeaderExpiryTimestamp, time.Now().UTC().Add(-time.Second).Format(types.Iso8601DateFormatSecond))
+		},
+		"added range": func(_ *url.URL, query url.Values) {
+			query.Set(types.HTTPHeaderRange, "bytes=0-9")
+		},
+		"missing signature": func(_ *url.URL, query url.Values) {
+			query.Del(types.HTTPHeaderAuthorization)
+		},
+	}
+	for name, modify := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := tamper(modify)
+			req.Header.Set(types.HTTPHeaderRange, req.URL.Query().Get(types.HTTPHeaderRange))
+			_, err := VerifyPresignedRequest(req)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPresignExpires(t *testing.T) {
+	c, _ := newPresignTestClient(t)
+	_, err := c.PresignGetObject(context.Background(), "bucket", "object", types.PresignGetObjectOptions{Expires: -time.Second})
+	require.Error(t, err)
+	_, err = c.PresignGetObject(context.Background(), "bucket", "object", types.PresignGetObjectOptions{
+		Expires: time.Second * time.Duration(httplib.MaxExpiryAgeInSec+1),
+	})
+	require.Error(t, err)
+}
@@ -9,8 +9,10 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	govTypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	govTypesV1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
 	"github.com/zkMeLabs/mechain-go-sdk/types"
+	"google.golang.org/grpc"
 )
 
 type IProposalClient interface {
@@ -39,7 +41,7 @@ type IProposalClient interface {
 //
 // - ret3: Return error if the transaction failed, otherwise return nil.
 func (c *Client) SubmitProposal(ctx context.Context, msgs []sdk.Msg, depositAmount math.Int, title, summary string, opts types.SubmitProposalOptions) (uint64, string, error) {
-	msgSubmitProposal, err := govTypesV1.NewMsgSubmitProposal(msgs, sdk.NewCoins(sdk.NewCoin(gnfdSdkTypes.Denom, depositAmount)), c.defaultAccount.GetAddress().String(), opts.Metadata, title, summary)
+	msgSubmitProposal, err := govTypesV1.NewMsgSubmitProposal(msgs, sdk.NewCoins(sdk.NewCoin(gnfdSdkTypes.Denom, depositAmount)), c.mustGetCallAccount(ctx).GetAddress().String(), opts.Metadata, title, summary)
 	if err != nil {
 		return 0, "", err
 	}
@@ -51,6 +53,10 @@ func (c *Client) SubmitProposal(ctx context.Context, msgs []sdk.Msg, depositAmou
 	if err != nil {
 		return 0, "", err
 	}
+	if c.dryRun {
+		// the proposal is not submitted, so it has no ID
+		return 0, "", nil
+	}
 	waitCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	txResult, err := c.WaitForTx(waitCtx, txResp.TxResponse.TxHash)
@@ -86,7 +92,7 @@ func (c *Client) SubmitProposal(ctx context.Context, msgs []sdk.Msg, depositAmou
 //
 // - ret2: Return error if the transaction failed, otherwise return nil.
 func (c *Client) VoteProposal(ctx context.Context, proposalID uint64, voteOption govTypesV1.VoteOption, opts types.VoteProposalOptions) (string, error) {
-	msgVote := govTypesV1.NewMsgVote(c.MustGetDefaultAccount().GetAddress(), proposalID, voteOption, opts.Metadata)
+	msgVote := govTypesV1.NewMsgVote(c.mustGetCallAccount(ctx).GetAddress(), proposalID, voteOption, opts.Metadata)
 	resp, err := c.BroadcastTx(ctx, []sdk.Msg{msgVote}, &opts.TxOpts)
 	if err != nil {
 		return "", err
@@ -104,7 +110,11 @@ func (c *Client) VoteProposal(ctx context.Context, proposalID uint64, voteOption
 //
 // - ret2: Return error if the query failed, otherwise return nil.
 func (c *Client) GetProposal(ctx context.Context, proposalID uint64) (*govTypesV1.Proposal, error) {
-	resp, err := c.chainClient.GovQueryClientV1.Proposal(ctx, &govTypesV1.QueryProposalRequest{ProposalId: proposalID})
+	resp, err := queryChain(ctx, c, "Proposal", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *govTypesV1.QueryProposalRequest, opts ...grpc.CallOption,
+	) (*govTypesV1.QueryProposalResponse, error) {
+		return chain.GovQueryClientV1.Proposal(ctx, req, opts...)
+	}, &govTypesV1.QueryProposalRequest{ProposalId: proposalID})
 	if err != nil {
 		return nil, nil
 	}
@@ -14,6 +14,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/authz"
 	govTypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
 	spTypes "github.com/evmos/evmos/v12/x/sp/types"
 	"github.com/zkMeLabs/mechain-go-sdk/pkg/utils"
@@ -34,7 +35,7 @@ type ISPClient interface {
 
 // GetStoragePrice - Get the storage price details for a particular storage provider, including update time, read price, store price and .etc.
 //
-// - ctx: Context variables for the current API call.
+// - ctx: Context variables for the current API call, the state at a past block height is queried if it is set by WithHeight.
 //
 // - spAddr: The HEX-encoded string of the storage provider address.
 //
@@ -46,7 +47,7 @@ func (c *Client) GetStoragePrice(ctx context.Context, spAddr string) (*spTypes.S
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.chainClient.QuerySpStoragePrice(ctx, &spTypes.QuerySpStoragePriceRequest{
+	resp, err := queryChain(ctx, c, "QuerySpStoragePrice", (*sdkclient.MechainClient).QuerySpStoragePrice, &spTypes.QuerySpStoragePriceRequest{
 		SpAddr: spAcc.String(),
 	})
 	if err != nil {
@@ -63,7 +64,7 @@ func (c *Client) GetStoragePrice(ctx context.Context, spAddr string) (*spTypes.S
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GetGlobalSpStorePrice(ctx context.Context) (*spTypes.GlobalSpStorePrice, error) {
-	resp, err := c.chainClient.QueryGlobalSpStorePriceByTime(ctx, &spTypes.QueryGlobalSpStorePriceByTimeRequest{
+	resp, err := queryChain(ctx, c, "QueryGlobalSpStorePriceByTime", (*sdkclient.MechainClient).QueryGlobalSpStorePriceByTime, &spTypes.QueryGlobalSpStorePriceByTimeRequest{
 		Timestamp: 0,
 	})
 	if err != nil {
@@ -83,7 +84,7 @@ func (c *Client) GetGlobalSpStorePrice(ctx context.Context) (*spTypes.GlobalSpSt
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) ListStorageProviders(ctx context.Context, isInService bool) ([]spTypes.StorageProvider, error) {
 	request := &spTypes.QueryStorageProvidersRequest{}
-	gnfdRep, err := c.chainClient.StorageProviders(ctx, request)
+	gnfdRep, err := queryChain(ctx, c, "StorageProviders", (*sdkclient.MechainClient).StorageProviders, request)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +115,7 @@ func (c *Client) GetStorageProviderInfo(ctx context.Context, spAddr sdk.AccAddre
 		OperatorAddress: spAddr.String(),
 	}
 
-	gnfdRep, err := c.chainClient.StorageProviderByOperatorAddress(ctx, request)
+	gnfdRep, err := queryChain(ctx, c, "StorageProviderByOperatorAddress", (*sdkclient.MechainClient).StorageProviderByOperatorAddress, request)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +124,7 @@ func (c *Client) GetStorageProviderInfo(ctx context.Context, spAddr sdk.AccAddre
 }
 
 func (c *Client) refreshStorageProviders(ctx context.Context) error {
-	gnfdRep, err := c.chainClient.StorageProviders(ctx, &spTypes.QueryStorageProvidersRequest{Pagination: &query.PageRequest{Limit: math2.MaxUint64}})
+	gnfdRep, err := queryChain(ctx, c, "StorageProviders", (*sdkclient.MechainClient).StorageProviders, &spTypes.QueryStorageProvidersRequest{Pagination: &query.PageRequest{Limit: math2.MaxUint64}})
 	if err != nil {
 		return err
 	}
@@ -186,7 +187,7 @@ func (c *Client) refreshStorageProviders(ctx context.Context) error {
 //
 // - ret3: Return error when the request failed, otherwise return nil.
 func (c *Client) CreateStorageProvider(ctx context.Context, fundingAddr, sealAddr, approvalAddr, gcAddr, maintenanceAddr, blsPubKey, blsProof, endpoint string, depositAmount math.Int, description spTypes.Description, opts types.CreateStorageProviderOptions) (uint64, string, error) {
-	defaultAccount := c.MustGetDefaultAccount()
+	account := c.mustGetCallAccount(ctx)
 	govModuleAddress, err := c.GetModuleAccountByName(ctx, govTypes.ModuleName)
 	if err != nil {
 		return 0, "", err
@@ -231,7 +232,7 @@ func (c *Client) CreateStorageProvider(ctx context.Context, fundingAddr, sealAdd
 	}
 	msgCreateStorageProvider, err := spTypes.NewMsgCreateStorageProvider(
 		govModuleAddress.GetAddress(),
-		defaultAccount.GetAddress(),
+		account.GetAddress(),
 		fundingAcc, sealAcc, approvalAcc, gcAcc, maintenanceAcc,
 		description,
 		endpoint,
@@ -267,7 +268,7 @@ func (c *Client) CreateStorageProvider(ctx context.Context, fundingAddr, sealAdd
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) GrantDepositForStorageProvider(ctx context.Context, spAddr string, depositAmount math.Int, opts types.GrantDepositForStorageProviderOptions) (string, error) {
-	granter := c.MustGetDefaultAccount()
+	granter := c.mustGetCallAccount(ctx)
 	govModuleAddress, err := c.GetModuleAccountByName(ctx, govTypes.ModuleName)
 	if err != nil {
 		return "", err
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/evmos/evmos/v12/types/resource"
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+const (
+	defaultSearchTxsPerPage = 30
+	maxSearchTxsPerPage     = 100
+)
+
+// bucketHistoryEvents is the typed events which change a bucket or its objects, they are searched by the bucket name.
+var bucketHistoryEvents = []proto.Message{
+	&storageTypes.EventCreateBucket{},
+	&storageTypes.EventUpdateBucketInfo{},
+	&storageTypes.EventDeleteBucket{},
+	&storageTypes.EventMigrationBucket{},
+	&storageTypes.EventCompleteMigrationBucket{},
+	&storageTypes.EventCreateObject{},
+	&storageTypes.EventSealObject{},
+	&storageTypes.EventRejectSealObject{},
+	&storageTypes.EventDeleteObject{},
+}
+
+// ITxSearchClient interface defines functions related to searching the transactions by their events.
+type ITxSearchClient interface {
+	SearchTxs(ctx context.Context, query *types.TxQuery, opts types.SearchTxsOptions) (*types.SearchTxsResult, error)
+	GetBucketHistory(ctx context.Context, bucketName string) ([]*types.Event, error)
+}
+
+// SearchTxs - Search the transactions matching the query, the node should index the transactions.
+//
+// - ctx: Context variables for the current API call.
+//
+// - query: The conditions of the transactions built by types.NewTxQuery, e.g. the message action, the sender, the
+// bucket name and the height range.
+//
+// - opts: The pagination and the order of the transactions.
+//
+// - ret1: A page of the matching transactions with their typed events, the failed transactions are skipped.
+//
+// - ret2: Return error when the query is invalid or the request failed, otherwise return nil.
+func (c *Client) SearchTxs(ctx context.Context, query *types.TxQuery, opts types.SearchTxsOptions) (*types.SearchTxsResult, error) {
+	page, perPage := opts.Page, opts.PerPage
+	if page <= 0 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = defaultSearchTxsPerPage
+	} else if perPage > maxSearchTxsPerPage {
+		perPage = maxSearchTxsPerPage
+	}
+	orderBy := "asc"
+	if opts.OrderDesc {
+		orderBy = "desc"
+	}
+
+	if err := query.Err(); err != nil {
+		return nil, err
+	}
+	resp, err := callChain(ctx, c, "TxSearch", func(ctx context.Context, endpoint *chainEndpoint) (*ctypes.ResultTxSearch, error) {
+		rpcClient, err := endpoint.rpcClient()
+		if err != nil {
+			return nil, err
+		}
+		return rpcClient.TxSearch(ctx, query.String(), false, &page, &perPage, orderBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.SearchTxsResult{
+		Txs:        make([]*types.TxResult, 0, len(resp.Txs)),
+		TotalCount: resp.TotalCount,
+	}
+	for _, resultTx := range resp.Txs {
+		txResult, err := DecodeTxResult(resultTx)
+		if err != nil {
+			var txErr *types.TxFailedError
+			if errors.As(err, &txErr) {
+				continue
+			}
+			return nil, err
+		}
+		result.Txs = append(result.Txs, txResult)
+	}
+	return result, nil
+}
+
+// GetBucketHistory - Get the history of a bucket, e.g. who created it, updated it, put its policies and created or
+// deleted its objects.
+//
+// The history includes the buckets deleted before, as long as the node keeps their transactions. The policies of the
+// objects are not included.
+//
+// - ctx: Context variables for the current API call.
+//
+// - bucketName: The name of the bucket.
+//
+// - ret1: The typed storage and permission events of the bucket in chronological order.
+//
+// - ret2: Return error when the request failed, otherwise return nil.
+func (c *Client) GetBucketHistory(ctx context.Context, bucketName string) ([]*types.Event, error) {
+	txs := make(map[string]*types.TxResult)
+	for _, event := range bucketHistoryEvents {
+		if err := c.searchAllTxs(ctx, types.NewTxQuery().BucketName(event, bucketName), txs); err != nil {
+			return nil, err
+		}
+	}
+
+	// the policies refer to the bucket by its ID, which is changed if the bucket is deleted and created again
+	bucketIDs := make(map[string]bool)
+	for _, tx := range txs {
+		for _, event := range tx.Events {
+			if e, ok := event.Data.(*storageTypes.EventCreateBucket); ok && e.BucketName == bucketName {
+				bucketIDs[e.BucketId.String()] = true
+			}
+		}
+	}
+	for bucketID := range bucketIDs {
+		query := types.NewTxQuery().EventAttribute(&permTypes.EventPutPolicy{}, "resource_id", bucketID)
+		if err := c.searchAllTxs(ctx, query, txs); err != nil {
+			return nil, err
+		}
+	}
+	// the deleted policies are only known by their IDs
+	policyIDs := make(map[string]bool)
+	for _, tx := range txs {
+		for _, event := range tx.Events {
+			if e, ok := event.Data.(*permTypes.EventPutPolicy); ok && isBucketPolicy(e, bucketIDs) {
+				policyIDs[e.PolicyId.String()] = true
+			}
+		}
+	}
+	for policyID := range policyIDs {
+		query := types.NewTxQuery().EventAttribute(&permTypes.EventDeletePolicy{}, "policy_id", policyID)
+		if err := c.searchAllTxs(ctx, query, txs); err != nil {
+			return nil, err
+		}
+	}
+
+	sorted := make([]*types.TxResult, 0, len(txs))
+	for _, tx := range txs {
+		sorted = append(sorted, tx)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Height != sorted[j].Height {
+			return sorted[i].Height < sorted[j].Height
+		}
+		return sorted[i].Index < sorted[j].Index
+	})
+
+	// the transactions may emit the events of other resources, they are skipped
+	var history []*types.Event
+	for _, tx := range sorted {
+		for _, event := range tx.Events {
+			switch e := event.Data.(type) {
+			case *permTypes.EventPutPolicy:
+				if !isBucketPolicy(e, bucketIDs) {
+					continue
+				}
+			case *permTypes.EventDeletePolicy:
+				if !policyIDs[e.PolicyId.String()] {
+					continue
+				}
+			case interface{ GetBucketName() string }:
+				if e.GetBucketName() != bucketName {
+					continue
+				}
+			default:
+				continue
+			}
+			history = append(history, event)
+		}
+	}
+	return history, nil
+}
+
+// searchAllTxs searches all the pages of the transactions matching the query, and adds them to txs by hash.
+func (c *Client) searchAllTxs(ctx context.Context, query *types.TxQuery, txs map[string]*types.TxResult) error {
+	for page, searched := 1, 0; ; page++ {
+		result, err := c.SearchTxs(ctx, query, types.SearchTxsOptions{Page: page, PerPage: maxSearchTxsPerPage})
+		if err != nil {
+			return err
+		}
+		for _, tx := range result.Txs {
+			txs[tx.TxHash] = tx
+		}
+		searched += maxSearchTxsPerPage
+		if searched >= result.TotalCount {
+			return nil
+		}
+	}
+}
+
+// isBucketPolicy reports whether the policy is put on one of the buckets.
+func isBucketPolicy(e *permTypes.EventPutPolicy, bucketIDs map[string]bool) bool {
+	return e.ResourceType == resource.RESOURCE_TYPE_BUCKET && bucketIDs[e.ResourceId.String()]
+}
@@ -13,8 +13,10 @@ import (
 	govTypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	gnfdsdktypes "github.com/evmos/evmos/v12/sdk/types"
 	"github.com/zkMeLabs/mechain-go-sdk/types"
+	"google.golang.org/grpc"
 )
 
 // IValidatorClient - Client APIs for operating Mechain validators and delegations.
@@ -45,7 +47,11 @@ type IValidatorClient interface {
 //
 // - ret2: Return error when getting validators failed, otherwise return nil.
 func (c *Client) ListValidators(ctx context.Context, status string) (*stakingtypes.QueryValidatorsResponse, error) {
-	return c.chainClient.StakingQueryClient.Validators(ctx, &stakingtypes.QueryValidatorsRequest{Status: status})
+	return queryChain(ctx, c, "Validators", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *stakingtypes.QueryValidatorsRequest, opts ...grpc.CallOption,
+	) (*stakingtypes.QueryValidatorsResponse, error) {
+		return chain.StakingQueryClient.Validators(ctx, req, opts...)
+	}, &stakingtypes.QueryValidatorsRequest{Status: status})
 }
 
 // CreateValidator - Submit a proposal to Mechain for creating a validator, and return a proposal id and tx hash.
@@ -161,7 +167,7 @@ func (c *Client) EditValidator(ctx context.Context, description stakingtypes.Des
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgEditValidator(c.MustGetDefaultAccount().GetAddress(), description, newRate, newMinSelfDelegation, relayer, challenger, newBlsKey, newBlsProof)
+	msg := stakingtypes.NewMsgEditValidator(c.mustGetCallAccount(ctx).GetAddress(), description, newRate, newMinSelfDelegation, relayer, challenger, newBlsKey, newBlsProof)
 	resp, err := c.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
@@ -187,7 +193,7 @@ func (c *Client) DelegateValidator(ctx context.Context, validatorAddr string, am
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgDelegate(c.MustGetDefaultAccount().GetAddress(), validator, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
+	msg := stakingtypes.NewMsgDelegate(c.mustGetCallAccount(ctx).GetAddress(), validator, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
 	resp, err := c.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
@@ -219,7 +225,7 @@ func (c *Client) BeginRedelegate(ctx context.Context, validatorSrcAddr, validato
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgBeginRedelegate(c.MustGetDefaultAccount().GetAddress(), validatorSrc, validatorDest, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
+	msg := stakingtypes.NewMsgBeginRedelegate(c.mustGetCallAccount(ctx).GetAddress(), validatorSrc, validatorDest, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
 	resp, err := c.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
@@ -245,7 +251,7 @@ func (c *Client) Undelegate(ctx context.Context, validatorAddr string, amount ma
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgUndelegate(c.MustGetDefaultAccount().GetAddress(), validator, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
+	msg := stakingtypes.NewMsgUndelegate(c.mustGetCallAccount(ctx).GetAddress(), validator, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
 	resp, err := c.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
@@ -273,7 +279,7 @@ func (c *Client) CancelUnbondingDelegation(ctx context.Context, validatorAddr st
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgCancelUnbondingDelegation(c.MustGetDefaultAccount().GetAddress(), validator, creationHeight, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
+	msg := stakingtypes.NewMsgCancelUnbondingDelegation(c.mustGetCallAccount(ctx).GetAddress(), validator, creationHeight, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
 	resp, err := c.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
@@ -298,14 +304,14 @@ func (c *Client) GrantDelegationForValidator(ctx context.Context, delegationAmou
 		return "", err
 	}
 	delegationCoin := sdktypes.NewCoin(gnfdsdktypes.Denom, delegationAmount)
-	authorization, err := stakingtypes.NewStakeAuthorization([]sdktypes.AccAddress{c.MustGetDefaultAccount().GetAddress()},
+	authorization, err := stakingtypes.NewStakeAuthorization([]sdktypes.AccAddress{c.mustGetCallAccount(ctx).GetAddress()},
 		nil, stakingtypes.AuthorizationType_AUTHORIZATION_TYPE_DELEGATE,
 		&delegationCoin)
 	if err != nil {
 		return "", err
 	}
 
-	msgGrant, err := authz.NewMsgGrant(c.MustGetDefaultAccount().GetAddress(),
+	msgGrant, err := authz.NewMsgGrant(c.mustGetCallAccount(ctx).GetAddress(),
 		govModule.GetAddress(),
 		authorization, nil)
 	if err != nil {
@@ -331,7 +337,7 @@ func (c *Client) GrantDelegationForValidator(ctx context.Context, delegationAmou
 //
 // - ret2: Return error when unjail validator tx failed, otherwise return nil.
 func (c *Client) UnJailValidator(ctx context.Context, txOption gnfdsdktypes.TxOption) (string, error) {
-	msg := slashingtypes.NewMsgUnjail(c.MustGetDefaultAccount().GetAddress())
+	msg := slashingtypes.NewMsgUnjail(c.mustGetCallAccount(ctx).GetAddress())
 	resp, err := c.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
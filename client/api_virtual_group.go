@@ -3,7 +3,9 @@ package client
 import (
 	"context"
 
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
 	"github.com/evmos/evmos/v12/x/virtualgroup/types"
+	"google.golang.org/grpc"
 )
 
 // IVirtualGroupClient interface defines basic functions related to Virtual Group.
@@ -26,7 +28,7 @@ type IVirtualGroupClient interface {
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) QueryVirtualGroupFamily(ctx context.Context, globalVirtualGroupFamilyID uint32) (*types.GlobalVirtualGroupFamily, error) {
-	queryResponse, err := c.chainClient.GlobalVirtualGroupFamily(ctx, &types.QueryGlobalVirtualGroupFamilyRequest{
+	queryResponse, err := queryChain(ctx, c, "GlobalVirtualGroupFamily", (*sdkclient.MechainClient).GlobalVirtualGroupFamily, &types.QueryGlobalVirtualGroupFamilyRequest{
 		FamilyId: globalVirtualGroupFamilyID,
 	})
 	if err != nil {
@@ -47,7 +49,7 @@ func (c *Client) QueryVirtualGroupFamily(ctx context.Context, globalVirtualGroup
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) QuerySpAvailableGlobalVirtualGroupFamilies(ctx context.Context, spID uint32) ([]uint32, error) {
-	queryResponse, err := c.chainClient.QuerySpAvailableGlobalVirtualGroupFamilies(ctx, &types.QuerySPAvailableGlobalVirtualGroupFamiliesRequest{
+	queryResponse, err := queryChain(ctx, c, "QuerySpAvailableGlobalVirtualGroupFamilies", (*sdkclient.MechainClient).QuerySpAvailableGlobalVirtualGroupFamilies, &types.QuerySPAvailableGlobalVirtualGroupFamiliesRequest{
 		SpId: spID,
 	})
 	if err != nil {
@@ -68,7 +70,7 @@ func (c *Client) QuerySpAvailableGlobalVirtualGroupFamilies(ctx context.Context,
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) QuerySpOptimalGlobalVirtualGroupFamily(ctx context.Context, spID uint32, strategy types.PickVGFStrategy) (uint32, error) {
-	queryResponse, err := c.chainClient.QuerySpOptimalGlobalVirtualGroupFamily(ctx, &types.QuerySpOptimalGlobalVirtualGroupFamilyRequest{
+	queryResponse, err := queryChain(ctx, c, "QuerySpOptimalGlobalVirtualGroupFamily", (*sdkclient.MechainClient).QuerySpOptimalGlobalVirtualGroupFamily, &types.QuerySpOptimalGlobalVirtualGroupFamilyRequest{
 		SpId:            spID,
 		PickVgfStrategy: strategy,
 	})
@@ -88,7 +90,11 @@ func (c *Client) QuerySpOptimalGlobalVirtualGroupFamily(ctx context.Context, spI
 //
 // - ret2: Return error when the request failed, otherwise return nil.
 func (c *Client) QueryVirtualGroupParams(ctx context.Context) (*types.Params, error) {
-	queryResponse, err := c.chainClient.VirtualGroupQueryClient.Params(ctx, &types.QueryParamsRequest{})
+	queryResponse, err := queryChain(ctx, c, "VirtualGroupParams", func(chain *sdkclient.MechainClient, ctx context.Context,
+		req *types.QueryParamsRequest, opts ...grpc.CallOption,
+	) (*types.QueryParamsResponse, error) {
+		return chain.VirtualGroupQueryClient.Params(ctx, req, opts...)
+	}, &types.QueryParamsRequest{})
 	if err != nil {
 		return nil, err
 	}
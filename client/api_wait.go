@@ -0,0 +1,296 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+const (
+	// minWaitPollInterval is the first interval to poll the state when the event subscription is unavailable, the
+	// interval grows up to maxWaitPollInterval.
+	minWaitPollInterval = time.Second
+	maxWaitPollInterval = 10 * time.Second
+	// eventWaitPollInterval is the interval to poll the state while the event subscription is available, in case an
+	// event is not recognized.
+	eventWaitPollInterval = 30 * time.Second
+)
+
+// IWaitClient interface defines functions to wait for the asynchronous operations to complete.
+//
+// The waiters prefer the events of the chain delivered by Subscribe, and fall back to polling the state with a
+// growing interval if the event subscription is unavailable. They return when the ctx is done.
+type IWaitClient interface {
+	WaitForObjectSealed(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error)
+	WaitForObjectUpdateCompleted(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error)
+	WaitForObjectDeleted(ctx context.Context, bucketName, objectName string) error
+	WaitForBucketMigrationComplete(ctx context.Context, bucketName string, fromHeight int64) (*storageTypes.BucketInfo, error)
+}
+
+// WaitForObjectSealed - Wait until the object created on chain is sealed by the primary SP.
+//
+// - ctx: Context variables for the current API call, the deadline of the ctx bounds the wait.
+//
+// - bucketName: The name of the bucket.
+//
+// - objectName: The name of the object.
+//
+// - ret1: The object detail after the object is sealed.
+//
+// - ret2: Return error matching types.ErrRejected if the seal is rejected by the SP or the object is deleted, or the
+// error of the ctx if it is done before the object is sealed.
+func (c *Client) WaitForObjectSealed(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error) {
+	const op = "seal object"
+	var objectDetail *types.ObjectDetail
+	err := c.waitUntil(ctx, types.SubscribeOptions{
+		EventTypes:   []string{types.EventTypeSealObject, types.EventTypeRejectSealObject, types.EventTypeDeleteObject},
+		BucketName:   bucketName,
+		ObjectPrefix: objectName,
+	}, func(ctx context.Context) (bool, error) {
+		detail, err := c.HeadObject(ctx, bucketName, objectName)
+		if err != nil {
+			if errors.Is(err, types.ErrNoSuchObject) {
+				return false, &types.RejectedError{Op: op, Reason: "the object does not exist, it may have been rejected by the SP", Err: err}
+			}
+			return false, err
+		}
+		switch detail.ObjectInfo.GetObjectStatus() {
+		case storageTypes.OBJECT_STATUS_SEALED:
+			objectDetail = detail
+			return true, nil
+		case storageTypes.OBJECT_STATUS_DISCONTINUED:
+			return false, &types.RejectedError{Op: op, Reason: "the object has been discontinued"}
+		}
+		return false, nil
+	}, func(event *types.Event) error {
+		if event.Type == types.EventTypeRejectSealObject && isObjectEvent(event, bucketName, objectName) {
+			return &types.RejectedError{Op: op, Reason: "the seal of the object is rejected by the SP in tx " + event.TxHash}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objectDetail, nil
+}
+
+// WaitForObjectUpdateCompleted - Wait until the new content of the object being updated is sealed by the primary SP.
+//
+// The rejection of the update is recognized by the events only, the object looks the same as the one whose update
+// completes when polling.
+//
+// - ctx: Context variables for the current API call, the deadline of the ctx bounds the wait.
+//
+// - bucketName: The name of the bucket.
+//
+// - objectName: The name of the object.
+//
+// - ret1: The object detail after the update completes.
+//
+// - ret2: Return error matching types.ErrRejected if the seal is rejected by the SP or the update is canceled, or the
+// error of the ctx if it is done before the update completes.
+func (c *Client) WaitForObjectUpdateCompleted(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error) {
+	const op = "update object content"
+	var objectDetail *types.ObjectDetail
+	err := c.waitUntil(ctx, types.SubscribeOptions{
+		EventTypes: []string{
+			types.EventTypeSealObject, types.EventTypeRejectSealObject, types.EventTypeCancelUpdateObjectContent,
+			types.EventTypeDeleteObject,
+		},
+		BucketName:   bucketName,
+		ObjectPrefix: objectName,
+	}, func(ctx context.Context) (bool, error) {
+		detail, err := c.HeadObject(ctx, bucketName, objectName)
+		if err != nil {
+			if errors.Is(err, types.ErrNoSuchObject) {
+				return false, &types.RejectedError{Op: op, Reason: "the object has been deleted", Err: err}
+			}
+			return false, err
+		}
+		if detail.ObjectInfo.GetObjectStatus() == storageTypes.OBJECT_STATUS_SEALED && !detail.ObjectInfo.GetIsUpdating() {
+			objectDetail = detail
+			return true, nil
+		}
+		return false, nil
+	}, func(event *types.Event) error {
+		if !isObjectEvent(event, bucketName, objectName) {
+			return nil
+		}
+		switch event.Type {
+		case types.EventTypeRejectSealObject:
+			return &types.RejectedError{Op: op, Reason: "the seal of the new content is rejected by the SP in tx " + event.TxHash}
+		case types.EventTypeCancelUpdateObjectContent:
+			return &types.RejectedError{Op: op, Reason: "the update is canceled in tx " + event.TxHash}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objectDetail, nil
+}
+
+// WaitForObjectDeleted - Wait until the object is deleted from chain, e.g. after it is discontinued.
+//
+// - ctx: Context variables for the current API call, the deadline of the ctx bounds the wait.
+//
+// - bucketName: The name of the bucket.
+//
+// - objectName: The name of the object.
+//
+// - ret: Return nil when the object does not exist, or the error of the ctx if it is done before the object is deleted.
+func (c *Client) WaitForObjectDeleted(ctx context.Context, bucketName, objectName string) error {
+	return c.waitUntil(ctx, types.SubscribeOptions{
+		EventTypes:   []string{types.EventTypeDeleteObject, types.EventTypeRejectSealObject},
+		BucketName:   bucketName,
+		ObjectPrefix: objectName,
+	}, func(ctx context.Context) (bool, error) {
+		_, err := c.HeadObject(ctx, bucketName, objectName)
+		if errors.Is(err, types.ErrNoSuchObject) {
+			return true, nil
+		}
+		return false, err
+	}, nil)
+}
+
+// WaitForBucketMigrationComplete - Wait until the migration of the bucket to the destination SP completes.
+//
+// The migration is complete only after the bucket has been seen migrating or the event of completing the migration
+// has been received, so that it waits for a migration which has not started yet, e.g. it is called right after
+// MigrateBucket in the async mode. The events are backfilled from fromHeight, so that a migration which completes, or
+// is rejected or canceled, before the wait is recognized.
+//
+// - ctx: Context variables for the current API call, the deadline of the ctx bounds the wait.
+//
+// - bucketName: The name of the bucket.
+//
+// - fromHeight: The height of the block which includes the MigrateBucket transaction, e.g. the height of the tx
+// response returned by WaitForTx. If it is 0, only the events after the latest block are received, and the wait can
+// not end if the migration has already completed.
+//
+// - ret1: The bucket info after the migration completes.
+//
+// - ret2: Return error matching types.ErrRejected if the migration is rejected by the destination SP or canceled, or
+// the error of the ctx if it is done before the migration completes.
+func (c *Client) WaitForBucketMigrationComplete(ctx context.Context, bucketName string, fromHeight int64) (*storageTypes.BucketInfo, error) {
+	const op = "migrate bucket"
+	var (
+		bucketInfo *storageTypes.BucketInfo
+		// familyID is the virtual group family of the bucket before the migration, it changes if the migration completes
+		familyID  uint32
+		migrating bool
+		// completed indicates the event of completing the migration has been received
+		completed bool
+	)
+	err := c.waitUntil(ctx, types.SubscribeOptions{
+		EventTypes: []string{
+			types.EventTypeCompleteMigrationBucket, types.EventTypeRejectMigrateBucket, types.EventTypeCancelMigrationBucket,
+		},
+		BucketName: bucketName,
+		FromHeight: fromHeight,
+	}, func(ctx context.Context) (bool, error) {
+		info, err := c.HeadBucket(ctx, bucketName)
+		if err != nil {
+			return false, err
+		}
+		if info.GetBucketStatus() == storageTypes.BUCKET_STATUS_MIGRATING {
+			if !migrating {
+				familyID, migrating = info.GetGlobalVirtualGroupFamilyId(), true
+			}
+			return false, nil
+		}
+		if !migrating && !completed {
+			// the migration has not started yet
+			return false, nil
+		}
+		if migrating && info.GetGlobalVirtualGroupFamilyId() == familyID {
+			return false, &types.RejectedError{Op: op, Reason: "the bucket stays in the virtual group family of the source SP"}
+		}
+		bucketInfo = info
+		return true, nil
+	}, func(event *types.Event) error {
+		switch event.Type {
+		case types.EventTypeCompleteMigrationBucket:
+			completed = true
+		case types.EventTypeRejectMigrateBucket:
+			return &types.RejectedError{Op: op, Reason: "the migration is rejected by the destination SP in tx " + event.TxHash}
+		case types.EventTypeCancelMigrationBucket:
+			return &types.RejectedError{Op: op, Reason: "the migration is canceled in tx " + event.TxHash}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bucketInfo, nil
+}
+
+// waitUntil waits until check reports done, or rejected reports an error for an event.
+//
+// The state is checked after the events matching opts are received. If the event subscription is unavailable, it is
+// polled with a growing interval instead, and the events are polled from the blocks if opts.FromHeight is set. rejected is called with every event before the state is checked, it can be
+// nil if no event indicates a rejection.
+func (c *Client) waitUntil(ctx context.Context, opts types.SubscribeOptions, check func(ctx context.Context) (bool, error),
+	rejected func(event *types.Event) error,
+) error {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	interval, polling := eventWaitPollInterval, false
+	events, err := c.Subscribe(subCtx, opts)
+	if err != nil {
+		c.logger.Debug("the event subscription is unavailable, polling the state", "error", err)
+		interval, polling = minWaitPollInterval, true
+		if opts.FromHeight > 0 {
+			// the events from the height are polled from the blocks, they may have been emitted before the wait
+			if events, err = c.pollEvents(subCtx, opts, minWaitPollInterval); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the state is checked after subscribing, so that the events emitted before it are not missed
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				// the subscription is shut down, e.g. the ctx is done, events is set to nil to poll the state
+				events, interval, polling = nil, minWaitPollInterval, true
+				resetTimer(timer, 0)
+				continue
+			}
+			if rejected != nil {
+				if err := rejected(event); err != nil {
+					return err
+				}
+			}
+			resetTimer(timer, 0)
+		case <-timer.C:
+			done, err := check(ctx)
+			if err != nil || done {
+				return err
+			}
+			timer.Reset(interval)
+			if polling && interval < maxWaitPollInterval {
+				interval = min(interval*2, maxWaitPollInterval)
+			}
+		}
+	}
+}
+
+// isObjectEvent reports whether the event is about the object.
+func isObjectEvent(event *types.Event, bucketName, objectName string) bool {
+	e, ok := event.Data.(interface {
+		GetBucketName() string
+		GetObjectName() string
+	})
+	return ok && e.GetBucketName() == bucketName && e.GetObjectName() == objectName
+}
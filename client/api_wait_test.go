@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	bfttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/proto"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// handleStates serves the responses of the query path in turn, the last one is served repeatedly. A nil response is
+// answered with the error.
+func handleStates(chain *testChain, path string, err error, resps ...proto.Message) {
+	var (
+		mu   sync.Mutex
+		next int
+	)
+	chain.handleQuery(path, func([]byte) (proto.Message, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		resp := resps[next]
+		if next < len(resps)-1 {
+			next++
+		}
+		if resp == nil {
+			return nil, err
+		}
+		return resp, nil
+	})
+}
+
+func headObject(status storageTypes.ObjectStatus, isUpdating bool) proto.Message {
+	return &storageTypes.QueryHeadObjectResponse{ObjectInfo: &storageTypes.ObjectInfo{
+		BucketName:   "photos",
+		ObjectName:   "a.jpg",
+		ObjectStatus: status,
+		IsUpdating:   isUpdating,
+	}}
+}
+
+func headBucket(status storageTypes.BucketStatus, familyID uint32) proto.Message {
+	return &storageTypes.QueryHeadBucketResponse{BucketInfo: &storageTypes.BucketInfo{
+		BucketName:                 "photos",
+		BucketStatus:               status,
+		GlobalVirtualGroupFamilyId: familyID,
+	}}
+}
+
+// migrationEvent returns a block whose transaction emits the event about the migration of the bucket.
+func migrationEvent(eventType, bucketName string) testBlock {
+	return testBlock{
+		txs: []bfttypes.Tx{[]byte(eventType)},
+		txResults: []*abci.ResponseDeliverTx{{Events: []abci.Event{
+			recordedEvent("mechain.storage."+eventType, "bucket_name", `"`+bucketName+`"`),
+		}}},
+	}
+}
+
+const (
+	headObjectPath = "/mechain.storage.Query/HeadObject"
+	headBucketPath = "/mechain.storage.Query/HeadBucket"
+)
+
+func TestWaitForObjectSealed(t *testing.T) {
+	chain := newTestChain(t)
+	cli := chain.newClient(t, Option{})
+	ctx := context.Background()
+
+	// the websocket of the stub node is unavailable, the state is polled
+	handleStates(chain, headObjectPath, nil,
+		headObject(storageTypes.OBJECT_STATUS_CREATED, false), headObject(storageTypes.OBJECT_STATUS_SEALED, false))
+	detail, err := cli.WaitForObjectSealed(ctx, "photos", "a.jpg")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.OBJECT_STATUS_SEALED, detail.ObjectInfo.ObjectStatus)
+	require.Equal(t, 2, chain.callCount(headObjectPath))
+
+	handleStates(chain, headObjectPath, nil, headObject(storageTypes.OBJECT_STATUS_DISCONTINUED, false))
+	_, err = cli.WaitForObjectSealed(ctx, "photos", "a.jpg")
+	require.ErrorIs(t, err, types.ErrRejected)
+
+	handleStates(chain, headObjectPath, storageTypes.ErrNoSuchObject, nil)
+	_, err = cli.WaitForObjectSealed(ctx, "photos", "a.jpg")
+	require.ErrorIs(t, err, types.ErrRejected)
+	require.ErrorIs(t, err, types.ErrNoSuchObject, "the rejection wraps the error")
+
+	handleStates(chain, headObjectPath, errors.New("unavailable"), nil)
+	_, err = cli.WaitForObjectSealed(ctx, "photos", "a.jpg")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, types.ErrRejected)
+}
+
+func TestWaitForObjectUpdateCompleted(t *testing.T) {
+	chain := newTestChain(t)
+	cli := chain.newClient(t, Option{})
+	ctx := context.Background()
+
+	handleStates(chain, headObjectPath, nil,
+		headObject(storageTypes.OBJECT_STATUS_SEALED, true), headObject(storageTypes.OBJECT_STATUS_SEALED, false))
+	detail, err := cli.WaitForObjectUpdateCompleted(ctx, "photos", "a.jpg")
+	require.NoError(t, err)
+	require.False(t, detail.ObjectInfo.IsUpdating)
+	require.Equal(t, 2, chain.callCount(headObjectPath))
+
+	handleStates(chain, headObjectPath, storageTypes.ErrNoSuchObject, nil)
+	_, err = cli.WaitForObjectUpdateCompleted(ctx, "photos", "a.jpg")
+	require.ErrorIs(t, err, types.ErrRejected)
+}
+
+func TestWaitForObjectDeleted(t *testing.T) {
+	chain := newTestChain(t)
+	cli := chain.newClient(t, Option{})
+
+	handleStates(chain, headObjectPath, storageTypes.ErrNoSuchObject,
+		headObject(storageTypes.OBJECT_STATUS_DISCONTINUED, false), nil)
+	require.NoError(t, cli.WaitForObjectDeleted(context.Background(), "photos", "a.jpg"))
+	require.Equal(t, 2, chain.callCount(headObjectPath))
+
+	// the wait is bounded by the ctx
+	handleStates(chain, headObjectPath, nil, headObject(storageTypes.OBJECT_STATUS_DISCONTINUED, false))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, cli.WaitForObjectDeleted(ctx, "photos", "a.jpg"), context.DeadlineExceeded)
+}
+
+func TestWaitForBucketMigrationComplete(t *testing.T) {
+	chain := newTestChain(t)
+	cli := chain.newClient(t, Option{})
+	ctx := context.Background()
+
+	// the bucket is seen migrating, then in the family of the destination SP
+	handleStates(chain, headBucketPath, nil,
+		headBucket(storageTypes.BUCKET_STATUS_MIGRATING, 1), headBucket(storageTypes.BUCKET_STATUS_CREATED, 2))
+	info, err := cli.WaitForBucketMigrationComplete(ctx, "photos", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), info.GlobalVirtualGroupFamilyId)
+
+	// the bucket stays in the family of the source SP after migrating
+	handleStates(chain, headBucketPath, nil,
+		headBucket(storageTypes.BUCKET_STATUS_MIGRATING, 1), headBucket(storageTypes.BUCKET_STATUS_CREATED, 1))
+	_, err = cli.WaitForBucketMigrationComplete(ctx, "photos", 0)
+	require.ErrorIs(t, err, types.ErrRejected)
+
+	// a migration which has not been seen migrating is not complete
+	handleStates(chain, headBucketPath, nil, headBucket(storageTypes.BUCKET_STATUS_CREATED, 2))
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = cli.WaitForBucketMigrationComplete(timeoutCtx, "photos", 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWaitForBucketMigrationCompleteBackfill(t *testing.T) {
+	chain := newTestChain(t)
+	cli := chain.newClient(t, Option{})
+	ctx := context.Background()
+
+	// the migration completes before the wait, the event is backfilled from the height of the MigrateBucket tx
+	fromHeight := chain.addBlock(testBlock{})
+	chain.addBlock(migrationEvent(types.EventTypeCompleteMigrationBucket, "docs"))
+	chain.addBlock(migrationEvent(types.EventTypeCompleteMigrationBucket, "photos"))
+	handleStates(chain, headBucketPath, nil, headBucket(storageTypes.BUCKET_STATUS_CREATED, 2))
+	info, err := cli.WaitForBucketMigrationComplete(ctx, "photos", fromHeight)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), info.GlobalVirtualGroupFamilyId)
+
+	// the migration is rejected or canceled before the wait
+	for _, eventType := range []string{types.EventTypeRejectMigrateBucket, types.EventTypeCancelMigrationBucket} {
+		fromHeight = chain.addBlock(migrationEvent(eventType, "photos"))
+		handleStates(chain, headBucketPath, nil, headBucket(storageTypes.BUCKET_STATUS_CREATED, 1))
+		_, err = cli.WaitForBucketMigrationComplete(ctx, "photos", fromHeight)
+		require.ErrorIs(t, err, types.ErrRejected, eventType)
+		require.ErrorContains(t, err, "tx ", eventType)
+	}
+
+	// the events before the height are not received
+	fromHeight = chain.addBlock(testBlock{})
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = cli.WaitForBucketMigrationComplete(timeoutCtx, "photos", fromHeight)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
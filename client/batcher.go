@@ -0,0 +1,383 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/evmos/evmos/v12/sdk/types"
+	gosdktypes "github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// The default configurations of the Batcher.
+const (
+	DefaultBatchMaxMsgs       = 100
+	DefaultBatchMaxBytes      = 512 * 1024
+	DefaultBatchFlushInterval = time.Second
+	DefaultBatchWaitTimeout   = time.Minute
+)
+
+// ErrBatcherClosed is returned by the futures of the messages submitted after the Batcher is closed.
+var ErrBatcherClosed = errors.New("the batcher has been closed")
+
+// failedMsgIndexRegexp matches the index of the failing message in the error log of a transaction.
+var failedMsgIndexRegexp = regexp.MustCompile(`message index: (\d+)`)
+
+// BatcherOption - The configurations of the Batcher.
+type BatcherOption struct {
+	// MaxMsgs is the max number of messages in a transaction, DefaultBatchMaxMsgs is used if it is not set.
+	MaxMsgs int
+	// MaxBytes is the max total size of the encoded messages in a transaction, DefaultBatchMaxBytes is used if it
+	// is not set.
+	MaxBytes int
+	// FlushInterval is the max time a message waits in the batcher before it is broadcast,
+	// DefaultBatchFlushInterval is used if it is not set.
+	FlushInterval time.Duration
+	// WaitTimeout is the max time to wait for a broadcast transaction to be included in a block,
+	// DefaultBatchWaitTimeout is used if it is not set.
+	WaitTimeout time.Duration
+	// TxOpts is the options of the transactions broadcast by the batcher, the gas limit should not be set since the
+	// number of the messages varies.
+	TxOpts *types.TxOption
+}
+
+// BatchMsgResult - The result of a message executed in a batched transaction.
+type BatchMsgResult struct {
+	// TxHash is the hash of the transaction which includes the message.
+	TxHash string
+	// Height is the height of the block which includes the transaction.
+	Height int64
+	// MsgIndex is the index of the message in the transaction.
+	MsgIndex int
+	// Events is the events emitted by the message.
+	Events sdk.StringEvents
+}
+
+// MsgFuture - The future result of a message submitted to the Batcher.
+type MsgFuture struct {
+	done   chan struct{}
+	result *BatchMsgResult
+	err    error
+}
+
+func newMsgFuture() *MsgFuture {
+	return &MsgFuture{done: make(chan struct{})}
+}
+
+// Done - Return a channel which is closed when the result of the message is available.
+func (f *MsgFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result - Wait for the result of the message.
+//
+// - ctx: Context to stop waiting, the message is still executed if the ctx is done.
+//
+// - ret1: The result of the message.
+//
+// - ret2: Return error if the message has failed, or the ctx is done before the result is available.
+func (f *MsgFuture) Result(ctx context.Context) (*BatchMsgResult, error) {
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (f *MsgFuture) resolve(result *BatchMsgResult, err error) {
+	f.result, f.err = result, err
+	close(f.done)
+}
+
+// batchItem is a message waiting in the Batcher.
+type batchItem struct {
+	ctx    context.Context
+	msg    sdk.Msg
+	future *MsgFuture
+}
+
+// Batcher - Coalesce the messages submitted by many goroutines into multi-message transactions.
+//
+// The pending messages are broadcast in a transaction when their number reaches MaxMsgs, their size reaches MaxBytes,
+// or the first of them has waited for FlushInterval. The messages submitted with different accounts set by WithAccount
+// are broadcast in different transactions. If a message of a transaction fails, it is isolated by the message index
+// in the error log and the other messages are broadcast again, if the transaction itself is rejected, e.g. by the
+// ante handler, all its messages fail.
+type Batcher struct {
+	client IClient
+	opt    BatcherOption
+
+	mu      sync.RWMutex
+	closed  bool
+	itemCh  chan *batchItem
+	workers sync.WaitGroup
+}
+
+// NewBatcher - Create a Batcher which broadcasts the transactions by the client, the transactions are signed by the
+// account set by WithAccount in the context of the messages, or the default account of the client.
+//
+// - client: The client to broadcast the transactions.
+//
+// - opt: The configurations of the Batcher.
+//
+// - ret: The Batcher, it should be closed by Close after use.
+func NewBatcher(client IClient, opt BatcherOption) *Batcher {
+	if opt.MaxMsgs <= 0 {
+		opt.MaxMsgs = DefaultBatchMaxMsgs
+	}
+	if opt.MaxBytes <= 0 {
+		opt.MaxBytes = DefaultBatchMaxBytes
+	}
+	if opt.FlushInterval <= 0 {
+		opt.FlushInterval = DefaultBatchFlushInterval
+	}
+	if opt.WaitTimeout <= 0 {
+		opt.WaitTimeout = DefaultBatchWaitTimeout
+	}
+	b := &Batcher{
+		client: client,
+		opt:    opt,
+		itemCh: make(chan *batchItem),
+	}
+	b.workers.Add(1)
+	go b.run()
+	return b
+}
+
+// Submit - Submit a message to be broadcast in a batched transaction.
+//
+// - ctx: Context of the message, the message is dropped if the ctx is done before it is broadcast. The account, the
+// deadline and the trace of the ctx apply to the transaction which includes the message.
+//
+// - msg: The message to be broadcast, e.g. the messages created by storagetypes.NewMsgSetTag.
+//
+// - ret: The future result of the message.
+func (b *Batcher) Submit(ctx context.Context, msg sdk.Msg) *MsgFuture {
+	future := newMsgFuture()
+	if err := msg.ValidateBasic(); err != nil {
+		future.resolve(nil, err)
+		return future
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		future.resolve(nil, ErrBatcherClosed)
+		return future
+	}
+	select {
+	case b.itemCh <- &batchItem{ctx: ctx, msg: msg, future: future}:
+	case <-ctx.Done():
+		future.resolve(nil, ctx.Err())
+	}
+	return future
+}
+
+// Close - Broadcast the pending messages and wait for the results of all the submitted messages.
+func (b *Batcher) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.itemCh)
+	}
+	b.mu.Unlock()
+	b.workers.Wait()
+}
+
+// run accumulates the submitted messages and flushes them until the Batcher is closed.
+func (b *Batcher) run() {
+	defer b.workers.Done()
+
+	var (
+		pending []*batchItem
+		size    int
+		timer   = time.NewTimer(b.opt.FlushInterval)
+	)
+	timer.Stop()
+	flush := func() {
+		if !timer.Stop() {
+			// drain the fired timer unless its value has been received, so that a later Reset does not flush early
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if len(pending) > 0 {
+			b.send(pending)
+		}
+		pending, size = nil, 0
+	}
+
+	for {
+		select {
+		case item, ok := <-b.itemCh:
+			if !ok {
+				flush()
+				return
+			}
+			pending = append(pending, item)
+			size += proto.Size(item.msg)
+			if len(pending) == 1 {
+				timer.Reset(b.opt.FlushInterval)
+			}
+			if len(pending) >= b.opt.MaxMsgs || size >= b.opt.MaxBytes {
+				flush()
+			}
+		case <-timer.C:
+			flush()
+		}
+	}
+}
+
+// send broadcasts the messages in transactions grouped by their accounts, the results are resolved after the
+// transactions are included.
+func (b *Batcher) send(items []*batchItem) {
+	var (
+		groups   [][]*batchItem
+		groupIdx = make(map[*gosdktypes.Account]int)
+	)
+	for _, item := range items {
+		if err := item.ctx.Err(); err != nil {
+			item.future.resolve(nil, err)
+			continue
+		}
+		account, _ := AccountFromContext(item.ctx)
+		idx, ok := groupIdx[account]
+		if !ok {
+			idx = len(groups)
+			groupIdx[account] = idx
+			groups = append(groups, nil)
+		}
+		groups[idx] = append(groups[idx], item)
+	}
+	for _, group := range groups {
+		b.broadcast(group)
+	}
+}
+
+// broadcast broadcasts the messages of an account in a transaction.
+func (b *Batcher) broadcast(items []*batchItem) {
+	msgs := make([]sdk.Msg, len(items))
+	for i, item := range items {
+		msgs[i] = item.msg
+	}
+	ctx, cancel := batchContext(items)
+	resp, err := b.client.BroadcastTx(ctx, msgs, b.opt.TxOpts)
+	cancel()
+	if err != nil {
+		b.isolate(items, err)
+		return
+	}
+
+	b.workers.Add(1)
+	go func() {
+		defer b.workers.Done()
+		b.wait(items, resp)
+	}()
+}
+
+// batchContext returns the context of the transaction including the messages, it carries the values of the context
+// of the first message, e.g. its account and trace, and expires at the earliest deadline of the messages. It is not
+// canceled with the context of any message, since the other messages are still to be broadcast.
+func batchContext(items []*batchItem) (context.Context, context.CancelFunc) {
+	ctx := context.WithoutCancel(items[0].ctx)
+	var deadline time.Time
+	for _, item := range items {
+		if d, ok := item.ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+			deadline = d
+		}
+	}
+	if deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, deadline)
+}
+
+// wait waits for the transaction to be included and resolves the results of its messages.
+func (b *Batcher) wait(items []*batchItem, resp *tx.BroadcastTxResponse) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(items[0].ctx), b.opt.WaitTimeout)
+	defer cancel()
+
+	txHash := resp.TxResponse.TxHash
+	txResult, err := b.client.WaitForTx(ctx, txHash)
+	if err != nil {
+		for _, item := range items {
+			item.future.resolve(nil, err)
+		}
+		return
+	}
+	if txResult.TxResult.Code != 0 {
+		b.isolate(items, &gosdktypes.TxFailedError{
+			Op:        "batched tx",
+			TxHash:    txHash,
+			Code:      txResult.TxResult.Code,
+			Codespace: txResult.TxResult.Codespace,
+			Log:       txResult.TxResult.Log,
+		})
+		return
+	}
+
+	logs, _ := sdk.ParseABCILogs(txResult.TxResult.Log)
+	for i, item := range items {
+		result := &BatchMsgResult{
+			TxHash:   txHash,
+			Height:   txResult.Height,
+			MsgIndex: i,
+		}
+		for _, log := range logs {
+			if int(log.MsgIndex) == i {
+				result.Events = log.Events
+			}
+		}
+		item.future.resolve(result, nil)
+	}
+}
+
+// isolate resolves the failing message of the failed transaction and broadcasts the other messages again.
+//
+// The failing message is located by the message index in the error. If it is unknown, the failure is not caused by
+// a single message, e.g. the transaction is rejected by the ante handler or the node is unavailable, so all the
+// messages fail with the error.
+func (b *Batcher) isolate(items []*batchItem, err error) {
+	if len(items) == 1 {
+		items[0].future.resolve(nil, err)
+		return
+	}
+
+	var failedLog string
+	var txErr *gosdktypes.TxFailedError
+	if errors.As(err, &txErr) {
+		failedLog = txErr.Log
+	} else {
+		failedLog = err.Error()
+	}
+	if idx, ok := failedMsgIndex(failedLog); ok && idx < len(items) {
+		items[idx].future.resolve(nil, err)
+		rest := make([]*batchItem, 0, len(items)-1)
+		rest = append(rest, items[:idx]...)
+		b.send(append(rest, items[idx+1:]...))
+		return
+	}
+
+	for _, item := range items {
+		item.future.resolve(nil, err)
+	}
+}
+
+// failedMsgIndex returns the index of the failing message in the error log of a transaction.
+func failedMsgIndex(log string) (int, bool) {
+	match := failedMsgIndexRegexp.FindStringSubmatch(log)
+	if match == nil {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(match[1])
+	return idx, err == nil
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// batchTestClient records the transactions broadcast by the Batcher, the failure of a transaction is decided by
+// the fail function.
+type batchTestClient struct {
+	IClient
+
+	mu       sync.Mutex
+	txs      [][]sdk.Msg
+	accounts []*types.Account
+	fail     func(msgs []sdk.Msg) error
+}
+
+func (c *batchTestClient) BroadcastTx(ctx context.Context, msgs []sdk.Msg, _ *gnfdSdkTypes.TxOption, _ ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	account, _ := AccountFromContext(ctx)
+	c.txs = append(c.txs, msgs)
+	c.accounts = append(c.accounts, account)
+	if c.fail != nil {
+		if err := c.fail(msgs); err != nil {
+			return nil, err
+		}
+	}
+	return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: fmt.Sprintf("tx%d", len(c.txs))}}, nil
+}
+
+func (c *batchTestClient) WaitForTx(_ context.Context, _ string) (*ctypes.ResultTx, error) {
+	return &ctypes.ResultTx{Height: 1}, nil
+}
+
+func (c *batchTestClient) broadcastTxs() [][]sdk.Msg {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.txs
+}
+
+// newBatchTestMsg returns a valid message which sends amount to a new account, the amount tells the messages apart.
+func newBatchTestMsg(t *testing.T, amount int64) sdk.Msg {
+	from, _, err := types.NewAccount("from")
+	require.NoError(t, err)
+	to, _, err := types.NewAccount("to")
+	require.NoError(t, err)
+	return bankTypes.NewMsgSend(from.GetAddress(), to.GetAddress(), sdk.NewCoins(sdk.NewInt64Coin(gnfdSdkTypes.Denom, amount)))
+}
+
+func msgAmount(msg sdk.Msg) int64 {
+	return msg.(*bankTypes.MsgSend).Amount[0].Amount.Int64()
+}
+
+func TestBatcherCoalesces(t *testing.T) {
+	client := &batchTestClient{}
+	b := NewBatcher(client, BatcherOption{MaxMsgs: 3, FlushInterval: time.Hour})
+
+	var futures []*MsgFuture
+	for i := 1; i <= 3; i++ {
+		futures = append(futures, b.Submit(context.Background(), newBatchTestMsg(t, int64(i))))
+	}
+	for i, future := range futures {
+		result, err := future.Result(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "tx1", result.TxHash)
+		require.Equal(t, i, result.MsgIndex)
+	}
+	b.Close()
+	require.Len(t, client.broadcastTxs(), 1)
+}
+
+func TestBatcherFlushInterval(t *testing.T) {
+	client := &batchTestClient{}
+	b := NewBatcher(client, BatcherOption{FlushInterval: 10 * time.Millisecond})
+	defer b.Close()
+
+	// each message waits for a flush by the timer, a stale tick must not flush the next message early
+	for i := 1; i <= 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := b.Submit(ctx, newBatchTestMsg(t, int64(i))).Result(ctx)
+		cancel()
+		require.NoError(t, err)
+	}
+	require.Len(t, client.broadcastTxs(), 3)
+}
+
+func TestBatcherIsolatesFailingMessage(t *testing.T) {
+	client := &batchTestClient{fail: func(msgs []sdk.Msg) error {
+		for i, msg := range msgs {
+			if msgAmount(msg) == 2 {
+				return &types.TxFailedError{Code: 1, Log: fmt.Sprintf("failed to execute message; message index: %d: insufficient funds", i)}
+			}
+		}
+		return nil
+	}}
+	b := NewBatcher(client, BatcherOption{MaxMsgs: 3, FlushInterval: time.Hour})
+
+	var futures []*MsgFuture
+	for i := 1; i <= 3; i++ {
+		futures = append(futures, b.Submit(context.Background(), newBatchTestMsg(t, int64(i))))
+	}
+	b.Close()
+
+	for i, future := range futures {
+		_, err := future.Result(context.Background())
+		if i == 1 {
+			require.ErrorIs(t, err, types.ErrTxFailed)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	txs := client.broadcastTxs()
+	require.Len(t, txs, 2)
+	require.Len(t, txs[1], 2)
+}
+
+func TestBatcherTxRejected(t *testing.T) {
+	rejected := errors.New("insufficient fee")
+	client := &batchTestClient{fail: func(msgs []sdk.Msg) error { return rejected }}
+	b := NewBatcher(client, BatcherOption{MaxMsgs: 4, FlushInterval: time.Hour})
+
+	var futures []*MsgFuture
+	for i := 1; i <= 4; i++ {
+		futures = append(futures, b.Submit(context.Background(), newBatchTestMsg(t, int64(i))))
+	}
+	b.Close()
+
+	for _, future := range futures {
+		_, err := future.Result(context.Background())
+		require.ErrorIs(t, err, rejected)
+	}
+	require.Len(t, client.broadcastTxs(), 1, "a rejected transaction is not split")
+}
+
+func TestBatcherAccounts(t *testing.T) {
+	client := &batchTestClient{}
+	b := NewBatcher(client, BatcherOption{MaxMsgs: 3, FlushInterval: time.Hour})
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	bob, _, err := types.NewAccount("bob")
+	require.NoError(t, err)
+
+	b.Submit(WithAccount(context.Background(), alice), newBatchTestMsg(t, 1))
+	b.Submit(WithAccount(context.Background(), bob), newBatchTestMsg(t, 2))
+	b.Submit(WithAccount(context.Background(), alice), newBatchTestMsg(t, 3))
+	b.Close()
+
+	txs := client.broadcastTxs()
+	require.Len(t, txs, 2)
+	require.Equal(t, []*types.Account{alice, bob}, client.accounts)
+	require.Len(t, txs[0], 2)
+	require.Len(t, txs[1], 1)
+}
@@ -0,0 +1,304 @@
+package clienttest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
+	paymentTypes "github.com/evmos/evmos/v12/x/payment/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// parseAddress returns the HEX-encoded address in the form the chain keeps it, e.g. the owners of the resources.
+func parseAddress(addr string) (string, error) {
+	accAddress, err := sdk.AccAddressFromHexUnsafe(addr)
+	if err != nil {
+		return "", err
+	}
+	return accAddress.String(), nil
+}
+
+// balance returns the balance of the account. f.mu should be held.
+func (f *FakeClient) balance(addr string) sdkmath.Int {
+	if balance, ok := f.balances[addr]; ok {
+		return balance
+	}
+	return sdkmath.ZeroInt()
+}
+
+// streamRecord returns the stream record of the account, it is created if it does not exist. f.mu should be held.
+func (f *FakeClient) streamRecord(addr string) *paymentTypes.StreamRecord {
+	record := f.streamRecords[addr]
+	if record == nil {
+		record = &paymentTypes.StreamRecord{
+			Account:           addr,
+			NetflowRate:       sdkmath.ZeroInt(),
+			StaticBalance:     sdkmath.ZeroInt(),
+			BufferBalance:     sdkmath.ZeroInt(),
+			LockBalance:       sdkmath.ZeroInt(),
+			FrozenNetflowRate: sdkmath.ZeroInt(),
+		}
+		f.streamRecords[addr] = record
+	}
+	return record
+}
+
+// SetBalance - Set the balance of the account, e.g. to fund the accounts of a test.
+//
+// - address: The HEX-encoded string of the account address.
+//
+// - amount: The balance in azkme.
+//
+// - ret: Return error if the address is invalid.
+func (f *FakeClient) SetBalance(address string, amount sdkmath.Int) error {
+	addr, err := parseAddress(address)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.balances[addr] = amount
+	f.notifyLocked()
+	return nil
+}
+
+// GetAccountBalance - Query the balance of the account, see client.IAccountClient.
+func (f *FakeClient) GetAccountBalance(ctx context.Context, address string) (*sdk.Coin, error) {
+	if err := f.call(ctx, "GetAccountBalance"); err != nil {
+		return nil, err
+	}
+	addr, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	coin := sdk.NewCoin(gnfdSdkTypes.Denom, f.balance(addr))
+	return &coin, nil
+}
+
+// Transfer - Transfer azkme from the caller to the account, see client.IAccountClient.
+func (f *FakeClient) Transfer(ctx context.Context, toAddress string, amount sdkmath.Int, txOption gnfdSdkTypes.TxOption) (string, error) {
+	if err := f.call(ctx, "Transfer"); err != nil {
+		return "", err
+	}
+	to, err := parseAddress(toAddress)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	from, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if f.balance(from).LT(amount) {
+		return "", txFailed("tx", txHash, sdkerrors.ErrInsufficientFunds, "%s%s is smaller than %s%s",
+			f.balance(from), gnfdSdkTypes.Denom, amount, gnfdSdkTypes.Denom)
+	}
+	f.balances[from] = f.balance(from).Sub(amount)
+	f.balances[to] = f.balance(to).Add(amount)
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// CreatePaymentAccount - Create a payment account owned by the caller, see client.IAccountClient.
+//
+// The address of the payment account is derived from the owner and the number of its payment accounts.
+func (f *FakeClient) CreatePaymentAccount(ctx context.Context, address string, txOption gnfdSdkTypes.TxOption) (string, error) {
+	if err := f.call(ctx, "CreatePaymentAccount"); err != nil {
+		return "", err
+	}
+	creator, err := parseAddress(address)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	txHash, _ := f.nextTx()
+
+	index := make([]byte, 8)
+	binary.BigEndian.PutUint64(index, uint64(len(f.ownedPaymentAccounts[creator])))
+	hash := sha256.Sum256(append([]byte(creator), index...))
+	addr := sdk.AccAddress(hash[:20]).String()
+	f.paymentAccounts[addr] = &paymentTypes.PaymentAccount{Addr: addr, Owner: creator, Refundable: true}
+	f.ownedPaymentAccounts[creator] = append(f.ownedPaymentAccounts[creator], addr)
+	f.streamRecord(addr)
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// GetPaymentAccount - Query the payment account, see client.IAccountClient.
+func (f *FakeClient) GetPaymentAccount(ctx context.Context, address string) (*paymentTypes.PaymentAccount, error) {
+	if err := f.call(ctx, "GetPaymentAccount"); err != nil {
+		return nil, err
+	}
+	addr, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pa := f.paymentAccounts[addr]
+	if pa == nil {
+		return nil, notFound("payment account %s not found", addr)
+	}
+	paymentAccount := *pa
+	return &paymentAccount, nil
+}
+
+// GetPaymentAccountsByOwner - Query the payment accounts owned by the account, see client.IAccountClient.
+func (f *FakeClient) GetPaymentAccountsByOwner(ctx context.Context, owner string) ([]*paymentTypes.PaymentAccount, error) {
+	if err := f.call(ctx, "GetPaymentAccountsByOwner"); err != nil {
+		return nil, err
+	}
+	ownerAddr, err := parseAddress(owner)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	paymentAccounts := make([]*paymentTypes.PaymentAccount, 0, len(f.ownedPaymentAccounts[ownerAddr]))
+	for _, addr := range f.ownedPaymentAccounts[ownerAddr] {
+		paymentAccount := *f.paymentAccounts[addr]
+		paymentAccounts = append(paymentAccounts, &paymentAccount)
+	}
+	return paymentAccounts, nil
+}
+
+// GetStreamRecord - Query the stream record of the account, see client.IPaymentClient.
+func (f *FakeClient) GetStreamRecord(ctx context.Context, streamAddress string) (*paymentTypes.StreamRecord, error) {
+	if err := f.call(ctx, "GetStreamRecord"); err != nil {
+		return nil, err
+	}
+	addr, err := parseAddress(streamAddress)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	record := f.streamRecords[addr]
+	if record == nil {
+		return nil, notFound("stream record %s not found", addr)
+	}
+	streamRecord := *record
+	return &streamRecord, nil
+}
+
+// Deposit - Deposit azkme from the caller to the stream record of the account, see client.IPaymentClient.
+func (f *FakeClient) Deposit(ctx context.Context, toAddress string, amount sdkmath.Int, txOption gnfdSdkTypes.TxOption) (string, error) {
+	if err := f.call(ctx, "Deposit"); err != nil {
+		return "", err
+	}
+	to, err := parseAddress(toAddress)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	from, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if f.balance(from).LT(amount) {
+		return "", txFailed("tx", txHash, sdkerrors.ErrInsufficientFunds, "%s%s is smaller than %s%s",
+			f.balance(from), gnfdSdkTypes.Denom, amount, gnfdSdkTypes.Denom)
+	}
+	f.balances[from] = f.balance(from).Sub(amount)
+	record := f.streamRecord(to)
+	record.StaticBalance = record.StaticBalance.Add(amount)
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// Withdraw - Withdraw azkme from the stream record of the caller or its refundable payment account, see
+// client.IPaymentClient.
+func (f *FakeClient) Withdraw(ctx context.Context, fromAddress string, amount sdkmath.Int, txOption gnfdSdkTypes.TxOption) (string, error) {
+	if err := f.call(ctx, "Withdraw"); err != nil {
+		return "", err
+	}
+	from, err := parseAddress(fromAddress)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	creator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if pa := f.paymentAccounts[from]; from != creator && (pa == nil || pa.Owner != creator || !pa.Refundable) {
+		return "", txFailed("tx", txHash, sdkerrors.ErrUnauthorized, "the payment account %s is not refundable by %s", from, creator)
+	}
+	record := f.streamRecords[from]
+	if record == nil || record.StaticBalance.LT(amount) {
+		return "", txFailed("tx", txHash, sdkerrors.ErrInsufficientFunds, "the static balance of %s is not enough", from)
+	}
+	record.StaticBalance = record.StaticBalance.Sub(amount)
+	f.balances[creator] = f.balance(creator).Add(amount)
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// DisableRefund - Disable the withdrawal of the payment account owned by the caller, see client.IPaymentClient.
+func (f *FakeClient) DisableRefund(ctx context.Context, paymentAddress string, txOption gnfdSdkTypes.TxOption) (string, error) {
+	if err := f.call(ctx, "DisableRefund"); err != nil {
+		return "", err
+	}
+	addr, err := parseAddress(paymentAddress)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	owner, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	pa := f.paymentAccounts[addr]
+	if pa == nil || pa.Owner != owner {
+		return "", txFailed("tx", txHash, sdkerrors.ErrUnauthorized, "the payment account %s is not owned by %s", addr, owner)
+	}
+	pa.Refundable = false
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// ListUserPaymentAccounts - List the payment accounts owned by the account with their stream records, see
+// client.IPaymentClient.
+func (f *FakeClient) ListUserPaymentAccounts(ctx context.Context, opts types.ListUserPaymentAccountsOptions) (types.ListUserPaymentAccountsResult, error) {
+	if err := f.call(ctx, "ListUserPaymentAccounts"); err != nil {
+		return types.ListUserPaymentAccountsResult{}, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	owner, err := f.callAccount(ctx)
+	if opts.Account != "" {
+		owner, err = parseAddress(opts.Account)
+	}
+	if err != nil {
+		return types.ListUserPaymentAccountsResult{}, err
+	}
+
+	result := types.ListUserPaymentAccountsResult{PaymentAccounts: make([]*types.PaymentAccounts, 0)}
+	for _, addr := range f.ownedPaymentAccounts[owner] {
+		pa, record := f.paymentAccounts[addr], f.streamRecord(addr)
+		result.PaymentAccounts = append(result.PaymentAccounts, &types.PaymentAccounts{
+			PaymentAccount: &types.PaymentAccount{Address: pa.Addr, Owner: pa.Owner, Refundable: pa.Refundable},
+			StreamRecord: &types.StreamRecord{
+				Account:       record.Account,
+				StaticBalance: record.StaticBalance.Int64(),
+			},
+		})
+	}
+	return result, nil
+}
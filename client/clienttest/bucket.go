@@ -0,0 +1,238 @@
+package clienttest
+
+import (
+	"context"
+	"sort"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/evmos/evmos/v12/types/resource"
+	"github.com/evmos/evmos/v12/types/s3util"
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// bucket is a bucket and its objects.
+type bucket struct {
+	info    *storageTypes.BucketInfo
+	objects map[string]*object
+}
+
+// txBucket returns the bucket a transaction acts on. f.mu should be held.
+func (f *FakeClient) txBucket(bucketName string) (*bucket, error) {
+	b := f.buckets[bucketName]
+	if b == nil {
+		txHash, _ := f.nextTx()
+		return nil, txFailed("tx", txHash, storageTypes.ErrNoSuchBucket, "bucket name: %s", bucketName)
+	}
+	return b, nil
+}
+
+// queryBucket returns the bucket a query is for. f.mu should be held.
+func (f *FakeClient) queryBucket(bucketName string) (*bucket, error) {
+	b := f.buckets[bucketName]
+	if b == nil {
+		return nil, queryFailed(storageTypes.ErrNoSuchBucket, "bucket name: %s", bucketName)
+	}
+	return b, nil
+}
+
+// CreateBucket - Create a bucket owned by the caller, see client.IBucketClient.
+func (f *FakeClient) CreateBucket(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (string, error) {
+	if err := f.call(ctx, "CreateBucket"); err != nil {
+		return "", err
+	}
+	result, err := f.createBucket(ctx, bucketName, primaryAddr, opts)
+	if result == nil {
+		return "", err
+	}
+	return result.TxHash, err
+}
+
+// CreateBucketWithResult - Create a bucket owned by the caller and return the created bucket, see client.IBucketClient.
+func (f *FakeClient) CreateBucketWithResult(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (*types.CreateBucketResult, error) {
+	if err := f.call(ctx, "CreateBucketWithResult"); err != nil {
+		return nil, err
+	}
+	return f.createBucket(ctx, bucketName, primaryAddr, opts)
+}
+
+// createBucket creates a bucket owned by the caller.
+func (f *FakeClient) createBucket(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (*types.CreateBucketResult, error) {
+	if _, err := sdk.AccAddressFromHexUnsafe(primaryAddr); err != nil {
+		return nil, err
+	}
+	if err := s3util.CheckValidBucketName(bucketName); err != nil {
+		return nil, err
+	}
+	visibility := opts.Visibility
+	if visibility == storageTypes.VISIBILITY_TYPE_UNSPECIFIED {
+		visibility = storageTypes.VISIBILITY_TYPE_PRIVATE
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	owner, err := f.callAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	paymentAddress := owner
+	if opts.PaymentAddress != "" {
+		if paymentAddress, err = parseAddress(opts.PaymentAddress); err != nil {
+			return nil, err
+		}
+	}
+	txHash, height := f.nextTx()
+	if f.buckets[bucketName] != nil {
+		return nil, txFailed("createBucket txn", txHash, storageTypes.ErrBucketAlreadyExists, "bucket name: %s", bucketName)
+	}
+	if pa := f.paymentAccounts[paymentAddress]; paymentAddress != owner && (pa == nil || pa.Owner != owner) {
+		return nil, txFailed("createBucket txn", txHash, storageTypes.ErrAccessDenied, "payment address: %s", paymentAddress)
+	}
+
+	info := &storageTypes.BucketInfo{
+		Owner:            owner,
+		BucketName:       bucketName,
+		Visibility:       visibility,
+		Id:               f.nextID("bucket"),
+		CreateAt:         height,
+		PaymentAddress:   paymentAddress,
+		ChargedReadQuota: opts.ChargedQuota,
+		BucketStatus:     storageTypes.BUCKET_STATUS_CREATED,
+		Tags:             opts.Tags,
+	}
+	b := &bucket{info: info, objects: make(map[string]*object)}
+	f.buckets[bucketName] = b
+	f.bucketsByID[info.Id.Uint64()] = b
+	f.notifyLocked()
+	return &types.CreateBucketResult{
+		TxHash: txHash,
+		Event: &storageTypes.EventCreateBucket{
+			Owner:            info.Owner,
+			BucketName:       info.BucketName,
+			Visibility:       info.Visibility,
+			CreateAt:         info.CreateAt,
+			BucketId:         info.Id,
+			ChargedReadQuota: info.ChargedReadQuota,
+			PaymentAddress:   info.PaymentAddress,
+			Status:           info.BucketStatus,
+		},
+	}, nil
+}
+
+// DeleteBucket - Delete an empty bucket, see client.IBucketClient.
+func (f *FakeClient) DeleteBucket(ctx context.Context, bucketName string, opt types.DeleteBucketOption) (string, error) {
+	if err := f.call(ctx, "DeleteBucket"); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, err := f.txBucket(bucketName)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if f.bucketEffect(b, operator, permTypes.ACTION_DELETE_BUCKET, "") != permTypes.EFFECT_ALLOW {
+		return "", txFailed("tx", txHash, storageTypes.ErrAccessDenied, "operator: %s, bucket name: %s", operator, bucketName)
+	}
+	if len(b.objects) > 0 {
+		return "", txFailed("tx", txHash, storageTypes.ErrBucketNotEmpty, "bucket name: %s", bucketName)
+	}
+	delete(f.buckets, bucketName)
+	delete(f.bucketsByID, b.info.Id.Uint64())
+	f.deletePolicies(resource.RESOURCE_TYPE_BUCKET, b.info.Id)
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// UpdateBucketVisibility - Update the visibility of the bucket, see client.IBucketClient.
+func (f *FakeClient) UpdateBucketVisibility(ctx context.Context, bucketName string, visibility storageTypes.VisibilityType, opt types.UpdateVisibilityOption) (string, error) {
+	if err := f.call(ctx, "UpdateBucketVisibility"); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, err := f.txBucket(bucketName)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if f.bucketEffect(b, operator, permTypes.ACTION_UPDATE_BUCKET_INFO, "") != permTypes.EFFECT_ALLOW {
+		return "", txFailed("tx", txHash, storageTypes.ErrAccessDenied, "operator: %s, bucket name: %s", operator, bucketName)
+	}
+	b.info.Visibility = visibility
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// HeadBucket - Query the bucket info, see client.IBucketClient.
+func (f *FakeClient) HeadBucket(ctx context.Context, bucketName string) (*storageTypes.BucketInfo, error) {
+	if err := f.call(ctx, "HeadBucket"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := f.queryBucket(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	info := *b.info
+	return &info, nil
+}
+
+// HeadBucketByID - Query the bucket info by the bucket ID, see client.IBucketClient.
+func (f *FakeClient) HeadBucketByID(ctx context.Context, bucketID string) (*storageTypes.BucketInfo, error) {
+	if err := f.call(ctx, "HeadBucketByID"); err != nil {
+		return nil, err
+	}
+	id, err := sdkmath.ParseUint(bucketID)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b := f.bucketsByID[id.Uint64()]
+	if b == nil {
+		return nil, queryFailed(storageTypes.ErrNoSuchBucket, "bucket id: %s", bucketID)
+	}
+	info := *b.info
+	return &info, nil
+}
+
+// ListBuckets - List the buckets owned by the account, see client.IBucketClient.
+func (f *FakeClient) ListBuckets(ctx context.Context, opts types.ListBucketsOptions) (types.ListBucketsResult, error) {
+	if err := f.call(ctx, "ListBuckets"); err != nil {
+		return types.ListBucketsResult{}, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	owner, err := f.callAccount(ctx)
+	if opts.Account != "" {
+		owner, err = parseAddress(opts.Account)
+	}
+	if err != nil {
+		return types.ListBucketsResult{}, err
+	}
+
+	result := types.ListBucketsResult{Buckets: make([]*types.BucketMetaWithVGF, 0)}
+	for _, b := range f.buckets {
+		if b.info.Owner == owner {
+			info := *b.info
+			result.Buckets = append(result.Buckets, &types.BucketMetaWithVGF{BucketInfo: &info})
+		}
+	}
+	sort.Slice(result.Buckets, func(i, j int) bool {
+		return result.Buckets[i].BucketInfo.BucketName < result.Buckets[j].BucketInfo.BucketName
+	})
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package clienttest
+
+import (
+	"fmt"
+	"net/http"
+
+	errorsmod "cosmossdk.io/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// txFailed returns the error of a transaction rejected by the chain with the registered error of a module, it is
+// the same as the one the Client returns after the transaction is delivered.
+func txFailed(op, txHash string, err *errorsmod.Error, format string, args ...any) error {
+	return &types.TxFailedError{
+		Op:        op,
+		TxHash:    txHash,
+		Code:      err.ABCICode(),
+		Codespace: err.Codespace(),
+		Log:       "failed to execute message; message index: 0: " + errorsmod.Wrapf(err, format, args...).Error(),
+	}
+}
+
+// queryFailed returns the error of a query rejected by the chain with the registered error of a module, it is the
+// same as the one the Client returns for the gRPC error.
+func queryFailed(err *errorsmod.Error, format string, args ...any) error {
+	return types.WrapChainError(status.Convert(errorsmod.Wrapf(err, format, args...)).Err())
+}
+
+// notFound returns the error of a query for the state which does not exist, e.g. a payment account.
+func notFound(format string, args ...any) error {
+	return status.Error(codes.NotFound, fmt.Sprintf(format, args...))
+}
+
+// spFailed returns the error response of an SP request, as the Client decodes it.
+func spFailed(statusCode int, code, message string) error {
+	return types.ErrResponse{StatusCode: statusCode, Code: code, Message: message}
+}
+
+// spNoSuchBucket returns the error response of an SP request for a bucket which does not exist.
+func spNoSuchBucket() error {
+	return spFailed(http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+}
+
+// spNoSuchObject returns the error response of an SP request for an object which does not exist.
+func spNoSuchObject() error {
+	return spFailed(http.StatusNotFound, "NoSuchObject", "The specified object does not exist.")
+}
+
+// spAccessDenied returns the error response of an SP request which is not permitted.
+func spAccessDenied() error {
+	return spFailed(http.StatusForbidden, "AccessDenied", "no permission to access the resource")
+}
+
+// unsupported returns the error of a method of IClient which the FakeClient does not support, naming the method.
+func unsupported(method, reason string) error {
+	return fmt.Errorf("clienttest: FakeClient.%s is not supported, %s", method, reason)
+}
+
+// unimplemented returns the error of the method of IClient which the FakeClient does not implement.
+func unimplemented(method string) error {
+	return unsupported(method, "it is not implemented by the FakeClient")
+}
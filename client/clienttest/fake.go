@@ -0,0 +1,346 @@
+// Package clienttest provides an in-memory fake of client.IClient for unit testing the code which depends on the
+// Client, without a chain or an SP.
+//
+// The fake keeps the state of the buckets, the objects, the groups, the policies, the balances and the payment
+// accounts, and returns the same kinds of errors as the Client, e.g. the errors matching types.ErrNoSuchBucket and
+// *types.TxFailedError. Faults and latency can be injected per method to test the error handling of the callers.
+package clienttest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"google.golang.org/grpc"
+
+	paymentTypes "github.com/evmos/evmos/v12/x/payment/types"
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/client"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// AllMethods is the method name which applies the fault injected by InjectFault to all the methods.
+const AllMethods = "*"
+
+// Option - Configurations for the FakeClient.
+type Option struct {
+	// DefaultAccount is the account the calls act as if no account is set by client.WithAccount.
+	DefaultAccount *types.Account
+	// ManualSeal keeps the uploaded objects in the created status until Seal or RejectSeal is called, so that the
+	// callers waiting for the seal can be tested. By default, the objects are sealed once their payload is put, as
+	// the SPs do.
+	ManualSeal bool
+	// Latency is added to every call, the call returns the error of the ctx if it is done before.
+	Latency time.Duration
+	// Now returns the current time, which decides the expiration of the policies and the group members. time.Now is
+	// used if it is not set.
+	Now func() time.Time
+}
+
+// Fault - A fault injected into the calls of a method.
+type Fault struct {
+	// Err is returned by the call instead of calling the method, the call proceeds if it is nil.
+	Err error
+	// Latency is added to the call before Err is returned or the method is called.
+	Latency time.Duration
+	// Times is the number of the calls the fault applies to, it applies until ClearFaults is called if it is 0.
+	Times int
+}
+
+// FakeClient - The in-memory fake of client.IClient.
+//
+// The methods of buckets, objects, groups, policies, balances, payment accounts and the waits are implemented.
+// BroadcastTx and WaitForBucketMigrationComplete return an error naming the method since the FakeClient neither
+// executes raw messages nor migrates buckets, the other methods of IClient return an error naming the method too, or
+// panic with it if they return no error. The transactions are executed immediately, those which would be rejected by the
+// chain return *types.TxFailedError whether they are sent in asynchronous mode or not.
+type FakeClient struct {
+	// unimplementedClient makes the FakeClient satisfy client.IClient, its methods fail for those not implemented.
+	unimplementedClient
+
+	manualSeal bool
+	latency    time.Duration
+	now        func() time.Time
+
+	mu             sync.Mutex
+	defaultAccount *types.Account
+	faults         map[string][]*Fault
+	calls          map[string]int
+	// changed is closed and replaced when the state changes, the waiters are woken up by it.
+	changed chan struct{}
+	height  int64
+	// sequences hands out the IDs of the resources by kind, starting from 1 as the chain does.
+	sequences map[string]uint64
+
+	buckets         map[string]*bucket
+	bucketsByID     map[uint64]*bucket
+	groups          map[string]*group
+	groupsByID      map[uint64]*group
+	policies        map[string]*permTypes.Policy
+	balances        map[string]sdkmath.Int
+	paymentAccounts map[string]*paymentTypes.PaymentAccount
+	// ownedPaymentAccounts maps the owners to the addresses of their payment accounts in the order of creation.
+	ownedPaymentAccounts map[string][]string
+	streamRecords        map[string]*paymentTypes.StreamRecord
+}
+
+var _ client.IClient = (*FakeClient)(nil)
+
+// New - Create an empty FakeClient.
+//
+// - option: The optional configurations for the FakeClient.
+//
+// - ret: The FakeClient.
+func New(option Option) *FakeClient {
+	f := &FakeClient{
+		manualSeal:           option.ManualSeal,
+		latency:              option.Latency,
+		now:                  option.Now,
+		defaultAccount:       option.DefaultAccount,
+		faults:               make(map[string][]*Fault),
+		calls:                make(map[string]int),
+		changed:              make(chan struct{}),
+		height:               1,
+		sequences:            make(map[string]uint64),
+		buckets:              make(map[string]*bucket),
+		bucketsByID:          make(map[uint64]*bucket),
+		groups:               make(map[string]*group),
+		groupsByID:           make(map[uint64]*group),
+		policies:             make(map[string]*permTypes.Policy),
+		balances:             make(map[string]sdkmath.Int),
+		paymentAccounts:      make(map[string]*paymentTypes.PaymentAccount),
+		ownedPaymentAccounts: make(map[string][]string),
+		streamRecords:        make(map[string]*paymentTypes.StreamRecord),
+	}
+	if f.now == nil {
+		f.now = time.Now
+	}
+	return f
+}
+
+// InjectFault - Inject a fault into the calls of a method.
+//
+// The faults of a method apply in the order they are injected, a fault is removed after it applies Times times.
+//
+// - method: The name of the method of IClient, e.g. "HeadObject", or AllMethods.
+//
+// - fault: The fault to inject.
+func (f *FakeClient) InjectFault(method string, fault Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults[method] = append(f.faults[method], &fault)
+}
+
+// ClearFaults - Remove all the faults injected.
+func (f *FakeClient) ClearFaults() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = make(map[string][]*Fault)
+}
+
+// Calls - Get the number of the calls to a method, including those failed by the injected faults.
+//
+// - method: The name of the method of IClient, e.g. "HeadObject".
+//
+// - ret: The number of the calls.
+func (f *FakeClient) Calls(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+// call counts the call to the method, and applies the latency and the faults injected. The returned error is the
+// injected one, or the error of the ctx if it is done while waiting.
+func (f *FakeClient) call(ctx context.Context, method string) error {
+	f.mu.Lock()
+	f.calls[method]++
+	latency := f.latency
+	var injected error
+	for _, key := range []string{method, AllMethods} {
+		faults := f.faults[key]
+		if len(faults) == 0 {
+			continue
+		}
+		fault := faults[0]
+		latency += fault.Latency
+		injected = fault.Err
+		if fault.Times > 0 {
+			if fault.Times--; fault.Times == 0 {
+				f.faults[key] = faults[1:]
+			}
+		}
+		break
+	}
+	f.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if injected != nil {
+		return injected
+	}
+	return ctx.Err()
+}
+
+// SetDefaultAccount - Set the default account of the FakeClient.
+func (f *FakeClient) SetDefaultAccount(account *types.Account) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.defaultAccount = account
+}
+
+// GetDefaultAccount - Get the default account of the FakeClient.
+func (f *FakeClient) GetDefaultAccount() (*types.Account, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.defaultAccount == nil {
+		return nil, types.ErrorDefaultAccountNotExist
+	}
+	return f.defaultAccount, nil
+}
+
+// MustGetDefaultAccount - Get the default account of the FakeClient, it panics if the default account is not set.
+func (f *FakeClient) MustGetDefaultAccount() *types.Account {
+	account, err := f.GetDefaultAccount()
+	if err != nil {
+		panic("Default account not exist, Use SetDefaultAccount to set ")
+	}
+	return account
+}
+
+// callAccount returns the address of the account the call acts as, the account set by client.WithAccount takes
+// precedence over the default account. f.mu should be held.
+func (f *FakeClient) callAccount(ctx context.Context) (string, error) {
+	if account, ok := client.AccountFromContext(ctx); ok {
+		return account.GetAddress().String(), nil
+	}
+	if f.defaultAccount == nil {
+		return "", types.ErrorDefaultAccountNotExist
+	}
+	return f.defaultAccount.GetAddress().String(), nil
+}
+
+// nextTx returns the hash and the height of a new transaction, each transaction is committed in a block of its own.
+// f.mu should be held.
+func (f *FakeClient) nextTx() (string, int64) {
+	f.height++
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(f.height))
+	hash := sha256.Sum256(buf)
+	return strings.ToUpper(hex.EncodeToString(hash[:])), f.height
+}
+
+// nextID returns the next ID of the kind of the resources. f.mu should be held.
+func (f *FakeClient) nextID(kind string) sdkmath.Uint {
+	f.sequences[kind]++
+	return sdkmath.NewUint(f.sequences[kind])
+}
+
+// notifyLocked wakes up the waiters after the state changes. f.mu should be held.
+func (f *FakeClient) notifyLocked() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// waitUntil waits until check reports done, check is called with f.mu held whenever the state changes.
+func (f *FakeClient) waitUntil(ctx context.Context, check func() (bool, error)) error {
+	for {
+		f.mu.Lock()
+		done, err := check()
+		changed := f.changed
+		f.mu.Unlock()
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// WaitForObjectSealed - Wait until the object is sealed, see client.IWaitClient.
+func (f *FakeClient) WaitForObjectSealed(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error) {
+	if err := f.call(ctx, "WaitForObjectSealed"); err != nil {
+		return nil, err
+	}
+	var detail *types.ObjectDetail
+	err := f.waitUntil(ctx, func() (bool, error) {
+		o, err := f.getObject(bucketName, objectName)
+		if err != nil {
+			return false, &types.RejectedError{Op: "seal object", Reason: "the object does not exist, it may have been rejected by the SP", Err: err}
+		}
+		if o.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+			return false, nil
+		}
+		detail = o.detail()
+		return true, nil
+	})
+	return detail, err
+}
+
+// WaitForObjectDeleted - Wait until the object is deleted, see client.IWaitClient.
+func (f *FakeClient) WaitForObjectDeleted(ctx context.Context, bucketName, objectName string) error {
+	if err := f.call(ctx, "WaitForObjectDeleted"); err != nil {
+		return err
+	}
+	return f.waitUntil(ctx, func() (bool, error) {
+		_, err := f.getObject(bucketName, objectName)
+		return err != nil, nil
+	})
+}
+
+// WaitForObjectUpdateCompleted - Wait until the object is sealed and not updating, see client.IWaitClient.
+//
+// The FakeClient does not update the content of the objects, so it waits only for the seal of the object.
+func (f *FakeClient) WaitForObjectUpdateCompleted(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error) {
+	if err := f.call(ctx, "WaitForObjectUpdateCompleted"); err != nil {
+		return nil, err
+	}
+	var detail *types.ObjectDetail
+	err := f.waitUntil(ctx, func() (bool, error) {
+		o, err := f.getObject(bucketName, objectName)
+		if err != nil {
+			return false, &types.RejectedError{Op: "update object content", Reason: "the object has been deleted", Err: err}
+		}
+		if o.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED || o.info.IsUpdating {
+			return false, nil
+		}
+		detail = o.detail()
+		return true, nil
+	})
+	return detail, err
+}
+
+// WaitForBucketMigrationComplete - It is not supported since the FakeClient does not migrate the buckets, an error
+// is returned instead of waiting forever.
+func (f *FakeClient) WaitForBucketMigrationComplete(ctx context.Context, bucketName string, fromHeight int64) (*storageTypes.BucketInfo, error) {
+	if err := f.call(ctx, "WaitForBucketMigrationComplete"); err != nil {
+		return nil, err
+	}
+	return nil, unsupported("WaitForBucketMigrationComplete", "the buckets are never migrated")
+}
+
+// BroadcastTx - It is not supported since the FakeClient does not execute the messages, the methods building the
+// transactions should be called instead.
+func (f *FakeClient) BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
+	if err := f.call(ctx, "BroadcastTx"); err != nil {
+		return nil, err
+	}
+	return nil, unsupported("BroadcastTx", "the messages are not executed")
+}
@@ -0,0 +1,173 @@
+package clienttest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zkMeLabs/mechain-go-sdk/client"
+	"github.com/zkMeLabs/mechain-go-sdk/pkg/utils"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// newTestAccount returns a new account.
+func newTestAccount(t *testing.T) *types.Account {
+	account, _, err := types.NewAccount("test")
+	require.NoError(t, err)
+	return account
+}
+
+// newTestBucket returns a FakeClient with a bucket owned by its default account.
+func newTestBucket(t *testing.T, option Option) (*FakeClient, string) {
+	owner := newTestAccount(t)
+	option.DefaultAccount = owner
+	f := New(option)
+	_, err := f.CreateBucket(context.Background(), "bucket", owner.GetAddress().String(), types.CreateBucketOptions{})
+	require.NoError(t, err)
+	return f, "bucket"
+}
+
+func TestFaultPerMethod(t *testing.T) {
+	f, bucketName := newTestBucket(t, Option{})
+	ctx := context.Background()
+	injected := errors.New("injected")
+	f.InjectFault("CreateObjectWithResult", Fault{Err: injected, Times: 1})
+
+	_, err := f.CreateObject(ctx, bucketName, "a", bytes.NewReader([]byte("a")), types.CreateObjectOptions{})
+	require.NoError(t, err, "the fault of CreateObjectWithResult does not apply to CreateObject")
+	_, err = f.CreateObjectWithResult(ctx, bucketName, "b", bytes.NewReader([]byte("b")), types.CreateObjectOptions{})
+	require.ErrorIs(t, err, injected)
+	_, err = f.CreateObjectWithResult(ctx, bucketName, "b", bytes.NewReader([]byte("b")), types.CreateObjectOptions{})
+	require.NoError(t, err, "the fault applies only once")
+
+	require.Equal(t, 1, f.Calls("CreateObject"))
+	require.Equal(t, 2, f.Calls("CreateObjectWithResult"))
+
+	f.InjectFault(AllMethods, Fault{Err: injected})
+	_, err = f.HeadBucket(ctx, bucketName)
+	require.ErrorIs(t, err, injected)
+	f.ClearFaults()
+	_, err = f.HeadBucket(ctx, bucketName)
+	require.NoError(t, err)
+}
+
+func TestSealLifecycle(t *testing.T) {
+	f, bucketName := newTestBucket(t, Option{ManualSeal: true})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	payload := []byte("payload")
+
+	for _, objectName := range []string{"sealed", "rejected"} {
+		_, err := f.CreateObject(ctx, bucketName, objectName, bytes.NewReader(payload), types.CreateObjectOptions{})
+		require.NoError(t, err)
+		require.Error(t, f.Seal(bucketName, objectName), "the payload has not been put")
+		require.NoError(t, f.PutObject(ctx, bucketName, objectName, int64(len(payload)), bytes.NewReader(payload), types.PutObjectOptions{}))
+	}
+
+	sealed := make(chan error, 1)
+	go func() {
+		detail, err := f.WaitForObjectSealed(ctx, bucketName, "sealed")
+		if err == nil && detail.ObjectInfo.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+			err = errors.New("the object is not sealed")
+		}
+		sealed <- err
+	}()
+	rejected := make(chan error, 1)
+	go func() {
+		_, err := f.WaitForObjectSealed(ctx, bucketName, "rejected")
+		rejected <- err
+	}()
+
+	require.NoError(t, f.Seal(bucketName, "sealed"))
+	require.NoError(t, <-sealed)
+	require.NoError(t, f.RejectSeal(bucketName, "rejected"))
+	require.ErrorIs(t, <-rejected, types.ErrRejected)
+
+	detail, err := f.WaitForObjectUpdateCompleted(ctx, bucketName, "sealed")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.OBJECT_STATUS_SEALED, detail.ObjectInfo.ObjectStatus)
+	_, err = f.HeadObject(ctx, bucketName, "rejected")
+	require.ErrorIs(t, err, types.ErrNoSuchObject)
+}
+
+func TestBucketPermission(t *testing.T) {
+	f, bucketName := newTestBucket(t, Option{})
+	ctx := context.Background()
+	user := newTestAccount(t)
+	userAddr := user.GetAddress().String()
+
+	effect, err := f.IsBucketPermissionAllowed(ctx, userAddr, bucketName, permTypes.ACTION_CREATE_OBJECT)
+	require.NoError(t, err)
+	require.Equal(t, permTypes.EFFECT_DENY, effect)
+	_, err = f.CreateObject(client.WithAccount(ctx, user), bucketName, "object", bytes.NewReader([]byte("a")), types.CreateObjectOptions{})
+	require.ErrorIs(t, err, types.ErrAccessDenied)
+
+	principal, err := utils.NewPrincipalWithAccount(user.GetAddress())
+	require.NoError(t, err)
+	statement := utils.NewStatement([]permTypes.ActionType{permTypes.ACTION_CREATE_OBJECT}, permTypes.EFFECT_ALLOW, nil, types.NewStatementOptions{})
+	_, err = f.PutBucketPolicy(ctx, bucketName, principal, []*permTypes.Statement{&statement}, types.PutPolicyOption{})
+	require.NoError(t, err)
+
+	effect, err = f.IsBucketPermissionAllowed(ctx, userAddr, bucketName, permTypes.ACTION_CREATE_OBJECT)
+	require.NoError(t, err)
+	require.Equal(t, permTypes.EFFECT_ALLOW, effect)
+	effect, err = f.IsBucketPermissionAllowed(ctx, userAddr, bucketName, permTypes.ACTION_DELETE_BUCKET)
+	require.NoError(t, err)
+	require.Equal(t, permTypes.EFFECT_DENY, effect, "only the actions of the statements are allowed")
+	_, err = f.CreateObject(client.WithAccount(ctx, user), bucketName, "object", bytes.NewReader([]byte("a")), types.CreateObjectOptions{})
+	require.NoError(t, err)
+}
+
+func TestListGroups(t *testing.T) {
+	owner := newTestAccount(t)
+	f := New(Option{DefaultAccount: owner})
+	ctx := context.Background()
+	for _, groupName := range []string{"team-a", "team-b", "other"} {
+		_, err := f.CreateGroup(ctx, groupName, types.CreateGroupOptions{})
+		require.NoError(t, err)
+	}
+	members := []string{newTestAccount(t).GetAddress().String(), newTestAccount(t).GetAddress().String()}
+	_, err := f.UpdateGroupMember(ctx, "team-a", owner.GetAddress().String(), members, nil, types.UpdateGroupMemberOption{})
+	require.NoError(t, err)
+
+	groups, err := f.ListGroup(ctx, "team", "team", types.ListGroupsOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), groups.Count)
+	require.Equal(t, "team-a", groups.Groups[0].Group.GroupName)
+	require.Equal(t, int64(2), groups.Groups[0].NumberOfMembers)
+	groups, err = f.ListGroup(ctx, "team", "team", types.ListGroupsOptions{Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, groups.Groups, 1)
+	require.Equal(t, "team-b", groups.Groups[0].Group.GroupName)
+
+	info, err := f.HeadGroup(ctx, "team-a", owner.GetAddress().String())
+	require.NoError(t, err)
+	first, err := f.ListGroupMembers(ctx, int64(info.Id.Uint64()), types.GroupMembersPaginationOptions{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, first.Groups, 1)
+	rest, err := f.ListGroupMembers(ctx, int64(info.Id.Uint64()), types.GroupMembersPaginationOptions{StartAfter: first.Groups[0].AccountID})
+	require.NoError(t, err)
+	require.Len(t, rest.Groups, 1)
+	require.ElementsMatch(t, members, []string{first.Groups[0].AccountID, rest.Groups[0].AccountID})
+}
+
+func TestUnsupported(t *testing.T) {
+	f := New(Option{})
+	_, err := f.BroadcastTx(context.Background(), nil, nil)
+	require.ErrorContains(t, err, "BroadcastTx")
+	_, err = f.WaitForBucketMigrationComplete(context.Background(), "bucket", 0)
+	require.ErrorContains(t, err, "WaitForBucketMigrationComplete")
+
+	// the methods not implemented fail naming the method instead of dereferencing a nil pointer
+	_, err = f.GetStatus(context.Background())
+	require.ErrorContains(t, err, "FakeClient.GetStatus is not supported")
+	_, err = f.GetChannelSendSequence(context.Background(), 1, 0)
+	require.ErrorContains(t, err, "FakeClient.GetChannelSendSequence is not supported")
+	require.PanicsWithError(t, unimplemented("EnableTrace").Error(), func() { f.EnableTrace(nil, false) })
+}
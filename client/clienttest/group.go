@@ -0,0 +1,363 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evmos/evmos/v12/types/resource"
+	"github.com/evmos/evmos/v12/types/s3util"
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// group is a group and its members.
+type group struct {
+	info *storageTypes.GroupInfo
+	// members maps the addresses of the members to their expiration time.
+	members map[string]time.Time
+}
+
+// isMember reports whether the account is a member of the group which has not expired.
+func (g *group) isMember(addr string, now time.Time) bool {
+	expiration, ok := g.members[addr]
+	return ok && expiration.After(now)
+}
+
+// groupKey returns the key of the group, the groups are identified by their owners and names.
+func groupKey(owner, groupName string) string {
+	return owner + "/" + groupName
+}
+
+// txGroup returns the group a transaction acts on. f.mu should be held.
+func (f *FakeClient) txGroup(owner, groupName string) (*group, error) {
+	g := f.groups[groupKey(owner, groupName)]
+	if g == nil {
+		txHash, _ := f.nextTx()
+		return nil, txFailed("tx", txHash, storageTypes.ErrNoSuchGroup, "group owner: %s, group name: %s", owner, groupName)
+	}
+	return g, nil
+}
+
+// queryGroup returns the group a query is for. f.mu should be held.
+func (f *FakeClient) queryGroup(owner, groupName string) (*group, error) {
+	g := f.groups[groupKey(owner, groupName)]
+	if g == nil {
+		return nil, queryFailed(storageTypes.ErrNoSuchGroup, "group owner: %s, group name: %s", owner, groupName)
+	}
+	return g, nil
+}
+
+// CreateGroup - Create a group owned by the caller, see client.IGroupClient.
+func (f *FakeClient) CreateGroup(ctx context.Context, groupName string, opt types.CreateGroupOptions) (string, error) {
+	if err := f.call(ctx, "CreateGroup"); err != nil {
+		return "", err
+	}
+	result, err := f.createGroup(ctx, groupName, opt)
+	if result == nil {
+		return "", err
+	}
+	return result.TxHash, err
+}
+
+// CreateGroupWithResult - Create a group owned by the caller and return the created group, see client.IGroupClient.
+func (f *FakeClient) CreateGroupWithResult(ctx context.Context, groupName string, opt types.CreateGroupOptions) (*types.CreateGroupResult, error) {
+	if err := f.call(ctx, "CreateGroupWithResult"); err != nil {
+		return nil, err
+	}
+	return f.createGroup(ctx, groupName, opt)
+}
+
+// createGroup creates a group owned by the caller.
+func (f *FakeClient) createGroup(ctx context.Context, groupName string, opt types.CreateGroupOptions) (*types.CreateGroupResult, error) {
+	if err := s3util.CheckValidGroupName(groupName); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	owner, err := f.callAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	txHash, _ := f.nextTx()
+	key := groupKey(owner, groupName)
+	if f.groups[key] != nil {
+		return nil, txFailed("tx", txHash, storageTypes.ErrGroupAlreadyExists, "group name: %s", groupName)
+	}
+
+	info := &storageTypes.GroupInfo{
+		Owner:     owner,
+		GroupName: groupName,
+		Id:        f.nextID("group"),
+		Extra:     opt.Extra,
+		Tags:      opt.Tags,
+	}
+	g := &group{info: info, members: make(map[string]time.Time)}
+	f.groups[key] = g
+	f.groupsByID[info.Id.Uint64()] = g
+	f.notifyLocked()
+	return &types.CreateGroupResult{
+		TxHash: txHash,
+		Event: &storageTypes.EventCreateGroup{
+			Owner:     info.Owner,
+			GroupName: info.GroupName,
+			GroupId:   info.Id,
+			Extra:     info.Extra,
+		},
+	}, nil
+}
+
+// DeleteGroup - Delete the group owned by the caller, see client.IGroupClient.
+func (f *FakeClient) DeleteGroup(ctx context.Context, groupName string, opt types.DeleteGroupOption) (string, error) {
+	if err := f.call(ctx, "DeleteGroup"); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	owner, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	g, err := f.txGroup(owner, groupName)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	delete(f.groups, groupKey(owner, groupName))
+	delete(f.groupsByID, g.info.Id.Uint64())
+	f.deletePolicies(resource.RESOURCE_TYPE_GROUP, g.info.Id)
+	// the policies granted to the group are deleted with it
+	principal := groupPrincipal(g.info.Id.Uint64())
+	for key, policy := range f.policies {
+		if policy.Principal.Type == principal.Type && policy.Principal.Value == principal.Value {
+			delete(f.policies, key)
+		}
+	}
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// UpdateGroupMember - Add or remove the members of the group, see client.IGroupClient.
+//
+// The caller should be the owner of the group or be granted ACTION_UPDATE_GROUP_MEMBER by the group policy.
+func (f *FakeClient) UpdateGroupMember(ctx context.Context, groupName string, groupOwnerAddr string,
+	addAddresses, removeAddresses []string, opts types.UpdateGroupMemberOption,
+) (string, error) {
+	if err := f.call(ctx, "UpdateGroupMember"); err != nil {
+		return "", err
+	}
+	groupOwner, err := parseAddress(groupOwnerAddr)
+	if err != nil {
+		return "", err
+	}
+	if groupName == "" {
+		return "", errors.New("group name is empty")
+	}
+	if len(addAddresses) == 0 && len(removeAddresses) == 0 {
+		return "", errors.New("no update member")
+	}
+	if opts.ExpirationTime != nil && len(addAddresses) != len(opts.ExpirationTime) {
+		return "", errors.New("please provide expirationTime for every new add member")
+	}
+	add := make(map[string]time.Time, len(addAddresses))
+	for i, addr := range addAddresses {
+		member, err := parseAddress(addr)
+		if err != nil {
+			return "", err
+		}
+		add[member] = storageTypes.MaxTimeStamp
+		if opts.ExpirationTime != nil && opts.ExpirationTime[i] != nil {
+			add[member] = *opts.ExpirationTime[i]
+		}
+	}
+	remove := make([]string, 0, len(removeAddresses))
+	for _, addr := range removeAddresses {
+		member, err := parseAddress(addr)
+		if err != nil {
+			return "", err
+		}
+		remove = append(remove, member)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	g, err := f.txGroup(groupOwner, groupName)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if f.groupEffect(g, operator, permTypes.ACTION_UPDATE_GROUP_MEMBER) != permTypes.EFFECT_ALLOW {
+		return "", txFailed("tx", txHash, storageTypes.ErrAccessDenied, "operator: %s, group name: %s", operator, groupName)
+	}
+	for member, expiration := range add {
+		g.members[member] = expiration
+	}
+	for _, member := range remove {
+		delete(g.members, member)
+	}
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// LeaveGroup - Leave the group as a member, see client.IGroupClient.
+func (f *FakeClient) LeaveGroup(ctx context.Context, groupName string, groupOwnerAddr string, opt types.LeaveGroupOption) (string, error) {
+	if err := f.call(ctx, "LeaveGroup"); err != nil {
+		return "", err
+	}
+	groupOwner, err := parseAddress(groupOwnerAddr)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	member, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	g, err := f.txGroup(groupOwner, groupName)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if _, ok := g.members[member]; !ok {
+		return "", txFailed("tx", txHash, storageTypes.ErrNoSuchGroupMember, "member: %s", member)
+	}
+	delete(g.members, member)
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// HeadGroup - Query the group info, see client.IGroupClient.
+func (f *FakeClient) HeadGroup(ctx context.Context, groupName string, groupOwnerAddr string) (*storageTypes.GroupInfo, error) {
+	if err := f.call(ctx, "HeadGroup"); err != nil {
+		return nil, err
+	}
+	groupOwner, err := parseAddress(groupOwnerAddr)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, err := f.queryGroup(groupOwner, groupName)
+	if err != nil {
+		return nil, err
+	}
+	info := *g.info
+	return &info, nil
+}
+
+// HeadGroupMember - Check whether the account is a member of the group which has not expired, see
+// client.IGroupClient.
+func (f *FakeClient) HeadGroupMember(ctx context.Context, groupName string, groupOwnerAddr, headMemberAddr string) bool {
+	if err := f.call(ctx, "HeadGroupMember"); err != nil {
+		return false
+	}
+	groupOwner, err := parseAddress(groupOwnerAddr)
+	if err != nil {
+		return false
+	}
+	member, err := parseAddress(headMemberAddr)
+	if err != nil {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g := f.groups[groupKey(groupOwner, groupName)]
+	return g != nil && g.isMember(member, f.now())
+}
+
+// listGroupsLimit returns the limit of a list of groups or group members, it defaults to 50 and is at most 1000 as
+// the SPs do.
+func listGroupsLimit(limit int64) int {
+	switch {
+	case limit <= 0:
+		return 50
+	case limit > 1000:
+		return 1000
+	}
+	return int(limit)
+}
+
+// ListGroup - List the groups whose names start with the prefix and contain the name, see client.IGroupClient.
+//
+// The groups are listed in the order of their IDs, nothing is listed if the name or the prefix is empty.
+func (f *FakeClient) ListGroup(ctx context.Context, name, prefix string, opts types.ListGroupsOptions) (types.ListGroupsResult, error) {
+	if err := f.call(ctx, "ListGroup"); err != nil {
+		return types.ListGroupsResult{}, err
+	}
+	if name == "" || prefix == "" || opts.Limit < 0 || opts.Offset < 0 {
+		return types.ListGroupsResult{}, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	matched := make([]*group, 0)
+	for _, g := range f.groups {
+		if strings.HasPrefix(g.info.GroupName, prefix) && strings.Contains(g.info.GroupName, name) {
+			matched = append(matched, g)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].info.Id.LT(matched[j].info.Id)
+	})
+
+	result := types.ListGroupsResult{Groups: make([]*types.GroupMeta, 0), Count: int64(len(matched))}
+	if opts.Offset >= int64(len(matched)) {
+		return result, nil
+	}
+	matched = matched[opts.Offset:]
+	if limit := listGroupsLimit(opts.Limit); len(matched) > limit {
+		matched = matched[:limit]
+	}
+	for _, g := range matched {
+		info := *g.info
+		result.Groups = append(result.Groups, &types.GroupMeta{
+			Group:           &info,
+			NumberOfMembers: int64(len(g.members)),
+			Operator:        info.Owner,
+		})
+	}
+	return result, nil
+}
+
+// ListGroupMembers - List the members of the group in the order of their addresses, including those expired, see
+// client.IGroupClient.
+func (f *FakeClient) ListGroupMembers(ctx context.Context, groupID int64, opts types.GroupMembersPaginationOptions) (*types.GroupMembersResult, error) {
+	if err := f.call(ctx, "ListGroupMembers"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := &types.GroupMembersResult{Groups: make([]*types.GroupMembers, 0)}
+	g := f.groupsByID[uint64(groupID)]
+	if g == nil {
+		return result, nil
+	}
+	members := make([]string, 0, len(g.members))
+	for member := range g.members {
+		if member > opts.StartAfter {
+			members = append(members, member)
+		}
+	}
+	sort.Strings(members)
+	if limit := listGroupsLimit(opts.Limit); len(members) > limit {
+		members = members[:limit]
+	}
+	for _, member := range members {
+		info := *g.info
+		result.Groups = append(result.Groups, &types.GroupMembers{
+			Group:          &info,
+			Operator:       info.Owner,
+			AccountID:      member,
+			ExpirationTime: strconv.FormatInt(g.members[member].Unix(), 10),
+		})
+	}
+	return result, nil
+}
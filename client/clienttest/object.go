@@ -0,0 +1,577 @@
+package clienttest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	sdkmath "cosmossdk.io/math"
+
+	gnfdTypes "github.com/evmos/evmos/v12/types"
+	"github.com/evmos/evmos/v12/types/resource"
+	"github.com/evmos/evmos/v12/types/s3util"
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/pkg/utils"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// listObjectsMaxKeys is the max number of the objects returned by ListObjects.
+const listObjectsMaxKeys = 1000
+
+// object is an object and its payload.
+type object struct {
+	info *storageTypes.ObjectInfo
+	// digest is the hash of the payload the object is created with, the payload put to the SP should match it.
+	digest [sha256.Size]byte
+	// payload is nil until the payload is put.
+	payload []byte
+}
+
+// detail returns a copy of the object detail.
+func (o *object) detail() *types.ObjectDetail {
+	info := *o.info
+	return &types.ObjectDetail{ObjectInfo: &info}
+}
+
+// getObject returns the object, the error does not mimic the Client. f.mu should be held.
+func (f *FakeClient) getObject(bucketName, objectName string) (*object, error) {
+	b := f.buckets[bucketName]
+	if b == nil || b.objects[objectName] == nil {
+		return nil, types.ErrNoSuchObject
+	}
+	return b.objects[objectName], nil
+}
+
+// txObject returns the object a transaction acts on. f.mu should be held.
+func (f *FakeClient) txObject(bucketName, objectName string) (*bucket, *object, error) {
+	b, err := f.txBucket(bucketName)
+	if err != nil {
+		return nil, nil, err
+	}
+	o := b.objects[objectName]
+	if o == nil {
+		txHash, _ := f.nextTx()
+		return nil, nil, txFailed("tx", txHash, storageTypes.ErrNoSuchObject, "bucket name: %s, object name: %s", bucketName, objectName)
+	}
+	return b, o, nil
+}
+
+// queryObject returns the object a query is for. f.mu should be held.
+func (f *FakeClient) queryObject(bucketName, objectName string) (*bucket, *object, error) {
+	b, err := f.queryBucket(bucketName)
+	if err != nil {
+		return nil, nil, err
+	}
+	o := b.objects[objectName]
+	if o == nil {
+		return nil, nil, queryFailed(storageTypes.ErrNoSuchObject, "bucket name: %s, object name: %s", bucketName, objectName)
+	}
+	return b, o, nil
+}
+
+// spObject returns the object an SP request is for. f.mu should be held.
+func (f *FakeClient) spObject(bucketName, objectName string) (*bucket, *object, error) {
+	b := f.buckets[bucketName]
+	if b == nil {
+		return nil, nil, spNoSuchBucket()
+	}
+	o := b.objects[objectName]
+	if o == nil {
+		return nil, nil, spNoSuchObject()
+	}
+	return b, o, nil
+}
+
+// deleteObjectLocked deletes the object and its policies. f.mu should be held.
+func (f *FakeClient) deleteObjectLocked(b *bucket, o *object) {
+	delete(b.objects, o.info.ObjectName)
+	f.deletePolicies(resource.RESOURCE_TYPE_OBJECT, o.info.Id)
+	f.notifyLocked()
+}
+
+// CreateObject - Create the object on chain, see client.IObjectClient.
+func (f *FakeClient) CreateObject(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (string, error) {
+	if err := f.call(ctx, "CreateObject"); err != nil {
+		return "", err
+	}
+	result, err := f.createObject(ctx, bucketName, objectName, reader, opts)
+	if result == nil {
+		return "", err
+	}
+	return result.TxHash, err
+}
+
+// CreateObjectWithResult - Create the object on chain and return the created object, see client.IObjectClient.
+//
+// The payload is read to compute its size and hash, PutObject should put the same payload. An empty object is
+// sealed once it is created.
+func (f *FakeClient) CreateObjectWithResult(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (*types.CreateObjectResult, error) {
+	if err := f.call(ctx, "CreateObjectWithResult"); err != nil {
+		return nil, err
+	}
+	return f.createObject(ctx, bucketName, objectName, reader, opts)
+}
+
+// createObject creates the object on chain.
+func (f *FakeClient) createObject(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (*types.CreateObjectResult, error) {
+	if reader == nil {
+		return nil, errors.New("fail to compute hash of payload, reader is nil")
+	}
+	if err := s3util.CheckValidBucketName(bucketName); err != nil {
+		return nil, err
+	}
+	if err := s3util.CheckValidObjectName(objectName); err != nil {
+		return nil, err
+	}
+	payload, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = types.ContentDefault
+	}
+	visibility := opts.Visibility
+	if visibility == storageTypes.VISIBILITY_TYPE_UNSPECIFIED {
+		visibility = storageTypes.VISIBILITY_TYPE_INHERIT
+	}
+	redundancyType := storageTypes.REDUNDANCY_EC_TYPE
+	if opts.IsReplicaType {
+		redundancyType = storageTypes.REDUNDANCY_REPLICA_TYPE
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b, err := f.txBucket(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	txHash, height := f.nextTx()
+	grn := gnfdTypes.NewObjectGRN(bucketName, objectName).String()
+	if f.bucketEffect(b, operator, permTypes.ACTION_CREATE_OBJECT, grn) != permTypes.EFFECT_ALLOW {
+		return nil, txFailed("createObject txn", txHash, storageTypes.ErrAccessDenied, "operator: %s, bucket name: %s", operator, bucketName)
+	}
+	if b.objects[objectName] != nil {
+		return nil, txFailed("createObject txn", txHash, storageTypes.ErrObjectAlreadyExists, "object name: %s", objectName)
+	}
+
+	info := &storageTypes.ObjectInfo{
+		// the objects are owned by the owner of the bucket, no matter who creates them
+		Owner:          b.info.Owner,
+		Creator:        operator,
+		BucketName:     bucketName,
+		ObjectName:     objectName,
+		Id:             f.nextID("object"),
+		PayloadSize:    uint64(len(payload)),
+		Visibility:     visibility,
+		ContentType:    contentType,
+		CreateAt:       height,
+		ObjectStatus:   storageTypes.OBJECT_STATUS_CREATED,
+		RedundancyType: redundancyType,
+		Tags:           opts.Tags,
+	}
+	o := &object{info: info, digest: sha256.Sum256(payload)}
+	if len(payload) == 0 {
+		o.info.ObjectStatus, o.payload = storageTypes.OBJECT_STATUS_SEALED, []byte{}
+	}
+	b.objects[objectName] = o
+	f.notifyLocked()
+	return &types.CreateObjectResult{
+		TxHash: txHash,
+		Event: &storageTypes.EventCreateObject{
+			Creator:        info.Creator,
+			Owner:          info.Owner,
+			BucketName:     info.BucketName,
+			ObjectName:     info.ObjectName,
+			BucketId:       b.info.Id,
+			ObjectId:       info.Id,
+			CreateAt:       info.CreateAt,
+			PayloadSize:    info.PayloadSize,
+			Visibility:     info.Visibility,
+			ContentType:    info.ContentType,
+			Status:         info.ObjectStatus,
+			RedundancyType: info.RedundancyType,
+		},
+	}, nil
+}
+
+// CreateFolder - Create an empty object whose name ends with "/", see client.IObjectClient.
+func (f *FakeClient) CreateFolder(ctx context.Context, bucketName, objectName string, opts types.CreateObjectOptions) (string, error) {
+	if !strings.HasSuffix(objectName, "/") {
+		return "", errors.New("failed to create folder. Folder names must end with a forward slash (/) character")
+	}
+	return f.CreateObject(ctx, bucketName, objectName, bytes.NewReader(nil), opts)
+}
+
+// PutObject - Put the payload of the object created on chain, see client.IObjectClient.
+//
+// The object is sealed after the payload is put unless Option.ManualSeal is set.
+func (f *FakeClient) PutObject(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader, opts types.PutObjectOptions) error {
+	if err := f.call(ctx, "PutObject"); err != nil {
+		return err
+	}
+	if objectSize <= 0 {
+		return errors.New("object size should be more than 0")
+	}
+	payload, err := io.ReadAll(io.LimitReader(reader, objectSize))
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return err
+	}
+	b, o, err := f.spObject(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	grn := gnfdTypes.NewObjectGRN(bucketName, objectName).String()
+	if o.info.Creator != operator && f.bucketEffect(b, operator, permTypes.ACTION_CREATE_OBJECT, grn) != permTypes.EFFECT_ALLOW {
+		return spAccessDenied()
+	}
+	if o.info.ObjectStatus != storageTypes.OBJECT_STATUS_CREATED {
+		return spFailed(http.StatusBadRequest, "InvalidObjectState", "the object has been sealed")
+	}
+	if uint64(len(payload)) != o.info.PayloadSize || sha256.Sum256(payload) != o.digest {
+		return spFailed(http.StatusBadRequest, "InvalidPayload", "the payload does not match the integrity hash of the object")
+	}
+	o.payload = payload
+	if !f.manualSeal {
+		o.info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	}
+	f.notifyLocked()
+	return nil
+}
+
+// FPutObject - Put the payload of the object from a file, see client.IObjectClient.
+func (f *FakeClient) FPutObject(ctx context.Context, bucketName, objectName, filePath string, opts types.PutObjectOptions) error {
+	fReader, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer fReader.Close()
+	stat, err := fReader.Stat()
+	if err != nil {
+		return err
+	}
+	return f.PutObject(ctx, bucketName, objectName, stat.Size(), fReader, opts)
+}
+
+// Seal - Seal the object whose payload has been put, as the primary SP does. It is used with Option.ManualSeal.
+//
+// - bucketName: The name of the bucket.
+//
+// - objectName: The name of the object.
+//
+// - ret: Return error if the object does not exist, it has been sealed, or its payload has not been put.
+func (f *FakeClient) Seal(bucketName, objectName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	o, err := f.getObject(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	if o.info.ObjectStatus != storageTypes.OBJECT_STATUS_CREATED || o.payload == nil {
+		return fmt.Errorf("the object %s can not be sealed in status %s", objectName, o.info.ObjectStatus)
+	}
+	o.info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	f.notifyLocked()
+	return nil
+}
+
+// RejectSeal - Reject the seal of the object, as the primary SP does when it fails to store the payload. The object is
+// deleted, as the chain does.
+//
+// - bucketName: The name of the bucket.
+//
+// - objectName: The name of the object.
+//
+// - ret: Return error if the object does not exist or it has been sealed.
+func (f *FakeClient) RejectSeal(bucketName, objectName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	o, err := f.getObject(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	if o.info.ObjectStatus != storageTypes.OBJECT_STATUS_CREATED {
+		return fmt.Errorf("the seal of the object %s can not be rejected in status %s", objectName, o.info.ObjectStatus)
+	}
+	f.deleteObjectLocked(f.buckets[bucketName], o)
+	return nil
+}
+
+// CancelCreateObject - Cancel the creation of the object which has not been sealed, see client.IObjectClient.
+func (f *FakeClient) CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (string, error) {
+	if err := f.call(ctx, "CancelCreateObject"); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, o, err := f.txObject(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if o.info.Creator != operator && o.info.Owner != operator {
+		return "", txFailed("tx", txHash, storageTypes.ErrAccessDenied, "operator: %s, object name: %s", operator, objectName)
+	}
+	if o.info.ObjectStatus != storageTypes.OBJECT_STATUS_CREATED {
+		return "", txFailed("tx", txHash, storageTypes.ErrObjectNotCreated, "object name: %s", objectName)
+	}
+	f.deleteObjectLocked(b, o)
+	return txHash, nil
+}
+
+// DeleteObject - Delete the sealed object, see client.IObjectClient.
+func (f *FakeClient) DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (string, error) {
+	if err := f.call(ctx, "DeleteObject"); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, o, err := f.txObject(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if f.objectEffect(b, o, operator, permTypes.ACTION_DELETE_OBJECT) != permTypes.EFFECT_ALLOW {
+		return "", txFailed("tx", txHash, storageTypes.ErrAccessDenied, "operator: %s, object name: %s", operator, objectName)
+	}
+	if o.info.ObjectStatus == storageTypes.OBJECT_STATUS_CREATED {
+		return "", txFailed("tx", txHash, storageTypes.ErrObjectNotSealed, "object name: %s", objectName)
+	}
+	f.deleteObjectLocked(b, o)
+	return txHash, nil
+}
+
+// UpdateObjectVisibility - Update the visibility of the object, see client.IObjectClient.
+func (f *FakeClient) UpdateObjectVisibility(ctx context.Context, bucketName, objectName string, visibility storageTypes.VisibilityType, opt types.UpdateObjectOption) (string, error) {
+	if err := f.call(ctx, "UpdateObjectVisibility"); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, o, err := f.txObject(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	txHash, _ := f.nextTx()
+	if f.objectEffect(b, o, operator, permTypes.ACTION_UPDATE_OBJECT_INFO) != permTypes.EFFECT_ALLOW {
+		return "", txFailed("tx", txHash, storageTypes.ErrAccessDenied, "operator: %s, object name: %s", operator, objectName)
+	}
+	o.info.Visibility = visibility
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// HeadObject - Query the object info, see client.IObjectClient.
+func (f *FakeClient) HeadObject(ctx context.Context, bucketName, objectName string) (*types.ObjectDetail, error) {
+	if err := f.call(ctx, "HeadObject"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, o, err := f.queryObject(bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	return o.detail(), nil
+}
+
+// HeadObjectByID - Query the object info by the object ID, see client.IObjectClient.
+func (f *FakeClient) HeadObjectByID(ctx context.Context, objID string) (*types.ObjectDetail, error) {
+	if err := f.call(ctx, "HeadObjectByID"); err != nil {
+		return nil, err
+	}
+	id, err := sdkmath.ParseUint(objID)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, b := range f.buckets {
+		for _, o := range b.objects {
+			if o.info.Id.Equal(id) {
+				return o.detail(), nil
+			}
+		}
+	}
+	return nil, queryFailed(storageTypes.ErrNoSuchObject, "object id: %s", objID)
+}
+
+// GetObject - Download the payload of the sealed object, see client.IObjectClient.
+func (f *FakeClient) GetObject(ctx context.Context, bucketName, objectName string, opts types.GetObjectOptions) (io.ReadCloser, types.ObjectStat, error) {
+	if err := f.call(ctx, "GetObject"); err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+	b, o, err := f.spObject(bucketName, objectName)
+	if err != nil {
+		return nil, types.ObjectStat{}, err
+	}
+	if f.objectEffect(b, o, operator, permTypes.ACTION_GET_OBJECT) != permTypes.EFFECT_ALLOW {
+		return nil, types.ObjectStat{}, spAccessDenied()
+	}
+	if o.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		return nil, types.ObjectStat{}, spFailed(http.StatusBadRequest, "InvalidObjectState", "the object is not sealed")
+	}
+
+	payload := o.payload
+	if opts.Range != "" {
+		isRange, start, end := utils.ParseRange(opts.Range)
+		size := int64(len(payload))
+		if end < 0 || end >= size {
+			end = size - 1
+		}
+		if !isRange || start < 0 || start > end {
+			return nil, types.ObjectStat{}, spFailed(http.StatusRequestedRangeNotSatisfiable, "InvalidRange", "invalid range "+opts.Range)
+		}
+		payload = payload[start : end+1]
+	}
+	return io.NopCloser(bytes.NewReader(payload)), types.ObjectStat{
+		ObjectName:  objectName,
+		ContentType: o.info.ContentType,
+		Size:        int64(len(payload)),
+	}, nil
+}
+
+// FGetObject - Download the payload of the sealed object to a file, see client.IObjectClient.
+func (f *FakeClient) FGetObject(ctx context.Context, bucketName, objectName, filePath string, opts types.GetObjectOptions) error {
+	if _, err := os.Stat(filePath); err == nil {
+		return errors.New("download file already exist")
+	}
+	body, _, err := f.GetObject(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	fd, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY, 0o660)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	_, err = io.Copy(fd, body)
+	return err
+}
+
+// ListObjects - List the objects in the bucket, see client.IObjectClient.
+//
+// The objects are listed in the order of their names, the continuation token is the base64-encoded name of the last
+// object listed.
+func (f *FakeClient) ListObjects(ctx context.Context, bucketName string, opts types.ListObjectsOptions) (types.ListObjectsResult, error) {
+	if err := f.call(ctx, "ListObjects"); err != nil {
+		return types.ListObjectsResult{}, err
+	}
+	if opts.Delimiter != "" && opts.Delimiter != "/" {
+		return types.ListObjectsResult{}, errors.New("not supported delimiter")
+	}
+	maxKeys := opts.MaxKeys
+	if maxKeys == 0 || maxKeys > listObjectsMaxKeys {
+		maxKeys = listObjectsMaxKeys
+	}
+	startAfter := opts.StartAfter
+	if opts.ContinuationToken != "" {
+		token, err := base64.StdEncoding.DecodeString(opts.ContinuationToken)
+		if err != nil {
+			return types.ListObjectsResult{}, err
+		}
+		startAfter = string(token)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b := f.buckets[bucketName]
+	if b == nil {
+		return types.ListObjectsResult{}, spNoSuchBucket()
+	}
+	names := make([]string, 0, len(b.objects))
+	for name := range b.objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := types.ListObjectsResult{
+		Objects:           make([]*types.ObjectMeta, 0),
+		CommonPrefixes:    make([]string, 0),
+		MaxKeys:           strconv.FormatUint(maxKeys, 10),
+		Name:              bucketName,
+		Prefix:            opts.Prefix,
+		Delimiter:         opts.Delimiter,
+		ContinuationToken: opts.ContinuationToken,
+	}
+	var (
+		count uint64
+		last  string
+	)
+	for _, name := range names {
+		if name <= startAfter || !strings.HasPrefix(name, opts.Prefix) {
+			continue
+		}
+		// the listing resumes after a common prefix, the objects under it have been listed as the prefix
+		if opts.Delimiter != "" && len(startAfter) > len(opts.Prefix) && strings.HasSuffix(startAfter, opts.Delimiter) &&
+			strings.HasPrefix(name, startAfter) {
+			continue
+		}
+		commonPrefix := ""
+		if opts.Delimiter != "" {
+			if i := strings.Index(name[len(opts.Prefix):], opts.Delimiter); i >= 0 {
+				commonPrefix = name[:len(opts.Prefix)+i+len(opts.Delimiter)]
+			}
+		}
+		if commonPrefix != "" && commonPrefix == last {
+			continue
+		}
+		if count == maxKeys {
+			result.IsTruncated = true
+			result.NextContinuationToken = base64.StdEncoding.EncodeToString([]byte(last))
+			break
+		}
+		count++
+		if commonPrefix != "" {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix)
+			// the objects under the common prefix are skipped, they sort before any name after it
+			last = commonPrefix
+			continue
+		}
+		info := *b.objects[name].info
+		result.Objects = append(result.Objects, &types.ObjectMeta{ObjectInfo: &info})
+		last = name
+	}
+	result.KeyCount = strconv.FormatUint(count, 10)
+	return result, nil
+}
@@ -0,0 +1,499 @@
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	gnfdTypes "github.com/evmos/evmos/v12/types"
+	"github.com/evmos/evmos/v12/types/resource"
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// publicReadActions is the actions allowed to everyone on the public-read objects.
+var publicReadActions = map[permTypes.ActionType]bool{
+	permTypes.ACTION_GET_OBJECT:     true,
+	permTypes.ACTION_EXECUTE_OBJECT: true,
+}
+
+// policyKey returns the key of the policy of the principal on the resource.
+func policyKey(resourceType resource.ResourceType, resourceID sdkmath.Uint, principal *permTypes.Principal) string {
+	return fmt.Sprintf("%d/%s/%d/%s", resourceType, resourceID.String(), principal.Type, principal.Value)
+}
+
+// parsePrincipal unmarshals the principal made by utils.NewPrincipalWithAccount or utils.NewPrincipalWithGroupId.
+func parsePrincipal(principalStr types.Principal) (*permTypes.Principal, error) {
+	principal := &permTypes.Principal{}
+	if err := principal.Unmarshal([]byte(principalStr)); err != nil {
+		return nil, err
+	}
+	return principal, nil
+}
+
+// putPolicy puts the policy of the principal on the resource owned by owner, it replaces the existing one.
+// f.mu should be held.
+func (f *FakeClient) putPolicy(operator, owner string, resourceType resource.ResourceType, resourceID sdkmath.Uint,
+	principal *permTypes.Principal, statements []*permTypes.Statement, expireTime *time.Time,
+) (string, error) {
+	txHash, _ := f.nextTx()
+	if operator != owner {
+		return "", txFailed("tx", txHash, storageTypes.ErrAccessDenied, "only the owner can put the policy")
+	}
+	if principal.Type == permTypes.PRINCIPAL_TYPE_GNFD_GROUP {
+		id, err := sdkmath.ParseUint(principal.Value)
+		if err != nil || f.groupsByID[id.Uint64()] == nil {
+			return "", txFailed("tx", txHash, storageTypes.ErrNoSuchGroup, "group id: %s", principal.Value)
+		}
+	}
+	policy := &permTypes.Policy{
+		Id:             f.nextID("policy"),
+		Principal:      principal,
+		ResourceType:   resourceType,
+		ResourceId:     resourceID,
+		Statements:     statements,
+		ExpirationTime: expireTime,
+	}
+	f.policies[policyKey(resourceType, resourceID, principal)] = policy
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// deletePolicy deletes the policy of the principal on the resource owned by owner. f.mu should be held.
+func (f *FakeClient) deletePolicy(operator, owner string, resourceType resource.ResourceType, resourceID sdkmath.Uint,
+	principal *permTypes.Principal,
+) (string, error) {
+	txHash, _ := f.nextTx()
+	if operator != owner {
+		return "", txFailed("tx", txHash, storageTypes.ErrAccessDenied, "only the owner can delete the policy")
+	}
+	key := policyKey(resourceType, resourceID, principal)
+	if f.policies[key] == nil {
+		return "", txFailed("tx", txHash, storageTypes.ErrNoSuchPolicy, "principal: %s", principal.Value)
+	}
+	delete(f.policies, key)
+	f.notifyLocked()
+	return txHash, nil
+}
+
+// getPolicy returns the policy of the principal on the resource. f.mu should be held.
+func (f *FakeClient) getPolicy(resourceType resource.ResourceType, resourceID sdkmath.Uint, principal *permTypes.Principal) (*permTypes.Policy, error) {
+	policy := f.policies[policyKey(resourceType, resourceID, principal)]
+	if policy == nil {
+		return nil, queryFailed(storageTypes.ErrNoSuchPolicy, "principal: %s", principal.Value)
+	}
+	p := *policy
+	return &p, nil
+}
+
+// deletePolicies deletes the policies on the resource when it is deleted. f.mu should be held.
+func (f *FakeClient) deletePolicies(resourceType resource.ResourceType, resourceID sdkmath.Uint) {
+	for key, policy := range f.policies {
+		if policy.ResourceType == resourceType && policy.ResourceId.Equal(resourceID) {
+			delete(f.policies, key)
+		}
+	}
+}
+
+// policyEffect evaluates the policies on the resource which apply to the operator, i.e. the policy of the operator
+// and those of the groups it is a member of. A deny statement takes precedence over an allow statement, and
+// EFFECT_UNSPECIFIED is returned if no statement applies.
+//
+// grn is the GRN of the object the action is on, the statements of a bucket policy only apply to the objects
+// matching their resources. It is empty for the actions on the resource itself. f.mu should be held.
+func (f *FakeClient) policyEffect(resourceType resource.ResourceType, resourceID sdkmath.Uint, operator string,
+	action permTypes.ActionType, grn string,
+) permTypes.Effect {
+	now := f.now()
+	effect := permTypes.EFFECT_UNSPECIFIED
+	for _, policy := range f.policies {
+		if policy.ResourceType != resourceType || !policy.ResourceId.Equal(resourceID) || !f.appliesTo(policy.Principal, operator) {
+			continue
+		}
+		if policy.ExpirationTime != nil && policy.ExpirationTime.Before(now) {
+			continue
+		}
+		for _, statement := range policy.Statements {
+			if !statementMatches(statement, action, grn, now) {
+				continue
+			}
+			if statement.Effect == permTypes.EFFECT_DENY {
+				return permTypes.EFFECT_DENY
+			}
+			if statement.Effect == permTypes.EFFECT_ALLOW {
+				effect = permTypes.EFFECT_ALLOW
+			}
+		}
+	}
+	return effect
+}
+
+// appliesTo reports whether the principal is the operator or a group the operator is a member of. f.mu should be
+// held.
+func (f *FakeClient) appliesTo(principal *permTypes.Principal, operator string) bool {
+	switch principal.Type {
+	case permTypes.PRINCIPAL_TYPE_GNFD_ACCOUNT:
+		return principal.Value == operator
+	case permTypes.PRINCIPAL_TYPE_GNFD_GROUP:
+		id, err := sdkmath.ParseUint(principal.Value)
+		if err != nil {
+			return false
+		}
+		g := f.groupsByID[id.Uint64()]
+		return g != nil && g.isMember(operator, f.now())
+	}
+	return false
+}
+
+// statementMatches reports whether the statement applies to the action on the object of grn.
+func statementMatches(statement *permTypes.Statement, action permTypes.ActionType, grn string, now time.Time) bool {
+	if statement.ExpirationTime != nil && statement.ExpirationTime.Before(now) {
+		return false
+	}
+	matched := false
+	for _, a := range statement.Actions {
+		if a == action || a == permTypes.ACTION_TYPE_ALL {
+			matched = true
+			break
+		}
+	}
+	if !matched || grn == "" || len(statement.Resources) == 0 {
+		return matched
+	}
+	for _, pattern := range statement.Resources {
+		if ok, err := regexp.MatchString(pattern, grn); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// bucketEffect evaluates the permission of the operator for the action on the bucket, or on the object of grn in the
+// bucket if grn is not empty. The owner is allowed all the actions. f.mu should be held.
+func (f *FakeClient) bucketEffect(b *bucket, operator string, action permTypes.ActionType, grn string) permTypes.Effect {
+	if b.info.Owner == operator {
+		return permTypes.EFFECT_ALLOW
+	}
+	if effect := f.policyEffect(resource.RESOURCE_TYPE_BUCKET, b.info.Id, operator, action, grn); effect != permTypes.EFFECT_UNSPECIFIED {
+		return effect
+	}
+	return permTypes.EFFECT_DENY
+}
+
+// objectEffect evaluates the permission of the operator for the action on the object. The owner is allowed all the
+// actions, and everyone is allowed to read the public-read objects. A deny statement of the bucket policy takes
+// precedence over the object policy. f.mu should be held.
+func (f *FakeClient) objectEffect(b *bucket, o *object, operator string, action permTypes.ActionType) permTypes.Effect {
+	if o.info.Owner == operator {
+		return permTypes.EFFECT_ALLOW
+	}
+	visibility := o.info.Visibility
+	if visibility == storageTypes.VISIBILITY_TYPE_INHERIT {
+		visibility = b.info.Visibility
+	}
+	if visibility == storageTypes.VISIBILITY_TYPE_PUBLIC_READ && publicReadActions[action] {
+		return permTypes.EFFECT_ALLOW
+	}
+	grn := gnfdTypes.NewObjectGRN(b.info.BucketName, o.info.ObjectName).String()
+	bucketEffect := f.policyEffect(resource.RESOURCE_TYPE_BUCKET, b.info.Id, operator, action, grn)
+	if bucketEffect == permTypes.EFFECT_DENY {
+		return permTypes.EFFECT_DENY
+	}
+	if effect := f.policyEffect(resource.RESOURCE_TYPE_OBJECT, o.info.Id, operator, action, ""); effect != permTypes.EFFECT_UNSPECIFIED {
+		return effect
+	}
+	if bucketEffect == permTypes.EFFECT_ALLOW {
+		return permTypes.EFFECT_ALLOW
+	}
+	return permTypes.EFFECT_DENY
+}
+
+// groupEffect evaluates the permission of the operator for the action on the group. f.mu should be held.
+func (f *FakeClient) groupEffect(g *group, operator string, action permTypes.ActionType) permTypes.Effect {
+	if g.info.Owner == operator {
+		return permTypes.EFFECT_ALLOW
+	}
+	if effect := f.policyEffect(resource.RESOURCE_TYPE_GROUP, g.info.Id, operator, action, ""); effect != permTypes.EFFECT_UNSPECIFIED {
+		return effect
+	}
+	return permTypes.EFFECT_DENY
+}
+
+// PutBucketPolicy - Put the bucket policy of the principal, see client.IBucketClient.
+func (f *FakeClient) PutBucketPolicy(ctx context.Context, bucketName string, principalStr types.Principal,
+	statements []*permTypes.Statement, opt types.PutPolicyOption,
+) (string, error) {
+	if err := f.call(ctx, "PutBucketPolicy"); err != nil {
+		return "", err
+	}
+	principal, err := parsePrincipal(principalStr)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, err := f.txBucket(bucketName)
+	if err != nil {
+		return "", err
+	}
+	return f.putPolicy(operator, b.info.Owner, resource.RESOURCE_TYPE_BUCKET, b.info.Id, principal, statements, opt.PolicyExpireTime)
+}
+
+// DeleteBucketPolicy - Delete the bucket policy of the principal, see client.IBucketClient.
+func (f *FakeClient) DeleteBucketPolicy(ctx context.Context, bucketName string, principalStr types.Principal, opt types.DeletePolicyOption) (string, error) {
+	if err := f.call(ctx, "DeleteBucketPolicy"); err != nil {
+		return "", err
+	}
+	principal, err := parsePrincipal(principalStr)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, err := f.txBucket(bucketName)
+	if err != nil {
+		return "", err
+	}
+	return f.deletePolicy(operator, b.info.Owner, resource.RESOURCE_TYPE_BUCKET, b.info.Id, principal)
+}
+
+// GetBucketPolicy - Get the bucket policy of the account, see client.IBucketClient.
+func (f *FakeClient) GetBucketPolicy(ctx context.Context, bucketName string, principalAddr string) (*permTypes.Policy, error) {
+	if err := f.call(ctx, "GetBucketPolicy"); err != nil {
+		return nil, err
+	}
+	principal, err := accountPrincipal(principalAddr)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := f.queryBucket(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	return f.getPolicy(resource.RESOURCE_TYPE_BUCKET, b.info.Id, principal)
+}
+
+// GetBucketPolicyOfGroup - Get the bucket policy of the group, see client.IGroupClient.
+func (f *FakeClient) GetBucketPolicyOfGroup(ctx context.Context, bucketName string, groupID uint64) (*permTypes.Policy, error) {
+	if err := f.call(ctx, "GetBucketPolicyOfGroup"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := f.queryBucket(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	return f.getPolicy(resource.RESOURCE_TYPE_BUCKET, b.info.Id, groupPrincipal(groupID))
+}
+
+// IsBucketPermissionAllowed - Check if the permission of the bucket is allowed to the user, see client.IBucketClient.
+func (f *FakeClient) IsBucketPermissionAllowed(ctx context.Context, userAddr string, bucketName string, action permTypes.ActionType) (permTypes.Effect, error) {
+	if err := f.call(ctx, "IsBucketPermissionAllowed"); err != nil {
+		return permTypes.EFFECT_DENY, err
+	}
+	operator, err := sdk.AccAddressFromHexUnsafe(userAddr)
+	if err != nil {
+		return permTypes.EFFECT_DENY, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := f.queryBucket(bucketName)
+	if err != nil {
+		return permTypes.EFFECT_DENY, err
+	}
+	return f.bucketEffect(b, operator.String(), action, ""), nil
+}
+
+// PutObjectPolicy - Put the object policy of the principal, see client.IObjectClient.
+func (f *FakeClient) PutObjectPolicy(ctx context.Context, bucketName, objectName string, principalStr types.Principal,
+	statements []*permTypes.Statement, opt types.PutPolicyOption,
+) (string, error) {
+	if err := f.call(ctx, "PutObjectPolicy"); err != nil {
+		return "", err
+	}
+	principal, err := parsePrincipal(principalStr)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	_, o, err := f.txObject(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	return f.putPolicy(operator, o.info.Owner, resource.RESOURCE_TYPE_OBJECT, o.info.Id, principal, statements, opt.PolicyExpireTime)
+}
+
+// DeleteObjectPolicy - Delete the object policy of the principal, see client.IObjectClient.
+func (f *FakeClient) DeleteObjectPolicy(ctx context.Context, bucketName, objectName string, principalStr types.Principal, opt types.DeletePolicyOption) (string, error) {
+	if err := f.call(ctx, "DeleteObjectPolicy"); err != nil {
+		return "", err
+	}
+	principal, err := parsePrincipal(principalStr)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	_, o, err := f.txObject(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	return f.deletePolicy(operator, o.info.Owner, resource.RESOURCE_TYPE_OBJECT, o.info.Id, principal)
+}
+
+// GetObjectPolicy - Get the object policy of the account, see client.IObjectClient.
+func (f *FakeClient) GetObjectPolicy(ctx context.Context, bucketName, objectName string, principalAddr string) (*permTypes.Policy, error) {
+	if err := f.call(ctx, "GetObjectPolicy"); err != nil {
+		return nil, err
+	}
+	principal, err := accountPrincipal(principalAddr)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, o, err := f.queryObject(bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	return f.getPolicy(resource.RESOURCE_TYPE_OBJECT, o.info.Id, principal)
+}
+
+// GetObjectPolicyOfGroup - Get the object policy of the group, see client.IGroupClient.
+func (f *FakeClient) GetObjectPolicyOfGroup(ctx context.Context, bucketName, objectName string, groupID uint64) (*permTypes.Policy, error) {
+	if err := f.call(ctx, "GetObjectPolicyOfGroup"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, o, err := f.queryObject(bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	return f.getPolicy(resource.RESOURCE_TYPE_OBJECT, o.info.Id, groupPrincipal(groupID))
+}
+
+// IsObjectPermissionAllowed - Check if the permission of the object is allowed to the user, see client.IObjectClient.
+func (f *FakeClient) IsObjectPermissionAllowed(ctx context.Context, userAddr string, bucketName, objectName string, action permTypes.ActionType) (permTypes.Effect, error) {
+	if err := f.call(ctx, "IsObjectPermissionAllowed"); err != nil {
+		return permTypes.EFFECT_DENY, err
+	}
+	operator, err := sdk.AccAddressFromHexUnsafe(userAddr)
+	if err != nil {
+		return permTypes.EFFECT_DENY, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, o, err := f.queryObject(bucketName, objectName)
+	if err != nil {
+		return permTypes.EFFECT_DENY, err
+	}
+	return f.objectEffect(b, o, operator.String(), action), nil
+}
+
+// PutGroupPolicy - Put the policy of the account on the group owned by the caller, see client.IGroupClient.
+func (f *FakeClient) PutGroupPolicy(ctx context.Context, groupName string, principalAddr string,
+	statements []*permTypes.Statement, opt types.PutPolicyOption,
+) (string, error) {
+	if err := f.call(ctx, "PutGroupPolicy"); err != nil {
+		return "", err
+	}
+	principal, err := accountPrincipal(principalAddr)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	g, err := f.txGroup(operator, groupName)
+	if err != nil {
+		return "", err
+	}
+	return f.putPolicy(operator, g.info.Owner, resource.RESOURCE_TYPE_GROUP, g.info.Id, principal, statements, opt.PolicyExpireTime)
+}
+
+// DeleteGroupPolicy - Delete the policy of the account on the group owned by the caller, see client.IGroupClient.
+func (f *FakeClient) DeleteGroupPolicy(ctx context.Context, groupName string, principalAddr string, opt types.DeletePolicyOption) (string, error) {
+	if err := f.call(ctx, "DeleteGroupPolicy"); err != nil {
+		return "", err
+	}
+	principal, err := accountPrincipal(principalAddr)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	g, err := f.txGroup(operator, groupName)
+	if err != nil {
+		return "", err
+	}
+	return f.deletePolicy(operator, g.info.Owner, resource.RESOURCE_TYPE_GROUP, g.info.Id, principal)
+}
+
+// GetGroupPolicy - Get the policy of the account on the group owned by the caller, see client.IGroupClient.
+func (f *FakeClient) GetGroupPolicy(ctx context.Context, groupName string, principalAddr string) (*permTypes.Policy, error) {
+	if err := f.call(ctx, "GetGroupPolicy"); err != nil {
+		return nil, err
+	}
+	principal, err := accountPrincipal(principalAddr)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	operator, err := f.callAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	g, err := f.queryGroup(operator, groupName)
+	if err != nil {
+		return nil, err
+	}
+	return f.getPolicy(resource.RESOURCE_TYPE_GROUP, g.info.Id, principal)
+}
+
+// accountPrincipal returns the principal of the HEX-encoded account address.
+func accountPrincipal(addr string) (*permTypes.Principal, error) {
+	accAddress, err := sdk.AccAddressFromHexUnsafe(addr)
+	if err != nil {
+		return nil, err
+	}
+	return permTypes.NewPrincipalWithAccount(accAddress), nil
+}
+
+// groupPrincipal returns the principal of the group.
+func groupPrincipal(groupID uint64) *permTypes.Principal {
+	return permTypes.NewPrincipalWithGroupID(sdkmath.NewUint(groupID))
+}
@@ -0,0 +1,950 @@
+package clienttest
+
+import (
+	"context"
+	"io"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cometbft/cometbft/proto/tendermint/p2p"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	bfttypes "github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/votepool"
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	govTypesV1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	oracletypes "github.com/cosmos/cosmos-sdk/x/oracle/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"google.golang.org/grpc"
+
+	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
+	challengetypes "github.com/evmos/evmos/v12/x/challenge/types"
+	paymentTypes "github.com/evmos/evmos/v12/x/payment/types"
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	spTypes "github.com/evmos/evmos/v12/x/sp/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	virtualGroupTypes "github.com/evmos/evmos/v12/x/virtualgroup/types"
+	"github.com/zkMeLabs/mechain-go-sdk/client"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// unimplementedClient implements all the methods of client.IClient by returning an error naming the method, or
+// panicking with it if the method returns no error. It is embedded by the FakeClient, whose own methods take
+// precedence, so that the methods the FakeClient does not implement fail clearly.
+type unimplementedClient struct{}
+
+var _ client.IClient = unimplementedClient{}
+
+func (unimplementedClient) AttestChallenge(context.Context, string, string, string, uint64, sdkmath.Uint, challengetypes.VoteResult, []uint64, []byte, gnfdSdkTypes.TxOption) (_ *sdk.TxResponse, err error) {
+	err = unimplemented("AttestChallenge")
+	return
+}
+
+func (unimplementedClient) BeginRedelegate(context.Context, string, string, sdkmath.Int, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("BeginRedelegate")
+	return
+}
+
+func (unimplementedClient) BroadcastRawTx(context.Context, []byte, bool) (_ *sdk.TxResponse, err error) {
+	err = unimplemented("BroadcastRawTx")
+	return
+}
+
+func (unimplementedClient) BroadcastSignedTx(context.Context, []byte, bool) (_ *sdk.TxResponse, err error) {
+	err = unimplemented("BroadcastSignedTx")
+	return
+}
+
+func (unimplementedClient) BroadcastTx(context.Context, []sdk.Msg, *gnfdSdkTypes.TxOption, ...grpc.CallOption) (_ *tx.BroadcastTxResponse, err error) {
+	err = unimplemented("BroadcastTx")
+	return
+}
+
+func (unimplementedClient) BroadcastVote(context.Context, votepool.Vote) error {
+	return unimplemented("BroadcastVote")
+}
+
+func (unimplementedClient) BuildUnsignedTx(context.Context, []sdk.Msg, gnfdSdkTypes.TxOption) (_ []byte, _ *types.TxSignerData, err error) {
+	err = unimplemented("BuildUnsignedTx")
+	return
+}
+
+func (unimplementedClient) BuyQuotaForBucket(context.Context, string, uint64, types.BuyQuotaOption) (_ string, err error) {
+	err = unimplemented("BuyQuotaForBucket")
+	return
+}
+
+func (unimplementedClient) CancelCreateObject(context.Context, string, string, types.CancelCreateOption) (_ string, err error) {
+	err = unimplemented("CancelCreateObject")
+	return
+}
+
+func (unimplementedClient) CancelMigrateBucket(context.Context, string, types.CancelMigrateBucketOptions) (_ string, err error) {
+	err = unimplemented("CancelMigrateBucket")
+	return
+}
+
+func (unimplementedClient) CancelUnbondingDelegation(context.Context, string, int64, sdkmath.Int, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("CancelUnbondingDelegation")
+	return
+}
+
+func (unimplementedClient) CancelUpdateObjectContent(context.Context, string, string, types.CancelUpdateObjectOption) (_ string, err error) {
+	err = unimplemented("CancelUpdateObjectContent")
+	return
+}
+
+func (unimplementedClient) ChallengeParams(context.Context, *challengetypes.QueryParamsRequest) (_ *challengetypes.QueryParamsResponse, err error) {
+	err = unimplemented("ChallengeParams")
+	return
+}
+
+func (unimplementedClient) Claims(context.Context, uint32, uint32, uint64, uint64, []byte, []uint64, []byte, gnfdSdkTypes.TxOption) (_ *sdk.TxResponse, err error) {
+	err = unimplemented("Claims")
+	return
+}
+
+func (unimplementedClient) ComputeHashRoots(io.Reader, bool) (_ [][]byte, _ int64, _ storageTypes.RedundancyType, err error) {
+	err = unimplemented("ComputeHashRoots")
+	return
+}
+
+func (unimplementedClient) CreateBucket(context.Context, string, string, types.CreateBucketOptions) (_ string, err error) {
+	err = unimplemented("CreateBucket")
+	return
+}
+
+func (unimplementedClient) CreateBucketWithResult(context.Context, string, string, types.CreateBucketOptions) (_ *types.CreateBucketResult, err error) {
+	err = unimplemented("CreateBucketWithResult")
+	return
+}
+
+func (unimplementedClient) CreateFolder(context.Context, string, string, types.CreateObjectOptions) (_ string, err error) {
+	err = unimplemented("CreateFolder")
+	return
+}
+
+func (unimplementedClient) CreateGroup(context.Context, string, types.CreateGroupOptions) (_ string, err error) {
+	err = unimplemented("CreateGroup")
+	return
+}
+
+func (unimplementedClient) CreateGroupWithResult(context.Context, string, types.CreateGroupOptions) (_ *types.CreateGroupResult, err error) {
+	err = unimplemented("CreateGroupWithResult")
+	return
+}
+
+func (unimplementedClient) CreateObject(context.Context, string, string, io.Reader, types.CreateObjectOptions) (_ string, err error) {
+	err = unimplemented("CreateObject")
+	return
+}
+
+func (unimplementedClient) CreateObjectWithResult(context.Context, string, string, io.Reader, types.CreateObjectOptions) (_ *types.CreateObjectResult, err error) {
+	err = unimplemented("CreateObjectWithResult")
+	return
+}
+
+func (unimplementedClient) CreatePaymentAccount(context.Context, string, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("CreatePaymentAccount")
+	return
+}
+
+func (unimplementedClient) CreateStorageProvider(context.Context, string, string, string, string, string, string, string, string, sdkmath.Int, spTypes.Description, types.CreateStorageProviderOptions) (_ uint64, _ string, err error) {
+	err = unimplemented("CreateStorageProvider")
+	return
+}
+
+func (unimplementedClient) CreateValidator(context.Context, stakingtypes.Description, stakingtypes.CommissionRates, sdkmath.Int, string, string, string, string, string, string, string, sdkmath.Int, string, string, string, gnfdSdkTypes.TxOption) (_ uint64, _ string, err error) {
+	err = unimplemented("CreateValidator")
+	return
+}
+
+func (unimplementedClient) DelegateCreateFolder(context.Context, string, string, types.PutObjectOptions) error {
+	return unimplemented("DelegateCreateFolder")
+}
+
+func (unimplementedClient) DelegatePutObject(context.Context, string, string, int64, io.Reader, types.PutObjectOptions) error {
+	return unimplemented("DelegatePutObject")
+}
+
+func (unimplementedClient) DelegateUpdateObjectContent(context.Context, string, string, int64, io.Reader, types.PutObjectOptions) error {
+	return unimplemented("DelegateUpdateObjectContent")
+}
+
+func (unimplementedClient) DelegateValidator(context.Context, string, sdkmath.Int, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("DelegateValidator")
+	return
+}
+
+func (unimplementedClient) DeleteBucket(context.Context, string, types.DeleteBucketOption) (_ string, err error) {
+	err = unimplemented("DeleteBucket")
+	return
+}
+
+func (unimplementedClient) DeleteBucketPolicy(context.Context, string, types.Principal, types.DeletePolicyOption) (_ string, err error) {
+	err = unimplemented("DeleteBucketPolicy")
+	return
+}
+
+func (unimplementedClient) DeleteGroup(context.Context, string, types.DeleteGroupOption) (_ string, err error) {
+	err = unimplemented("DeleteGroup")
+	return
+}
+
+func (unimplementedClient) DeleteGroupPolicy(context.Context, string, string, types.DeletePolicyOption) (_ string, err error) {
+	err = unimplemented("DeleteGroupPolicy")
+	return
+}
+
+func (unimplementedClient) DeleteObject(context.Context, string, string, types.DeleteObjectOption) (_ string, err error) {
+	err = unimplemented("DeleteObject")
+	return
+}
+
+func (unimplementedClient) DeleteObjectPolicy(context.Context, string, string, types.Principal, types.DeletePolicyOption) (_ string, err error) {
+	err = unimplemented("DeleteObjectPolicy")
+	return
+}
+
+func (unimplementedClient) DeleteUserPublicKeyV2(string, string, []string) (_ bool, err error) {
+	err = unimplemented("DeleteUserPublicKeyV2")
+	return
+}
+
+func (unimplementedClient) Deposit(context.Context, string, sdkmath.Int, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("Deposit")
+	return
+}
+
+func (unimplementedClient) DisableRefund(context.Context, string, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("DisableRefund")
+	return
+}
+
+func (unimplementedClient) DryRunRawTx(context.Context, []byte) (_ *types.DryRunResult, err error) {
+	err = unimplemented("DryRunRawTx")
+	return
+}
+
+func (unimplementedClient) DryRunTx(context.Context, []sdk.Msg, *gnfdSdkTypes.TxOption) (_ *types.DryRunResult, err error) {
+	err = unimplemented("DryRunTx")
+	return
+}
+
+func (unimplementedClient) EditValidator(context.Context, stakingtypes.Description, *sdk.Dec, *sdkmath.Int, string, string, string, string, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("EditValidator")
+	return
+}
+
+func (unimplementedClient) EnableTrace(io.Writer, bool) {
+	panic(unimplemented("EnableTrace"))
+}
+
+func (unimplementedClient) FGetObject(context.Context, string, string, string, types.GetObjectOptions) error {
+	return unimplemented("FGetObject")
+}
+
+func (unimplementedClient) FGetObjectResumable(context.Context, string, string, string, types.GetObjectOptions) error {
+	return unimplemented("FGetObjectResumable")
+}
+
+func (unimplementedClient) FPutObject(context.Context, string, string, string, types.PutObjectOptions) error {
+	return unimplemented("FPutObject")
+}
+
+func (unimplementedClient) FundCommunityPool(context.Context, sdkmath.Int, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("FundCommunityPool")
+	return
+}
+
+func (unimplementedClient) GetAccount(context.Context, string) (_ authTypes.AccountI, err error) {
+	err = unimplemented("GetAccount")
+	return
+}
+
+func (unimplementedClient) GetAccountBalance(context.Context, string) (_ *sdk.Coin, err error) {
+	err = unimplemented("GetAccountBalance")
+	return
+}
+
+func (unimplementedClient) GetBlockByHeight(context.Context, int64) (_ *bfttypes.Block, err error) {
+	err = unimplemented("GetBlockByHeight")
+	return
+}
+
+func (unimplementedClient) GetBlockHeightByTime(context.Context, time.Time) (_ int64, err error) {
+	err = unimplemented("GetBlockHeightByTime")
+	return
+}
+
+func (unimplementedClient) GetBlockResultByHeight(context.Context, int64) (_ *ctypes.ResultBlockResults, err error) {
+	err = unimplemented("GetBlockResultByHeight")
+	return
+}
+
+func (unimplementedClient) GetBucketHistory(context.Context, string) (_ []*types.Event, err error) {
+	err = unimplemented("GetBucketHistory")
+	return
+}
+
+func (unimplementedClient) GetBucketMigrationProgress(context.Context, string, uint32) (_ types.MigrationProgress, err error) {
+	err = unimplemented("GetBucketMigrationProgress")
+	return
+}
+
+func (unimplementedClient) GetBucketPolicy(context.Context, string, string) (_ *permTypes.Policy, err error) {
+	err = unimplemented("GetBucketPolicy")
+	return
+}
+
+func (unimplementedClient) GetBucketPolicyOfGroup(context.Context, string, uint64) (_ *permTypes.Policy, err error) {
+	err = unimplemented("GetBucketPolicyOfGroup")
+	return
+}
+
+func (unimplementedClient) GetBucketReadQuota(context.Context, string) (_ types.QuotaInfo, err error) {
+	err = unimplemented("GetBucketReadQuota")
+	return
+}
+
+func (unimplementedClient) GetChallengeInfo(context.Context, string, int, int, types.GetChallengeInfoOptions) (_ types.ChallengeResult, err error) {
+	err = unimplemented("GetChallengeInfo")
+	return
+}
+
+func (unimplementedClient) GetChannelReceiveSequence(context.Context, sdk.ChainID, uint32) (_ uint64, err error) {
+	err = unimplemented("GetChannelReceiveSequence")
+	return
+}
+
+func (unimplementedClient) GetChannelSendSequence(context.Context, sdk.ChainID, uint32) (_ uint64, err error) {
+	err = unimplemented("GetChannelSendSequence")
+	return
+}
+
+func (unimplementedClient) GetCommit(context.Context, int64) (_ *ctypes.ResultCommit, err error) {
+	err = unimplemented("GetCommit")
+	return
+}
+
+func (unimplementedClient) GetCreateBucketApproval(context.Context, *storageTypes.MsgCreateBucket) (_ *storageTypes.MsgCreateBucket, err error) {
+	err = unimplemented("GetCreateBucketApproval")
+	return
+}
+
+func (unimplementedClient) GetCreateObjectApproval(context.Context, *storageTypes.MsgCreateObject) (_ *storageTypes.MsgCreateObject, err error) {
+	err = unimplemented("GetCreateObjectApproval")
+	return
+}
+
+func (unimplementedClient) GetCrossChainPackage(context.Context, sdk.ChainID, uint32, uint64) (_ []byte, err error) {
+	err = unimplemented("GetCrossChainPackage")
+	return
+}
+
+func (unimplementedClient) GetDefaultAccount() (_ *types.Account, err error) {
+	err = unimplemented("GetDefaultAccount")
+	return
+}
+
+func (unimplementedClient) GetGlobalSpStorePrice(context.Context) (_ *spTypes.GlobalSpStorePrice, err error) {
+	err = unimplemented("GetGlobalSpStorePrice")
+	return
+}
+
+func (unimplementedClient) GetGroupPolicy(context.Context, string, string) (_ *permTypes.Policy, err error) {
+	err = unimplemented("GetGroupPolicy")
+	return
+}
+
+func (unimplementedClient) GetInturnRelayer(context.Context, *oracletypes.QueryInturnRelayerRequest) (_ *oracletypes.QueryInturnRelayerResponse, err error) {
+	err = unimplemented("GetInturnRelayer")
+	return
+}
+
+func (unimplementedClient) GetLatestBlock(context.Context) (_ *bfttypes.Block, err error) {
+	err = unimplemented("GetLatestBlock")
+	return
+}
+
+func (unimplementedClient) GetLatestBlockHeight(context.Context) (_ int64, err error) {
+	err = unimplemented("GetLatestBlockHeight")
+	return
+}
+
+func (unimplementedClient) GetMigrateBucketApproval(context.Context, *storageTypes.MsgMigrateBucket) (_ *storageTypes.MsgMigrateBucket, err error) {
+	err = unimplemented("GetMigrateBucketApproval")
+	return
+}
+
+func (unimplementedClient) GetModuleAccountByName(context.Context, string) (_ authTypes.ModuleAccountI, err error) {
+	err = unimplemented("GetModuleAccountByName")
+	return
+}
+
+func (unimplementedClient) GetModuleAccounts(context.Context) (_ []authTypes.ModuleAccountI, err error) {
+	err = unimplemented("GetModuleAccounts")
+	return
+}
+
+func (unimplementedClient) GetMultisigAccount(context.Context, string) (_ *types.MultisigAccount, err error) {
+	err = unimplemented("GetMultisigAccount")
+	return
+}
+
+func (unimplementedClient) GetNextNonce(string) (_ string, err error) {
+	err = unimplemented("GetNextNonce")
+	return
+}
+
+func (unimplementedClient) GetNodeInfo(context.Context) (_ *p2p.DefaultNodeInfo, _ *tmservice.VersionInfo, err error) {
+	err = unimplemented("GetNodeInfo")
+	return
+}
+
+func (unimplementedClient) GetObject(context.Context, string, string, types.GetObjectOptions) (_ io.ReadCloser, _ types.ObjectStat, err error) {
+	err = unimplemented("GetObject")
+	return
+}
+
+func (unimplementedClient) GetObjectPolicy(context.Context, string, string, string) (_ *permTypes.Policy, err error) {
+	err = unimplemented("GetObjectPolicy")
+	return
+}
+
+func (unimplementedClient) GetObjectPolicyOfGroup(context.Context, string, string, uint64) (_ *permTypes.Policy, err error) {
+	err = unimplemented("GetObjectPolicyOfGroup")
+	return
+}
+
+func (unimplementedClient) GetObjectUploadProgress(context.Context, string, string) (_ string, err error) {
+	err = unimplemented("GetObjectUploadProgress")
+	return
+}
+
+func (unimplementedClient) GetPaymentAccount(context.Context, string) (_ *paymentTypes.PaymentAccount, err error) {
+	err = unimplemented("GetPaymentAccount")
+	return
+}
+
+func (unimplementedClient) GetPaymentAccountFlowRateLimit(context.Context, sdk.AccAddress, sdk.AccAddress, string) (_ *storageTypes.QueryPaymentAccountBucketFlowRateLimitResponse, err error) {
+	err = unimplemented("GetPaymentAccountFlowRateLimit")
+	return
+}
+
+func (unimplementedClient) GetPaymentAccountsByOwner(context.Context, string) (_ []*paymentTypes.PaymentAccount, err error) {
+	err = unimplemented("GetPaymentAccountsByOwner")
+	return
+}
+
+func (unimplementedClient) GetProposal(context.Context, uint64) (_ *govTypesV1.Proposal, err error) {
+	err = unimplemented("GetProposal")
+	return
+}
+
+func (unimplementedClient) GetQuotaUpdateTime(context.Context, string) (_ int64, err error) {
+	err = unimplemented("GetQuotaUpdateTime")
+	return
+}
+
+func (unimplementedClient) GetRecommendedVirtualGroupFamilyIDBySPID(context.Context, uint32) (_ uint32, err error) {
+	err = unimplemented("GetRecommendedVirtualGroupFamilyIDBySPID")
+	return
+}
+
+func (unimplementedClient) GetStatus(context.Context) (_ *ctypes.ResultStatus, err error) {
+	err = unimplemented("GetStatus")
+	return
+}
+
+func (unimplementedClient) GetStoragePrice(context.Context, string) (_ *spTypes.SpStoragePrice, err error) {
+	err = unimplemented("GetStoragePrice")
+	return
+}
+
+func (unimplementedClient) GetStorageProviderInfo(context.Context, sdk.AccAddress) (_ *spTypes.StorageProvider, err error) {
+	err = unimplemented("GetStorageProviderInfo")
+	return
+}
+
+func (unimplementedClient) GetStreamRecord(context.Context, string) (_ *paymentTypes.StreamRecord, err error) {
+	err = unimplemented("GetStreamRecord")
+	return
+}
+
+func (unimplementedClient) GetSyncing(context.Context) (_ bool, err error) {
+	err = unimplemented("GetSyncing")
+	return
+}
+
+func (unimplementedClient) GetValidatorSet(context.Context) (_ int64, _ []*bfttypes.Validator, err error) {
+	err = unimplemented("GetValidatorSet")
+	return
+}
+
+func (unimplementedClient) GetValidatorsByHeight(context.Context, int64) (_ []*bfttypes.Validator, err error) {
+	err = unimplemented("GetValidatorsByHeight")
+	return
+}
+
+func (unimplementedClient) GrantAllowance(context.Context, string, feegrant.FeeAllowanceI, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("GrantAllowance")
+	return
+}
+
+func (unimplementedClient) GrantBasicAllowance(context.Context, string, sdkmath.Int, *time.Time, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("GrantBasicAllowance")
+	return
+}
+
+func (unimplementedClient) GrantDelegationForValidator(context.Context, sdkmath.Int, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("GrantDelegationForValidator")
+	return
+}
+
+func (unimplementedClient) GrantDepositForStorageProvider(context.Context, string, sdkmath.Int, types.GrantDepositForStorageProviderOptions) (_ string, err error) {
+	err = unimplemented("GrantDepositForStorageProvider")
+	return
+}
+
+func (unimplementedClient) HeadBucket(context.Context, string) (_ *storageTypes.BucketInfo, err error) {
+	err = unimplemented("HeadBucket")
+	return
+}
+
+func (unimplementedClient) HeadBucketByID(context.Context, string) (_ *storageTypes.BucketInfo, err error) {
+	err = unimplemented("HeadBucketByID")
+	return
+}
+
+func (unimplementedClient) HeadGroup(context.Context, string, string) (_ *storageTypes.GroupInfo, err error) {
+	err = unimplemented("HeadGroup")
+	return
+}
+
+func (unimplementedClient) HeadGroupMember(context.Context, string, string, string) bool {
+	panic(unimplemented("HeadGroupMember"))
+}
+
+func (unimplementedClient) HeadObject(context.Context, string, string) (_ *types.ObjectDetail, err error) {
+	err = unimplemented("HeadObject")
+	return
+}
+
+func (unimplementedClient) HeadObjectByID(context.Context, string) (_ *types.ObjectDetail, err error) {
+	err = unimplemented("HeadObjectByID")
+	return
+}
+
+func (unimplementedClient) ImpeachValidator(context.Context, string, sdkmath.Int, string, string, string, gnfdSdkTypes.TxOption) (_ uint64, _ string, err error) {
+	err = unimplemented("ImpeachValidator")
+	return
+}
+
+func (unimplementedClient) InturnAttestationSubmitter(context.Context, *challengetypes.QueryInturnAttestationSubmitterRequest) (_ *challengetypes.QueryInturnAttestationSubmitterResponse, err error) {
+	err = unimplemented("InturnAttestationSubmitter")
+	return
+}
+
+func (unimplementedClient) IsBucketPermissionAllowed(context.Context, string, string, permTypes.ActionType) (_ permTypes.Effect, err error) {
+	err = unimplemented("IsBucketPermissionAllowed")
+	return
+}
+
+func (unimplementedClient) IsObjectPermissionAllowed(context.Context, string, string, string, permTypes.ActionType) (_ permTypes.Effect, err error) {
+	err = unimplemented("IsObjectPermissionAllowed")
+	return
+}
+
+func (unimplementedClient) LatestAttestedChallenges(context.Context, *challengetypes.QueryLatestAttestedChallengesRequest) (_ *challengetypes.QueryLatestAttestedChallengesResponse, err error) {
+	err = unimplemented("LatestAttestedChallenges")
+	return
+}
+
+func (unimplementedClient) LeaveGroup(context.Context, string, string, types.LeaveGroupOption) (_ string, err error) {
+	err = unimplemented("LeaveGroup")
+	return
+}
+
+func (unimplementedClient) ListBucketReadRecord(context.Context, string, types.ListReadRecordOptions) (_ types.QuotaRecordInfo, err error) {
+	err = unimplemented("ListBucketReadRecord")
+	return
+}
+
+func (unimplementedClient) ListBuckets(context.Context, types.ListBucketsOptions) (_ types.ListBucketsResult, err error) {
+	err = unimplemented("ListBuckets")
+	return
+}
+
+func (unimplementedClient) ListBucketsByBucketID(context.Context, []uint64, types.EndPointOptions) (_ types.ListBucketsByBucketIDResponse, err error) {
+	err = unimplemented("ListBucketsByBucketID")
+	return
+}
+
+func (unimplementedClient) ListBucketsByPaymentAccount(context.Context, string, types.ListBucketsByPaymentAccountOptions) (_ types.ListBucketsByPaymentAccountResult, err error) {
+	err = unimplemented("ListBucketsByPaymentAccount")
+	return
+}
+
+func (unimplementedClient) ListGroup(context.Context, string, string, types.ListGroupsOptions) (_ types.ListGroupsResult, err error) {
+	err = unimplemented("ListGroup")
+	return
+}
+
+func (unimplementedClient) ListGroupMembers(context.Context, int64, types.GroupMembersPaginationOptions) (_ *types.GroupMembersResult, err error) {
+	err = unimplemented("ListGroupMembers")
+	return
+}
+
+func (unimplementedClient) ListGroupsByAccount(context.Context, types.GroupsPaginationOptions) (_ *types.GroupsResult, err error) {
+	err = unimplemented("ListGroupsByAccount")
+	return
+}
+
+func (unimplementedClient) ListGroupsByGroupID(context.Context, []uint64, types.EndPointOptions) (_ types.ListGroupsByGroupIDResponse, err error) {
+	err = unimplemented("ListGroupsByGroupID")
+	return
+}
+
+func (unimplementedClient) ListGroupsByOwner(context.Context, types.GroupsOwnerPaginationOptions) (_ *types.GroupsResult, err error) {
+	err = unimplemented("ListGroupsByOwner")
+	return
+}
+
+func (unimplementedClient) ListObjectPolicies(context.Context, string, string, uint32, types.ListObjectPoliciesOptions) (_ types.ListObjectPoliciesResponse, err error) {
+	err = unimplemented("ListObjectPolicies")
+	return
+}
+
+func (unimplementedClient) ListObjects(context.Context, string, types.ListObjectsOptions) (_ types.ListObjectsResult, err error) {
+	err = unimplemented("ListObjects")
+	return
+}
+
+func (unimplementedClient) ListObjectsByObjectID(context.Context, []uint64, types.EndPointOptions) (_ types.ListObjectsByObjectIDResponse, err error) {
+	err = unimplemented("ListObjectsByObjectID")
+	return
+}
+
+func (unimplementedClient) ListStorageProviders(context.Context, bool) (_ []spTypes.StorageProvider, err error) {
+	err = unimplemented("ListStorageProviders")
+	return
+}
+
+func (unimplementedClient) ListUserPaymentAccounts(context.Context, types.ListUserPaymentAccountsOptions) (_ types.ListUserPaymentAccountsResult, err error) {
+	err = unimplemented("ListUserPaymentAccounts")
+	return
+}
+
+func (unimplementedClient) ListUserPublicKeyV2(string, string) (_ []string, err error) {
+	err = unimplemented("ListUserPublicKeyV2")
+	return
+}
+
+func (unimplementedClient) ListValidators(context.Context, string) (_ *stakingtypes.QueryValidatorsResponse, err error) {
+	err = unimplemented("ListValidators")
+	return
+}
+
+func (unimplementedClient) MigrateBucket(context.Context, string, uint32, types.MigrateBucketOptions) (_ string, err error) {
+	err = unimplemented("MigrateBucket")
+	return
+}
+
+func (unimplementedClient) MirrorBucket(context.Context, sdk.ChainID, sdkmath.Uint, string, gnfdSdkTypes.TxOption) (_ *sdk.TxResponse, err error) {
+	err = unimplemented("MirrorBucket")
+	return
+}
+
+func (unimplementedClient) MirrorGroup(context.Context, sdk.ChainID, sdkmath.Uint, string, gnfdSdkTypes.TxOption) (_ *sdk.TxResponse, err error) {
+	err = unimplemented("MirrorGroup")
+	return
+}
+
+func (unimplementedClient) MirrorObject(context.Context, sdk.ChainID, sdkmath.Uint, string, string, gnfdSdkTypes.TxOption) (_ *sdk.TxResponse, err error) {
+	err = unimplemented("MirrorObject")
+	return
+}
+
+func (unimplementedClient) MultiTransfer(context.Context, []types.TransferDetail, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("MultiTransfer")
+	return
+}
+
+func (unimplementedClient) MustGetDefaultAccount() *types.Account {
+	panic(unimplemented("MustGetDefaultAccount"))
+}
+
+func (unimplementedClient) OffChainAuthSign([]byte) string {
+	panic(unimplemented("OffChainAuthSign"))
+}
+
+func (unimplementedClient) OffChainAuthSignV2([]byte) string {
+	panic(unimplemented("OffChainAuthSignV2"))
+}
+
+func (unimplementedClient) PresignGetObject(context.Context, string, string, types.PresignGetObjectOptions) (_ string, err error) {
+	err = unimplemented("PresignGetObject")
+	return
+}
+
+func (unimplementedClient) PresignPutObject(context.Context, string, string, types.PresignPutObjectOptions) (_ string, err error) {
+	err = unimplemented("PresignPutObject")
+	return
+}
+
+func (unimplementedClient) PutBucketPolicy(context.Context, string, types.Principal, []*permTypes.Statement, types.PutPolicyOption) (_ string, err error) {
+	err = unimplemented("PutBucketPolicy")
+	return
+}
+
+func (unimplementedClient) PutGroupPolicy(context.Context, string, string, []*permTypes.Statement, types.PutPolicyOption) (_ string, err error) {
+	err = unimplemented("PutGroupPolicy")
+	return
+}
+
+func (unimplementedClient) PutObject(context.Context, string, string, int64, io.Reader, types.PutObjectOptions) error {
+	return unimplemented("PutObject")
+}
+
+func (unimplementedClient) PutObjectPolicy(context.Context, string, string, types.Principal, []*permTypes.Statement, types.PutPolicyOption) (_ string, err error) {
+	err = unimplemented("PutObjectPolicy")
+	return
+}
+
+func (unimplementedClient) QueryAllowance(context.Context, string, string) (_ *feegrant.Grant, err error) {
+	err = unimplemented("QueryAllowance")
+	return
+}
+
+func (unimplementedClient) QueryAllowances(context.Context, string) (_ []*feegrant.Grant, err error) {
+	err = unimplemented("QueryAllowances")
+	return
+}
+
+func (unimplementedClient) QueryBasicAllowance(context.Context, string, string) (_ *feegrant.BasicAllowance, err error) {
+	err = unimplemented("QueryBasicAllowance")
+	return
+}
+
+func (unimplementedClient) QuerySpAvailableGlobalVirtualGroupFamilies(context.Context, uint32) (_ []uint32, err error) {
+	err = unimplemented("QuerySpAvailableGlobalVirtualGroupFamilies")
+	return
+}
+
+func (unimplementedClient) QuerySpOptimalGlobalVirtualGroupFamily(context.Context, uint32, virtualGroupTypes.PickVGFStrategy) (_ uint32, err error) {
+	err = unimplemented("QuerySpOptimalGlobalVirtualGroupFamily")
+	return
+}
+
+func (unimplementedClient) QueryVirtualGroupFamily(context.Context, uint32) (_ *virtualGroupTypes.GlobalVirtualGroupFamily, err error) {
+	err = unimplemented("QueryVirtualGroupFamily")
+	return
+}
+
+func (unimplementedClient) QueryVirtualGroupParams(context.Context) (_ *virtualGroupTypes.Params, err error) {
+	err = unimplemented("QueryVirtualGroupParams")
+	return
+}
+
+func (unimplementedClient) QueryVote(context.Context, int, []byte) (_ *ctypes.ResultQueryVote, err error) {
+	err = unimplemented("QueryVote")
+	return
+}
+
+func (unimplementedClient) RegisterEDDSAPublicKey(string, string) (_ string, err error) {
+	err = unimplemented("RegisterEDDSAPublicKey")
+	return
+}
+
+func (unimplementedClient) RegisterEDDSAPublicKeyV2(string) (_ string, err error) {
+	err = unimplemented("RegisterEDDSAPublicKeyV2")
+	return
+}
+
+func (unimplementedClient) RenewGroupMember(context.Context, string, string, []string, types.RenewGroupMemberOption) (_ string, err error) {
+	err = unimplemented("RenewGroupMember")
+	return
+}
+
+func (unimplementedClient) RevokeAllowance(context.Context, string, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("RevokeAllowance")
+	return
+}
+
+func (unimplementedClient) SearchTxs(context.Context, *types.TxQuery, types.SearchTxsOptions) (_ *types.SearchTxsResult, err error) {
+	err = unimplemented("SearchTxs")
+	return
+}
+
+func (unimplementedClient) SetBucketFlowRateLimit(context.Context, string, sdk.AccAddress, sdk.AccAddress, sdkmath.Int, types.SetBucketFlowRateLimitOption) (_ string, err error) {
+	err = unimplemented("SetBucketFlowRateLimit")
+	return
+}
+
+func (unimplementedClient) SetDefaultAccount(*types.Account) {
+	panic(unimplemented("SetDefaultAccount"))
+}
+
+func (unimplementedClient) SetTag(context.Context, string, storageTypes.ResourceTags, types.SetTagsOptions) (_ string, err error) {
+	err = unimplemented("SetTag")
+	return
+}
+
+func (unimplementedClient) SetWithdrawAddress(context.Context, string, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("SetWithdrawAddress")
+	return
+}
+
+func (unimplementedClient) SimulateRawTx(context.Context, []byte, ...grpc.CallOption) (_ *tx.SimulateResponse, err error) {
+	err = unimplemented("SimulateRawTx")
+	return
+}
+
+func (unimplementedClient) SimulateTx(context.Context, []sdk.Msg, gnfdSdkTypes.TxOption, ...grpc.CallOption) (_ *tx.SimulateResponse, err error) {
+	err = unimplemented("SimulateTx")
+	return
+}
+
+func (unimplementedClient) SubmitChallenge(context.Context, string, string, string, string, bool, uint32, gnfdSdkTypes.TxOption) (_ *sdk.TxResponse, err error) {
+	err = unimplemented("SubmitChallenge")
+	return
+}
+
+func (unimplementedClient) SubmitProposal(context.Context, []sdk.Msg, sdkmath.Int, string, string, types.SubmitProposalOptions) (_ uint64, _ string, err error) {
+	err = unimplemented("SubmitProposal")
+	return
+}
+
+func (unimplementedClient) Subscribe(context.Context, types.SubscribeOptions) (_ <-chan *types.Event, err error) {
+	err = unimplemented("Subscribe")
+	return
+}
+
+func (unimplementedClient) ToggleSPAsDelegatedAgent(context.Context, string, types.UpdateBucketOptions) (_ string, err error) {
+	err = unimplemented("ToggleSPAsDelegatedAgent")
+	return
+}
+
+func (unimplementedClient) Transfer(context.Context, string, sdkmath.Int, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("Transfer")
+	return
+}
+
+func (unimplementedClient) TransferOut(context.Context, string, sdkmath.Int, gnfdSdkTypes.TxOption) (_ *sdk.TxResponse, err error) {
+	err = unimplemented("TransferOut")
+	return
+}
+
+func (unimplementedClient) UnJailValidator(context.Context, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("UnJailValidator")
+	return
+}
+
+func (unimplementedClient) Undelegate(context.Context, string, sdkmath.Int, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("Undelegate")
+	return
+}
+
+func (unimplementedClient) UpdateBucketInfo(context.Context, string, types.UpdateBucketOptions) (_ string, err error) {
+	err = unimplemented("UpdateBucketInfo")
+	return
+}
+
+func (unimplementedClient) UpdateBucketPaymentAddr(context.Context, string, sdk.AccAddress, types.UpdatePaymentOption) (_ string, err error) {
+	err = unimplemented("UpdateBucketPaymentAddr")
+	return
+}
+
+func (unimplementedClient) UpdateBucketVisibility(context.Context, string, storageTypes.VisibilityType, types.UpdateVisibilityOption) (_ string, err error) {
+	err = unimplemented("UpdateBucketVisibility")
+	return
+}
+
+func (unimplementedClient) UpdateGroupMember(context.Context, string, string, []string, []string, types.UpdateGroupMemberOption) (_ string, err error) {
+	err = unimplemented("UpdateGroupMember")
+	return
+}
+
+func (unimplementedClient) UpdateObjectContent(context.Context, string, string, io.Reader, types.UpdateObjectOptions) (_ string, err error) {
+	err = unimplemented("UpdateObjectContent")
+	return
+}
+
+func (unimplementedClient) UpdateObjectVisibility(context.Context, string, string, storageTypes.VisibilityType, types.UpdateObjectOption) (_ string, err error) {
+	err = unimplemented("UpdateObjectVisibility")
+	return
+}
+
+func (unimplementedClient) UpdateSpStatus(context.Context, string, spTypes.Status, int64, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("UpdateSpStatus")
+	return
+}
+
+func (unimplementedClient) UpdateSpStoragePrice(context.Context, string, sdk.Dec, sdk.Dec, uint64, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("UpdateSpStoragePrice")
+	return
+}
+
+func (unimplementedClient) VoteProposal(context.Context, uint64, govTypesV1.VoteOption, types.VoteProposalOptions) (_ string, err error) {
+	err = unimplemented("VoteProposal")
+	return
+}
+
+func (unimplementedClient) WaitForBlockHeight(context.Context, int64) error {
+	return unimplemented("WaitForBlockHeight")
+}
+
+func (unimplementedClient) WaitForBucketMigrationComplete(context.Context, string, int64) (_ *storageTypes.BucketInfo, err error) {
+	err = unimplemented("WaitForBucketMigrationComplete")
+	return
+}
+
+func (unimplementedClient) WaitForNBlocks(context.Context, int64) error {
+	return unimplemented("WaitForNBlocks")
+}
+
+func (unimplementedClient) WaitForNextBlock(context.Context) error {
+	return unimplemented("WaitForNextBlock")
+}
+
+func (unimplementedClient) WaitForObjectDeleted(context.Context, string, string) error {
+	return unimplemented("WaitForObjectDeleted")
+}
+
+func (unimplementedClient) WaitForObjectSealed(context.Context, string, string) (_ *types.ObjectDetail, err error) {
+	err = unimplemented("WaitForObjectSealed")
+	return
+}
+
+func (unimplementedClient) WaitForObjectUpdateCompleted(context.Context, string, string) (_ *types.ObjectDetail, err error) {
+	err = unimplemented("WaitForObjectUpdateCompleted")
+	return
+}
+
+func (unimplementedClient) WaitForTx(context.Context, string) (_ *ctypes.ResultTx, err error) {
+	err = unimplemented("WaitForTx")
+	return
+}
+
+func (unimplementedClient) Withdraw(context.Context, string, sdkmath.Int, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("Withdraw")
+	return
+}
+
+func (unimplementedClient) WithdrawDelegatorReward(context.Context, string, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("WithdrawDelegatorReward")
+	return
+}
+
+func (unimplementedClient) WithdrawValidatorCommission(context.Context, gnfdSdkTypes.TxOption) (_ string, err error) {
+	err = unimplemented("WithdrawValidatorCommission")
+	return
+}
+
+func (unimplementedClient) putObjectResumable(context.Context, string, string, int64, io.Reader, types.PutObjectOptions) error {
+	return unimplemented("putObjectResumable")
+}
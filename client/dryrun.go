@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+
+	"github.com/evmos/evmos/v12/sdk/types"
+	gosdktypes "github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// checkDryRun returns an error matching types.ErrDryRun if the SP request of the method has side effects in the
+// dry-run mode, only the GET and HEAD requests are allowed.
+func (c *Client) checkDryRun(method string) error {
+	if !c.dryRun || method == http.MethodGet || method == http.MethodHead {
+		return nil
+	}
+	return fmt.Errorf("%w: the %s request to SP has side effects and is not sent", gosdktypes.ErrDryRun, method)
+}
+
+// dryRunTxInfo is the info of the TxResponse returned by BroadcastTx and BroadcastRawTx in the dry-run mode.
+const dryRunTxInfo = "dry run: the transaction has been simulated and not broadcast"
+
+// DryRunTx - Simulate the transaction of the message(s) and estimate its gas and fee, the transaction is not
+// broadcast. It can be called whether the dry-run mode is enabled or not.
+//
+// - ctx: Context variables for the current API call.
+//
+// - msgs: Message(s) of the transaction.
+//
+// - txOpt: The options of the transaction, the GasLimit and the FeeAmount are estimated unless they are set.
+//
+// - ret1: The result of the simulation.
+//
+// - ret2: Return error when the simulation failed, otherwise return nil.
+func (c *Client) DryRunTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption) (_ *gosdktypes.DryRunResult, err error) {
+	ctx, span := c.startChainSpan(ctx, "DryRunTx", AttrMsgCount.Int(len(msgs)))
+	defer func() { endSpan(span, err) }()
+	return c.dryRunTx(ctx, msgs, withCallAccount(ctx, txOpt))
+}
+
+// DryRunRawTx - Simulate the raw transaction and estimate its gas and fee, the transaction is not broadcast. It can
+// be called whether the dry-run mode is enabled or not.
+//
+// - ctx: Context variables for the current API call.
+//
+// - txBytes: The transaction bytes.
+//
+// - ret1: The result of the simulation.
+//
+// - ret2: Return error when the simulation failed, otherwise return nil.
+func (c *Client) DryRunRawTx(ctx context.Context, txBytes []byte) (*gosdktypes.DryRunResult, error) {
+	return c.dryRunRawTx(ctx, txBytes)
+}
+
+// dryRunTx simulates the transaction of the messages.
+func (c *Client) dryRunTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption) (*gosdktypes.DryRunResult, error) {
+	opt := types.TxOption{}
+	if txOpt != nil {
+		opt = *txOpt
+	}
+	simulateRes, err := c.chain().SimulateTx(ctx, msgs, &opt)
+	if err != nil {
+		return nil, &gosdktypes.SimulationError{Reason: simulationFailureReason(err), Err: err}
+	}
+	return c.dryRunResult(msgs, simulateRes, opt.GasLimit, opt.FeeAmount), nil
+}
+
+// dryRunRawTx simulates the raw transaction.
+func (c *Client) dryRunRawTx(ctx context.Context, txBytes []byte) (*gosdktypes.DryRunResult, error) {
+	decodedTx, err := newTxConfig(c.chain().GetCodec()).TxDecoder()(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	simulateRes, err := c.SimulateRawTx(ctx, txBytes)
+	if err != nil {
+		return nil, &gosdktypes.SimulationError{Reason: simulationFailureReason(err), Err: err}
+	}
+	var (
+		gasLimit uint64
+		fee      sdk.Coins
+	)
+	if feeTx, ok := decodedTx.(sdk.FeeTx); ok {
+		gasLimit, fee = feeTx.GetGas(), feeTx.GetFee()
+	}
+	return c.dryRunResult(decodedTx.GetMsgs(), simulateRes, gasLimit, fee), nil
+}
+
+// dryRunTxResponse returns the response of the transaction simulated in the dry-run mode, its hash is empty since
+// it is not broadcast.
+func dryRunTxResponse(result *gosdktypes.DryRunResult) *sdk.TxResponse {
+	return &sdk.TxResponse{
+		GasWanted: int64(result.GasLimit),
+		GasUsed:   int64(result.GasUsed),
+		Events:    result.Events,
+		Info:      dryRunTxInfo,
+	}
+}
+
+// checkDryRunWait returns an error matching types.ErrDryRun if the transaction to wait for is not broadcast in the
+// dry-run mode.
+func (c *Client) checkDryRunWait(hash string) error {
+	if !c.dryRun || hash != "" {
+		return nil
+	}
+	return fmt.Errorf("%w: the transaction is not broadcast and can not be waited for", gosdktypes.ErrDryRun)
+}
+
+// dryRunResult returns the result of the simulation, the gas limit and the fee are estimated unless they are given.
+func (c *Client) dryRunResult(msgs []sdk.Msg, simulateRes *tx.SimulateResponse, gasLimit uint64, fee sdk.Coins) *gosdktypes.DryRunResult {
+	estimator := c.autoGas
+	if estimator == nil {
+		estimator = &autoGasEstimator{multiplier: defaultGasMultiplier}
+	}
+	result := &gosdktypes.DryRunResult{
+		Msgs:     msgs,
+		GasUsed:  simulateRes.GasInfo.GetGasUsed(),
+		GasLimit: gasLimit,
+		Fee:      fee,
+	}
+	if result.GasLimit == 0 {
+		result.GasLimit = estimator.gasLimit(result.GasUsed)
+	}
+	if result.Fee.IsZero() {
+		// the fee is left empty if the gas price is unknown, it does not fail the dry run
+		result.Fee, _ = estimator.fee(result.GasLimit, simulateRes.GasInfo.GetMinGasPrice())
+	}
+	if simulateRes.Result != nil {
+		result.Events = simulateRes.Result.Events
+	}
+	c.logger.Info("dry run the transaction", "msgs", len(msgs), "gas_used", result.GasUsed,
+		"gas_limit", result.GasLimit, "fee", result.Fee.String())
+	return result
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+func TestDryRunTxResponse(t *testing.T) {
+	resp := dryRunTxResponse(&types.DryRunResult{
+		GasUsed:  100,
+		GasLimit: 120,
+		Events:   []abci.Event{{Type: "message"}},
+	})
+	require.Equal(t, uint32(0), resp.Code, "a successful simulation is not a failure")
+	require.Empty(t, resp.TxHash)
+	require.Equal(t, int64(120), resp.GasWanted)
+	require.Equal(t, int64(100), resp.GasUsed)
+	require.Len(t, resp.Events, 1)
+}
+
+func TestDryRunRejects(t *testing.T) {
+	c := &Client{dryRun: true}
+	require.NoError(t, c.checkDryRun(http.MethodGet))
+	require.ErrorIs(t, c.checkDryRun(http.MethodPut), types.ErrDryRun)
+
+	_, err := c.WaitForTx(context.Background(), "")
+	require.ErrorIs(t, err, types.ErrDryRun)
+}
@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
+	"github.com/evmos/evmos/v12/sdk/keys"
+)
+
+// The default configurations of the chain endpoints.
+const (
+	DefaultMaxBlockLag         = 10
+	DefaultHealthCheckInterval = 10 * time.Second
+)
+
+// chainEndpoint is a node of the chain and its health.
+type chainEndpoint struct {
+	url    string
+	client *sdkclient.MechainClient
+	// height and healthy are guarded by endpointPool.mu.
+	height  int64
+	healthy bool
+
+	// rpc is the RPC client of the node for the calls not served by client, e.g. TxSearch, it is created once and
+	// reused by the calls.
+	rpcOnce sync.Once
+	rpc     *rpchttp.HTTP
+	rpcErr  error
+}
+
+// rpcClient returns the RPC client of the node.
+func (e *chainEndpoint) rpcClient() (*rpchttp.HTTP, error) {
+	e.rpcOnce.Do(func() {
+		e.rpc, e.rpcErr = rpchttp.New(e.url, "/websocket")
+	})
+	return e.rpc, e.rpcErr
+}
+
+// endpointPool routes the calls to the chain to a healthy node.
+//
+// The current node is kept as long as it is healthy, so that the consecutive calls see the same state. The nodes are
+// checked by GetStatus in background every check interval, so that the recovery of a node is noticed even if the
+// Client is idle, and lazily by a call if the last check is older than the interval. A node is unhealthy if it fails,
+// is catching up, or lags behind the highest node by more than maxLag blocks.
+type endpointPool struct {
+	endpoints []*chainEndpoint
+	maxLag    int64
+	interval  time.Duration
+	logger    Logger
+
+	mu        sync.RWMutex
+	current   *chainEndpoint
+	checkedAt time.Time
+	checking  bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func newEndpointPool(chainID string, urls []string, option Option, logger Logger) (*endpointPool, error) {
+	p := &endpointPool{
+		maxLag:   option.MaxBlockLag,
+		interval: option.HealthCheckInterval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+	if p.maxLag <= 0 {
+		p.maxLag = DefaultMaxBlockLag
+	}
+	if p.interval <= 0 {
+		p.interval = DefaultHealthCheckInterval
+	}
+	for _, url := range urls {
+		var (
+			cc  *sdkclient.MechainClient
+			err error
+		)
+		if option.UseWebSocketConn {
+			cc, err = sdkclient.NewMechainClient(url, chainID, sdkclient.WithWebSocketClient())
+		} else {
+			cc, err = sdkclient.NewMechainClient(url, chainID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.endpoints = append(p.endpoints, &chainEndpoint{url: url, client: cc, healthy: true})
+	}
+	p.current = p.endpoints[0]
+	return p, nil
+}
+
+// get returns the node to call, the nodes are checked in background if the last check is stale.
+func (p *endpointPool) get() *chainEndpoint {
+	p.mu.RLock()
+	current := p.current
+	stale := len(p.endpoints) > 1 && !p.checking && time.Since(p.checkedAt) > p.interval
+	p.mu.RUnlock()
+
+	if stale && p.startCheck() {
+		go p.check()
+	}
+	return current
+}
+
+// startCheck reports whether the caller should check the nodes, it is false if a check is in progress.
+func (p *endpointPool) startCheck() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.checking {
+		return false
+	}
+	p.checking = true
+	return true
+}
+
+// monitor checks the nodes every check interval until the pool is closed, there is nothing to check if there is only
+// one node.
+func (p *endpointPool) monitor() {
+	if len(p.endpoints) == 1 {
+		return
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			if p.startCheck() {
+				p.check()
+			}
+		}
+	}
+}
+
+// close stops monitoring the nodes.
+func (p *endpointPool) close() {
+	p.stopOnce.Do(func() { close(p.stop) })
+}
+
+// size returns the number of the nodes.
+func (p *endpointPool) size() int {
+	return len(p.endpoints)
+}
+
+// setKeyManager sets the key manager which signs the transactions of all the nodes.
+func (p *endpointPool) setKeyManager(km keys.KeyManager) {
+	for _, endpoint := range p.endpoints {
+		endpoint.client.SetKeyManager(km)
+	}
+}
+
+// check updates the health of the nodes and selects the current node.
+func (p *endpointPool) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), p.interval)
+	defer cancel()
+
+	type health struct {
+		height   int64
+		syncedUp bool
+	}
+	healths := make([]health, len(p.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range p.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *chainEndpoint) {
+			defer wg.Done()
+			status, err := endpoint.client.GetStatus(ctx)
+			if err != nil {
+				p.logger.Warn("failed to check the chain endpoint", "endpoint", endpoint.url, "error", err)
+				return
+			}
+			healths[i] = health{height: status.SyncInfo.LatestBlockHeight, syncedUp: !status.SyncInfo.CatchingUp}
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	var maxHeight int64
+	for _, h := range healths {
+		if h.syncedUp && h.height > maxHeight {
+			maxHeight = h.height
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, endpoint := range p.endpoints {
+		endpoint.height = healths[i].height
+		endpoint.healthy = healths[i].syncedUp && healths[i].height >= maxHeight-p.maxLag
+	}
+	p.checkedAt = time.Now()
+	p.checking = false
+	p.selectLocked()
+}
+
+// markFailed marks the node unhealthy after a call to it fails, and selects another node.
+func (p *endpointPool) markFailed(endpoint *chainEndpoint) {
+	if len(p.endpoints) == 1 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	endpoint.healthy = false
+	p.selectLocked()
+}
+
+// reportFailure marks the node which served the call unhealthy if the error indicates the node is unreachable. The
+// failures after the ctx of the call is done are not reported, e.g. the short deadline of a retried call expires.
+func (p *endpointPool) reportFailure(ctx context.Context, endpoint *chainEndpoint, err error) {
+	if ctx.Err() != nil || !isEndpointError(err) {
+		return
+	}
+	p.markFailed(endpoint)
+}
+
+// selectLocked keeps the current node if it is healthy, otherwise the healthy node with the highest height is
+// selected. If no node is healthy, the node next to the current one is tried.
+func (p *endpointPool) selectLocked() {
+	if p.current.healthy {
+		return
+	}
+	var best *chainEndpoint
+	for _, endpoint := range p.endpoints {
+		if endpoint.healthy && (best == nil || endpoint.height > best.height) {
+			best = endpoint
+		}
+	}
+	if best == nil {
+		for i, endpoint := range p.endpoints {
+			if endpoint == p.current {
+				best = p.endpoints[(i+1)%len(p.endpoints)]
+				break
+			}
+		}
+	}
+	p.logger.Warn("switched the chain endpoint", "from", p.current.url, "to", best.url, "height", best.height)
+	p.current = best
+}
+
+// isEndpointError reports whether the error indicates the node is unreachable rather than the call is rejected. The
+// errors of the ctx are not, though context.DeadlineExceeded is a net.Error.
+func isEndpointError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == codes.Unavailable {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range []string{"connection refused", "connection reset", "no such host", "broken pipe"} {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
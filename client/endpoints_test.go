@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsEndpointError(t *testing.T) {
+	for _, err := range []error{
+		io.EOF,
+		fmt.Errorf("post failed: %w", io.ErrUnexpectedEOF),
+		status.Error(codes.Unavailable, "connection closed"),
+		errors.New("dial tcp 127.0.0.1:26657: connect: connection refused"),
+	} {
+		require.True(t, isEndpointError(err), err)
+	}
+
+	for _, err := range []error{
+		nil,
+		context.Canceled,
+		// context.DeadlineExceeded is a net.Error, the deadline of the call rather than the node is exceeded
+		context.DeadlineExceeded,
+		&url.Error{Op: "Post", URL: "http://127.0.0.1:26657", Err: context.DeadlineExceeded},
+		status.Error(codes.NotFound, "No such bucket"),
+		// the words of a rejected call are not mistaken for an end of file
+		errors.New("the bucket geofence is not found"),
+	} {
+		require.False(t, isEndpointError(err), err)
+	}
+}
+
+// currentEndpoint returns the node to call without checking the nodes.
+func currentEndpoint(p *endpointPool) *chainEndpoint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.current
+}
+
+func TestReportFailure(t *testing.T) {
+	a, b := &chainEndpoint{url: "a", healthy: true, height: 10}, &chainEndpoint{url: "b", healthy: true, height: 10}
+	p := &endpointPool{endpoints: []*chainEndpoint{a, b}, current: a, logger: NopLogger()}
+	ctx := context.Background()
+
+	// the node which served the call is marked, though it is not the current node any more
+	p.reportFailure(ctx, b, io.EOF)
+	require.False(t, b.healthy)
+	require.Same(t, a, currentEndpoint(p))
+	b.healthy = true
+
+	p.reportFailure(ctx, a, context.DeadlineExceeded)
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	p.reportFailure(canceled, a, io.EOF)
+	require.True(t, a.healthy, "the failures after the ctx is done are not reported")
+	require.Same(t, a, currentEndpoint(p))
+
+	p.reportFailure(ctx, a, io.EOF)
+	require.False(t, a.healthy)
+	require.Same(t, b, currentEndpoint(p))
+}
+
+func TestCallChainFailover(t *testing.T) {
+	slow, healthy := newTestChain(t), newTestChain(t)
+	slow.addBlock(testBlock{})
+	healthy.addBlock(testBlock{})
+	slow.handle("status", func(params map[string]json.RawMessage) (interface{}, error) {
+		time.Sleep(200 * time.Millisecond)
+		return slow.status(params)
+	})
+	cli, err := NewWithEndpoints(testChainID, []string{slow.srv.URL, healthy.srv.URL}, Option{})
+	require.NoError(t, err)
+	pool := cli.(*Client).endpoints
+	first := pool.endpoints[0]
+
+	// the short deadline of the call does not mark the node failed
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = cli.GetStatus(ctx)
+	require.Error(t, err)
+	require.Same(t, first, currentEndpoint(pool))
+
+	// the unreachable node is marked failed, and the calls go to the other node
+	slow.srv.Close()
+	_, err = cli.GetStatus(context.Background())
+	require.Error(t, err)
+	require.NotSame(t, first, currentEndpoint(pool))
+	_, err = cli.GetStatus(context.Background())
+	require.NoError(t, err)
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/evmos/evmos/v12/sdk/types"
+	gosdktypes "github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// defaultGasMultiplier is the gas multiplier used if AutoGasOption.GasMultiplier is not set.
+const defaultGasMultiplier = 1.0
+
+// autoGasEstimator estimates the gas limit and the fee of the transactions by simulation.
+type autoGasEstimator struct {
+	multiplier  float64
+	minGasLimit uint64
+	// gasPrice is nil if the minimum gas price of the chain is used.
+	gasPrice *sdk.DecCoin
+}
+
+func newAutoGasEstimator(opt AutoGasOption) (*autoGasEstimator, error) {
+	if opt.GasMultiplier < 0 || math.IsNaN(opt.GasMultiplier) || math.IsInf(opt.GasMultiplier, 0) {
+		return nil, fmt.Errorf("invalid gas multiplier %v", opt.GasMultiplier)
+	}
+	e := &autoGasEstimator{
+		multiplier:  opt.GasMultiplier,
+		minGasLimit: opt.MinGasLimit,
+	}
+	if e.multiplier == 0 {
+		e.multiplier = defaultGasMultiplier
+	}
+	if opt.GasPrice != "" {
+		gasPrice, err := sdk.ParseDecCoin(opt.GasPrice)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas price %s: %w", opt.GasPrice, err)
+		}
+		e.gasPrice = &gasPrice
+	}
+	return e, nil
+}
+
+// gasLimit returns the gas limit of the transaction which uses the gas in the simulation.
+func (e *autoGasEstimator) gasLimit(gasUsed uint64) uint64 {
+	gasLimit := uint64(math.Ceil(float64(gasUsed) * e.multiplier))
+	if gasLimit < e.minGasLimit {
+		gasLimit = e.minGasLimit
+	}
+	return gasLimit
+}
+
+// fee returns the fee of the gas limit, minGasPrice is the minimum gas price of the chain returned by the simulation.
+func (e *autoGasEstimator) fee(gasLimit uint64, minGasPrice string) (sdk.Coins, error) {
+	gasPrice := e.gasPrice
+	if gasPrice == nil {
+		if minGasPrice == "" {
+			return nil, errors.New("the minimum gas price of the chain is unknown, please set the gas price of AutoGasOption")
+		}
+		price, err := sdk.ParseDecCoin(minGasPrice)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum gas price %s of the chain: %w", minGasPrice, err)
+		}
+		gasPrice = &price
+	}
+	amount := gasPrice.Amount.MulInt64(int64(gasLimit)).Ceil().TruncateInt()
+	return sdk.NewCoins(sdk.NewCoin(gasPrice.Denom, amount)), nil
+}
+
+// broadcastTxWithGas broadcasts the transaction, its gas limit and fee are estimated first if AutoGas is enabled. The
+// transaction is broadcast to another node if the node is unreachable.
+func (c *Client) broadcastTxWithGas(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
+	txOpt, err := c.estimateGas(ctx, msgs, txOpt)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 1; ; attempt++ {
+		endpoint := c.endpoints.get()
+		resp, err := endpoint.client.BroadcastTx(ctx, msgs, txOpt, opts...)
+		if err == nil || attempt >= c.endpoints.size() || ctx.Err() != nil || !isEndpointError(err) {
+			return resp, err
+		}
+		c.logger.Warn("failed to broadcast the transaction, failing over", "endpoint", endpoint.url, "error", err)
+		c.endpoints.markFailed(endpoint)
+	}
+}
+
+// estimateGas returns the tx option whose gas limit and fee are estimated by simulation, the option of the caller is
+// not modified. The option is returned as it is if AutoGas is not enabled, or the gas limit or NoSimulate is set.
+func (c *Client) estimateGas(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption) (*types.TxOption, error) {
+	if c.autoGas == nil || (txOpt != nil && (txOpt.GasLimit != 0 || txOpt.NoSimulate)) {
+		return txOpt, nil
+	}
+	opt := types.TxOption{}
+	if txOpt != nil {
+		opt = *txOpt
+	}
+
+	simulateRes, err := c.chain().SimulateTx(ctx, msgs, &opt)
+	if err != nil {
+		return nil, &gosdktypes.SimulationError{Reason: simulationFailureReason(err), Err: err}
+	}
+	opt.GasLimit = c.autoGas.gasLimit(simulateRes.GasInfo.GetGasUsed())
+	if opt.FeeAmount.IsZero() {
+		if opt.FeeAmount, err = c.autoGas.fee(opt.GasLimit, simulateRes.GasInfo.GetMinGasPrice()); err != nil {
+			return nil, err
+		}
+	}
+	// the transaction has been simulated, the chain client does not need to simulate it again
+	opt.NoSimulate = true
+	c.logger.Debug("estimated the gas of the transaction", "gas_used", simulateRes.GasInfo.GetGasUsed(),
+		"gas_limit", opt.GasLimit, "fee", opt.FeeAmount.String())
+	return &opt, nil
+}
+
+// simulationFailureReason returns the readable reason of the simulation failure, the gRPC status is removed.
+func simulationFailureReason(err error) string {
+	reason := err.Error()
+	if s, ok := status.FromError(err); ok {
+		reason = s.Message()
+	}
+	return strings.TrimSpace(strings.TrimPrefix(reason, "failed to execute message;"))
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/gogoproto/proto"
+	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+func TestNewAutoGasEstimator(t *testing.T) {
+	e, err := newAutoGasEstimator(AutoGasOption{})
+	require.NoError(t, err)
+	require.Equal(t, defaultGasMultiplier, e.multiplier)
+	require.Nil(t, e.gasPrice, "the minimum gas price of the chain is used")
+
+	e, err = newAutoGasEstimator(AutoGasOption{GasMultiplier: 1.5, MinGasLimit: 100, GasPrice: "0.5azkme"})
+	require.NoError(t, err)
+	require.Equal(t, 1.5, e.multiplier)
+	require.Equal(t, uint64(100), e.minGasLimit)
+	require.Equal(t, "0.500000000000000000azkme", e.gasPrice.String())
+
+	for _, opt := range []AutoGasOption{
+		{GasMultiplier: -1},
+		{GasMultiplier: math.NaN()},
+		{GasMultiplier: math.Inf(1)},
+		{GasPrice: "azkme"},
+		{GasPrice: "-1azkme"},
+		{GasPrice: "5000000000"},
+	} {
+		_, err = newAutoGasEstimator(opt)
+		require.Error(t, err, opt)
+	}
+}
+
+func TestAutoGasEstimatorGasLimit(t *testing.T) {
+	e := &autoGasEstimator{multiplier: 1.5, minGasLimit: 1000}
+	require.Equal(t, uint64(1000), e.gasLimit(0), "the gas limit is not below the floor")
+	require.Equal(t, uint64(1000), e.gasLimit(600))
+	require.Equal(t, uint64(1500), e.gasLimit(1000))
+	require.Equal(t, uint64(1502), e.gasLimit(1001), "the gas limit is rounded up")
+
+	e = &autoGasEstimator{multiplier: defaultGasMultiplier}
+	require.Equal(t, uint64(123456), e.gasLimit(123456))
+}
+
+func TestAutoGasEstimatorFee(t *testing.T) {
+	e := &autoGasEstimator{multiplier: defaultGasMultiplier}
+	fee, err := e.fee(1000, "5azkme")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("azkme", 5000)), fee)
+	fee, err = e.fee(3, "0.5azkme")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("azkme", 2)), fee, "the fee is rounded up")
+
+	_, err = e.fee(1000, "")
+	require.ErrorContains(t, err, "minimum gas price of the chain is unknown")
+	_, err = e.fee(1000, "azkme")
+	require.ErrorContains(t, err, "invalid minimum gas price")
+
+	// the configured gas price takes precedence over the minimum gas price of the chain
+	gasPrice := sdk.NewInt64DecCoin("azkme", 7)
+	e.gasPrice = &gasPrice
+	fee, err = e.fee(1000, "5azkme")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("azkme", 7000)), fee)
+	fee, err = e.fee(1000, "")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("azkme", 7000)), fee)
+}
+
+func TestEstimateGasSkips(t *testing.T) {
+	ctx := context.Background()
+	// the Client has no node, the estimation would fail if it simulated the transaction
+	c := &Client{autoGas: &autoGasEstimator{multiplier: defaultGasMultiplier}}
+	for _, txOpt := range []*gnfdSdkTypes.TxOption{
+		{GasLimit: 1000},
+		{NoSimulate: true},
+	} {
+		opt, err := c.estimateGas(ctx, nil, txOpt)
+		require.NoError(t, err)
+		require.Same(t, txOpt, opt)
+	}
+
+	c.autoGas = nil
+	txOpt := &gnfdSdkTypes.TxOption{}
+	opt, err := c.estimateGas(ctx, nil, txOpt)
+	require.NoError(t, err)
+	require.Same(t, txOpt, opt, "the gas is not estimated if AutoGas is not enabled")
+	opt, err = c.estimateGas(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, opt)
+}
+
+func TestBuildUnsignedTxEstimatesGas(t *testing.T) {
+	account, _, err := types.NewAccount("account")
+	require.NoError(t, err)
+	chain, cli, msgs := newOfflineTestClient(t, account)
+	chain.handleQuery("/cosmos.tx.v1beta1.Service/Simulate", func(data []byte) (proto.Message, error) {
+		return &tx.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 1000, MinGasPrice: "5azkme"}}, nil
+	})
+	cli.autoGas = &autoGasEstimator{multiplier: 1.5}
+
+	unsignedTx, _, err := cli.BuildUnsignedTx(context.Background(), msgs, gnfdSdkTypes.TxOption{})
+	require.NoError(t, err)
+	decodedTx, err := newTxConfig(gnfdSdkTypes.Codec()).TxJSONDecoder()(unsignedTx)
+	require.NoError(t, err)
+	feeTx := decodedTx.(sdk.FeeTx)
+	require.Equal(t, uint64(1500), feeTx.GetGas())
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("azkme", 7500)), feeTx.GetFee())
+	require.Equal(t, 1, chain.callCount("/cosmos.tx.v1beta1.Service/Simulate"))
+
+	// the gas limit of the caller is respected
+	unsignedTx, _, err = cli.BuildUnsignedTx(context.Background(), msgs, testTxOption)
+	require.NoError(t, err)
+	decodedTx, err = newTxConfig(gnfdSdkTypes.Codec()).TxJSONDecoder()(unsignedTx)
+	require.NoError(t, err)
+	require.Equal(t, testTxOption.GasLimit, decodedTx.(sdk.FeeTx).GetGas())
+	require.Equal(t, 1, chain.callCount("/cosmos.tx.v1beta1.Service/Simulate"))
+}
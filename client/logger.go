@@ -0,0 +1,66 @@
+package client
+
+import (
+	"log/slog"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Logger - The structured logger used by the Client.
+//
+// The keysAndValues are alternating keys and values, the keys are strings. The method set is the same as the one of
+// *slog.Logger, so a *slog.Logger can be used as a Logger directly.
+//
+// The Client never passes private keys, signatures or authorization headers to the Logger.
+type Logger interface {
+	Debug(msg string, keysAndValues ...any)
+	Info(msg string, keysAndValues ...any)
+	Warn(msg string, keysAndValues ...any)
+	Error(msg string, keysAndValues ...any)
+}
+
+// NewSlogLogger - Create a Logger which writes the logs of the Client to the slog logger.
+//
+// - logger: The slog logger, slog.Default() is used if it is nil.
+//
+// - ret: The Logger to be set in Option.Logger.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return logger
+}
+
+// NopLogger - Create a Logger which discards all the logs of the Client.
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+
+func (nopLogger) Info(string, ...any) {}
+
+func (nopLogger) Warn(string, ...any) {}
+
+func (nopLogger) Error(string, ...any) {}
+
+// zerologLogger is the Logger used when Option.Logger is not set, it writes to the global zerolog logger.
+type zerologLogger struct{}
+
+func (zerologLogger) Debug(msg string, keysAndValues ...any) {
+	log.Debug().Fields(keysAndValues).Msg(msg)
+}
+
+func (zerologLogger) Info(msg string, keysAndValues ...any) {
+	log.Info().Fields(keysAndValues).Msg(msg)
+}
+
+func (zerologLogger) Warn(msg string, keysAndValues ...any) {
+	log.Warn().Fields(keysAndValues).Msg(msg)
+}
+
+func (zerologLogger) Error(msg string, keysAndValues ...any) {
+	log.Error().Fields(keysAndValues).Msg(msg)
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The names of the metrics reported by the Client to its MetricsSink.
+const (
+	// MetricSPRequestsTotal counts the requests sent to SP, labeled by sp, operation, method and status.
+	// The status label is the HTTP status code, or "error" if no response has been received.
+	MetricSPRequestsTotal = "sp_requests_total"
+	// MetricSPRequestDuration observes the latency in seconds of the requests sent to SP, labeled by sp, operation and method.
+	MetricSPRequestDuration = "sp_request_duration_seconds"
+	// MetricSPBytesOutTotal counts the bytes of the request bodies sent to SP, labeled by sp, operation and method.
+	MetricSPBytesOutTotal = "sp_bytes_out_total"
+	// MetricSPBytesInTotal counts the bytes of the response bodies received from SP, labeled by sp, operation and method.
+	MetricSPBytesInTotal = "sp_bytes_in_total"
+	// MetricTxBroadcastTotal counts the broadcast transactions, labeled by outcome, codespace and code.
+	// The outcome label is one of "success", "failed"(rejected by the chain) and "error"(the broadcast did not complete).
+	MetricTxBroadcastTotal = "tx_broadcast_total"
+	// MetricWaitForTxDuration observes the time in seconds spent in WaitForTx, labeled by outcome.
+	MetricWaitForTxDuration = "wait_for_tx_duration_seconds"
+	// MetricWaitForTxRetries observes the number of retries of the tx query made by WaitForTx, labeled by outcome.
+	MetricWaitForTxRetries = "wait_for_tx_retries"
+)
+
+// The metric label names used by the Client.
+const (
+	LabelSP        = "sp"
+	LabelOperation = "operation"
+	LabelMethod    = "method"
+	LabelStatus    = "status"
+	LabelOutcome   = "outcome"
+	LabelCodespace = "codespace"
+	LabelCode      = "code"
+)
+
+// The values of the outcome label.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailed  = "failed"
+	OutcomeError   = "error"
+)
+
+// MetricsSink - The receiver of the counters and histograms produced by the Client.
+//
+// A metric is always reported with the same set of label names, so that the implementation can register it lazily
+// on its first observation. The implementation must be safe for concurrent use.
+type MetricsSink interface {
+	// IncCounter adds the value to the counter identified by name and labels.
+	IncCounter(name string, value float64, labels map[string]string)
+	// ObserveHistogram records the value in the histogram identified by name and labels.
+	ObserveHistogram(name string, value float64, labels map[string]string)
+}
+
+// nopMetrics is the MetricsSink used when Option.Metrics is not set.
+type nopMetrics struct{}
+
+func (nopMetrics) IncCounter(string, float64, map[string]string) {}
+
+func (nopMetrics) ObserveHistogram(string, float64, map[string]string) {}
+
+// operationCtxKey is the context key of the name of the high-level Client operation in progress.
+type operationCtxKey struct{}
+
+// withOperation returns a context which carries the name of the high-level Client operation.
+func withOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationCtxKey{}, name)
+}
+
+// operationFromContext returns the name of the high-level Client operation carried by ctx, "unknown" if there is none.
+func operationFromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(operationCtxKey{}).(string); ok {
+		return name
+	}
+	return "unknown"
+}
+
+// recordSPRequest reports the metrics of a single request sent to SP.
+// The status is the HTTP status code of the response, 0 if no response has been received. The bytesIn is the number
+// of the bytes read from the body of the response rather than its content length, which is -1 if it is unknown.
+func (c *Client) recordSPRequest(ctx context.Context, method string, endpoint *url.URL, status int, start time.Time, bytesOut, bytesIn int64) {
+	var sp string
+	if endpoint != nil {
+		sp = endpoint.Host
+	}
+	labels := map[string]string{
+		LabelSP:        sp,
+		LabelOperation: operationFromContext(ctx),
+		LabelMethod:    method,
+	}
+	c.metrics.ObserveHistogram(MetricSPRequestDuration, time.Since(start).Seconds(), labels)
+	if bytesOut > 0 {
+		c.metrics.IncCounter(MetricSPBytesOutTotal, float64(bytesOut), labels)
+	}
+	if bytesIn > 0 {
+		c.metrics.IncCounter(MetricSPBytesInTotal, float64(bytesIn), labels)
+	}
+
+	statusLabel := OutcomeError
+	if status != 0 {
+		statusLabel = strconv.Itoa(status)
+	}
+	c.metrics.IncCounter(MetricSPRequestsTotal, 1, map[string]string{
+		LabelSP:        sp,
+		LabelOperation: labels[LabelOperation],
+		LabelMethod:    method,
+		LabelStatus:    statusLabel,
+	})
+}
+
+// recordTxBroadcast reports the outcome of a transaction broadcast, resp is nil if the broadcast did not complete.
+func (c *Client) recordTxBroadcast(resp *sdk.TxResponse) {
+	labels := map[string]string{
+		LabelOutcome:   OutcomeError,
+		LabelCodespace: "",
+		LabelCode:      "",
+	}
+	if resp != nil {
+		labels[LabelOutcome] = OutcomeSuccess
+		if resp.Code != 0 {
+			labels[LabelOutcome] = OutcomeFailed
+		}
+		labels[LabelCodespace] = resp.Codespace
+		labels[LabelCode] = strconv.FormatUint(uint64(resp.Code), 10)
+	}
+	c.metrics.IncCounter(MetricTxBroadcastTotal, 1, labels)
+}
+
+// recordWaitForTx reports the duration and the retry count of a WaitForTx call.
+func (c *Client) recordWaitForTx(start time.Time, retries int, err error) {
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeError
+	}
+	labels := map[string]string{LabelOutcome: outcome}
+	c.metrics.ObserveHistogram(MetricWaitForTxDuration, time.Since(start).Seconds(), labels)
+	c.metrics.ObserveHistogram(MetricWaitForTxRetries, float64(retries), labels)
+}
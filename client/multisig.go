@@ -0,0 +1,177 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	cosmosclient "github.com/cosmos/cosmos-sdk/client"
+	cryptomultisig "github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// SignMultisigTx - Sign the unsigned transaction of the multisig account by one of its members, it does not access
+// the network so that it can run on an air-gapped machine.
+//
+// - unsignedTx: The unsigned transaction returned by BuildUnsignedTx, its signer should be the multisig account.
+//
+// - signerData: The signer data of the unsigned transaction returned by BuildUnsignedTx.
+//
+// - multisig: The multisig account which sends the transaction.
+//
+// - member: The member of the multisig account who signs the transaction.
+//
+// - ret1: The transaction partially signed by the member in the JSON format of the Cosmos CLI sign output, the
+// partial signatures of the members are aggregated by AggregateMultisigTx.
+//
+// - ret2: Return error when the transaction can not be signed by the member, otherwise return nil.
+func SignMultisigTx(unsignedTx []byte, signerData *types.TxSignerData, multisig *types.MultisigAccount, member *types.Account) ([]byte, error) {
+	txConfig := newTxConfig(gnfdSdkTypes.Codec())
+	txBuilder, err := decodeUnsignedTx(txConfig, unsignedTx, signerData)
+	if err != nil {
+		return nil, err
+	}
+	if signerData.Signer != multisig.GetAddress().String() {
+		return nil, fmt.Errorf("the transaction should be signed by %s rather than the multisig account %s", signerData.Signer, multisig.GetAddress().String())
+	}
+	if !multisig.IsMember(member.GetAddress()) {
+		return nil, fmt.Errorf("%s is not a member of the multisig account %s", member.GetAddress().String(), multisig.GetAddress().String())
+	}
+
+	signBytes, err := multisigSignBytes(txConfig, signerData, txBuilder, multisig)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := member.SignTx(signBytes)
+	if err != nil {
+		return nil, err
+	}
+	// the partially signed transaction carries the signature of the member with its public key
+	if err = txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   member.GetPubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: txSignMode, Signature: signature},
+		Sequence: signerData.Sequence,
+	}); err != nil {
+		return nil, err
+	}
+	return txConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// multisigSignBytes returns the bytes signed by the members of the multisig account. Like the Cosmos CLI sign
+// --multisig, the signer info of the multisig account is set to the transaction, and the bytes are computed with the
+// signer data of the multisig account, which are what the chain verifies the aggregated signature with.
+func multisigSignBytes(txConfig cosmosclient.TxConfig, signerData *types.TxSignerData, txBuilder cosmosclient.TxBuilder,
+	multisig *types.MultisigAccount,
+) ([]byte, error) {
+	if err := txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   multisig.GetPubKey(),
+		Data:     cryptomultisig.NewMultisig(len(multisig.GetMemberPubKeys())),
+		Sequence: signerData.Sequence,
+	}); err != nil {
+		return nil, err
+	}
+	return unsignedTxSignBytes(txConfig, signerData, txBuilder, multisig.GetPubKey())
+}
+
+// AggregateMultisigTx - Aggregate the partial signatures of the members into the signature of the multisig account.
+//
+// - unsignedTx: The unsigned transaction returned by BuildUnsignedTx, its signer should be the multisig account.
+//
+// - signerData: The signer data of the unsigned transaction returned by BuildUnsignedTx.
+//
+// - multisig: The multisig account which sends the transaction.
+//
+// - partialTxs: The transactions partially signed by the members, which are returned by SignMultisigTx.
+//
+// - ret1: The signed transaction in the JSON format of the Cosmos CLI multisign output, it can be broadcast by
+// BroadcastSignedTx.
+//
+// - ret2: Return error when a partial signature is invalid, or the signatures do not reach the threshold.
+func AggregateMultisigTx(unsignedTx []byte, signerData *types.TxSignerData, multisig *types.MultisigAccount, partialTxs ...[]byte) ([]byte, error) {
+	txConfig := newTxConfig(gnfdSdkTypes.Codec())
+	txBuilder, err := decodeUnsignedTx(txConfig, unsignedTx, signerData)
+	if err != nil {
+		return nil, err
+	}
+	if signerData.Signer != multisig.GetAddress().String() {
+		return nil, fmt.Errorf("the transaction should be signed by %s rather than the multisig account %s", signerData.Signer, multisig.GetAddress().String())
+	}
+
+	signBytes, err := multisigSignBytes(txConfig, signerData, txBuilder, multisig)
+	if err != nil {
+		return nil, err
+	}
+	pubKeys := multisig.GetMemberPubKeys()
+	multisigSig := cryptomultisig.NewMultisig(len(pubKeys))
+	signed := make(map[string]bool)
+	for _, partialTx := range partialTxs {
+		sigs, err := partialSignatures(txConfig, partialTx)
+		if err != nil {
+			return nil, err
+		}
+		for _, sig := range sigs {
+			addr := sdk.AccAddress(sig.PubKey.Address())
+			if !multisig.IsMember(addr) {
+				return nil, fmt.Errorf("%s is not a member of the multisig account %s", addr.String(), multisig.GetAddress().String())
+			}
+			if signed[addr.String()] {
+				continue
+			}
+			if err = verifyPartialSignature(signBytes, signerData, sig); err != nil {
+				return nil, fmt.Errorf("invalid signature of %s: %w", addr.String(), err)
+			}
+			if err = cryptomultisig.AddSignatureV2(multisigSig, sig, pubKeys); err != nil {
+				return nil, err
+			}
+			signed[addr.String()] = true
+		}
+	}
+	if len(signed) < multisig.GetThreshold() {
+		return nil, fmt.Errorf("the transaction is signed by %d members, the threshold of the multisig account is %d", len(signed), multisig.GetThreshold())
+	}
+
+	if err = txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   multisig.GetPubKey(),
+		Data:     multisigSig,
+		Sequence: signerData.Sequence,
+	}); err != nil {
+		return nil, err
+	}
+	return txConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// partialSignatures returns the signatures of the partially signed transaction.
+func partialSignatures(txConfig cosmosclient.TxConfig, partialTx []byte) ([]signing.SignatureV2, error) {
+	decodedTx, err := txConfig.TxJSONDecoder()(partialTx)
+	if err != nil {
+		return nil, err
+	}
+	sigTx, ok := decodedTx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return nil, errors.New("the partially signed transaction has no signatures")
+	}
+	return sigTx.GetSignaturesV2()
+}
+
+// verifyPartialSignature verifies the partial signature of a member against the bytes signed by the members, so that
+// the signatures of another transaction are rejected.
+func verifyPartialSignature(signBytes []byte, signerData *types.TxSignerData, sig signing.SignatureV2) error {
+	if sig.Sequence != signerData.Sequence {
+		return fmt.Errorf("the sequence %d does not match the sequence %d of the transaction", sig.Sequence, signerData.Sequence)
+	}
+	data, ok := sig.Data.(*signing.SingleSignatureData)
+	if !ok {
+		return errors.New("not a single signature")
+	}
+	if data.SignMode != txSignMode {
+		return fmt.Errorf("the sign mode %s is not %s", data.SignMode, txSignMode)
+	}
+	if !sig.PubKey.VerifySignature(signBytes, data.Signature) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"testing"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	gnfdSdkTypes "github.com/evmos/evmos/v12/sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// newMultisigTestTx returns a 2-of-3 multisig account, its members, and an unsigned transaction of the account.
+func newMultisigTestTx(t *testing.T) (*types.MultisigAccount, []*types.Account, []byte, *types.TxSignerData) {
+	var (
+		members []*types.Account
+		pubKeys []cryptotypes.PubKey
+	)
+	for i := 0; i < 3; i++ {
+		member, _, err := types.NewAccount("member")
+		require.NoError(t, err)
+		members = append(members, member)
+		pubKeys = append(pubKeys, member.GetPubKey())
+	}
+	multisig, err := types.NewMultisigAccount("multisig", 2, pubKeys)
+	require.NoError(t, err)
+
+	txConfig := newTxConfig(gnfdSdkTypes.Codec())
+	txBuilder := txConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(bankTypes.NewMsgSend(multisig.GetAddress(), members[0].GetAddress(),
+		sdk.NewCoins(sdk.NewInt64Coin(gnfdSdkTypes.Denom, 1)))))
+	txBuilder.SetGasLimit(200000)
+	txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(gnfdSdkTypes.Denom, 1000)))
+	unsignedTx, err := txConfig.TxJSONEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+
+	return multisig, members, unsignedTx, &types.TxSignerData{
+		ChainID:       "mechain_5151-1",
+		AccountNumber: 7,
+		Sequence:      0,
+		Signer:        multisig.GetAddress().String(),
+	}
+}
+
+func TestAggregateMultisigTx(t *testing.T) {
+	multisig, members, unsignedTx, signerData := newMultisigTestTx(t)
+
+	partial0, err := SignMultisigTx(unsignedTx, signerData, multisig, members[0])
+	require.NoError(t, err)
+	partial2, err := SignMultisigTx(unsignedTx, signerData, multisig, members[2])
+	require.NoError(t, err)
+	signedTx, err := AggregateMultisigTx(unsignedTx, signerData, multisig, partial0, partial2)
+	require.NoError(t, err)
+
+	// the aggregated signature is verified the way the ante handler of the chain does
+	txConfig := newTxConfig(gnfdSdkTypes.Codec())
+	decodedTx, err := txConfig.TxJSONDecoder()(signedTx)
+	require.NoError(t, err)
+	sigTx, ok := decodedTx.(authsigning.SigVerifiableTx)
+	require.True(t, ok)
+	sigs, err := sigTx.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.True(t, sigs[0].PubKey.Equals(multisig.GetPubKey()))
+	require.NoError(t, authsigning.VerifySignature(multisig.GetPubKey(), authsigning.SignerData{
+		Address:       signerData.Signer,
+		ChainID:       signerData.ChainID,
+		AccountNumber: signerData.AccountNumber,
+		Sequence:      signerData.Sequence,
+		PubKey:        multisig.GetPubKey(),
+	}, sigs[0].Data, txConfig.SignModeHandler(), decodedTx))
+}
+
+func TestAggregateMultisigTxRejects(t *testing.T) {
+	multisig, members, unsignedTx, signerData := newMultisigTestTx(t)
+	partial0, err := SignMultisigTx(unsignedTx, signerData, multisig, members[0])
+	require.NoError(t, err)
+
+	_, err = AggregateMultisigTx(unsignedTx, signerData, multisig, partial0)
+	require.Error(t, err, "the threshold is not reached")
+
+	// the partial signature of the same transaction with another sequence is rejected
+	other := *signerData
+	other.Sequence++
+	partial1, err := SignMultisigTx(unsignedTx, &other, multisig, members[1])
+	require.NoError(t, err)
+	_, err = AggregateMultisigTx(unsignedTx, signerData, multisig, partial0, partial1)
+	require.Error(t, err)
+
+	outsider, _, err := types.NewAccount("outsider")
+	require.NoError(t, err)
+	_, err = SignMultisigTx(unsignedTx, signerData, multisig, outsider)
+	require.Error(t, err)
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"google.golang.org/grpc"
+
+	"github.com/evmos/evmos/v12/sdk/types"
+)
+
+// maxSequenceRetries is the max times of re-broadcasting a transaction after the sequence is resynchronized.
+const maxSequenceRetries = 3
+
+// sequenceMismatchRegexp matches the sequence expected by the chain in the log of the sequence mismatch, e.g.
+// "account sequence mismatch, expected 12, got 10: incorrect account sequence".
+var sequenceMismatchRegexp = regexp.MustCompile(`account sequence mismatch, expected (\d+), got (\d+)`)
+
+// sequenceContextKey is the context key of the sequence set by WithSequence.
+type sequenceContextKey struct{}
+
+// WithSequence - Return a copy of ctx which makes the transactions use the given sequence instead of the one handed
+// out by the Client.
+//
+// Unlike TxOption.Nonce, whose zero value means the sequence is not set, the sequence 0 of an account which has not
+// sent any transaction can be set.
+//
+// - ctx: The parent context.
+//
+// - sequence: The sequence of the transactions.
+//
+// - ret: The context carrying the sequence.
+func WithSequence(ctx context.Context, sequence uint64) context.Context {
+	return context.WithValue(ctx, sequenceContextKey{}, sequence)
+}
+
+// SequenceFromContext - Get the sequence set by WithSequence.
+//
+// - ctx: The context of the API call.
+//
+// - ret1: The sequence set by WithSequence.
+//
+// - ret2: Whether a sequence has been set.
+func SequenceFromContext(ctx context.Context) (uint64, bool) {
+	sequence, ok := ctx.Value(sequenceContextKey{}).(uint64)
+	return sequence, ok
+}
+
+// sequenceManager hands out the sequences of the transactions locally, so that the transactions of an account
+// broadcast concurrently do not query and use the same sequence from the chain.
+type sequenceManager struct {
+	mu       sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+// accountSequence is the next sequence of an account. The lock is held while a transaction of the account is being
+// broadcast, so that the transactions reach the mempool in the order of their sequences.
+type accountSequence struct {
+	mu     sync.Mutex
+	next   uint64
+	synced bool
+}
+
+func newSequenceManager() *sequenceManager {
+	return &sequenceManager{accounts: make(map[string]*accountSequence)}
+}
+
+// get returns the sequence of the account, it is created unsynchronized on the first use.
+func (m *sequenceManager) get(addr sdk.AccAddress) *accountSequence {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	seq, ok := m.accounts[addr.String()]
+	if !ok {
+		seq = &accountSequence{}
+		m.accounts[addr.String()] = seq
+	}
+	return seq
+}
+
+// broadcastTx broadcasts the transaction with the sequence handed out by the sequence manager. If the chain rejects
+// the sequence, it is resynchronized from the chain and the transaction is broadcast again.
+//
+// The sequence manager is bypassed if the sequence is set by WithSequence or txOpt.Nonce, or the signer of the
+// transaction is unknown. The chain client resolves the sequence 0 set by WithSequence from the chain, which is 0
+// as long as the account has not sent any transaction.
+func (c *Client) broadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
+	if sequence, ok := SequenceFromContext(ctx); ok {
+		opt := types.TxOption{}
+		if txOpt != nil {
+			opt = *txOpt
+		}
+		opt.Nonce = sequence
+		return c.broadcastTxWithGas(ctx, msgs, &opt, opts...)
+	}
+	signer := c.txSigner(txOpt)
+	if signer == nil || (txOpt != nil && txOpt.Nonce != 0) {
+		return c.broadcastTxWithGas(ctx, msgs, txOpt, opts...)
+	}
+
+	seq := c.sequences.get(signer)
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+
+	opt := types.TxOption{}
+	if txOpt != nil {
+		opt = *txOpt
+	}
+	for retries := 0; ; retries++ {
+		if !seq.synced {
+			account, err := c.GetAccount(ctx, signer.String())
+			if err != nil {
+				return nil, err
+			}
+			seq.next = account.GetSequence()
+			seq.synced = true
+		}
+
+		opt.Nonce = seq.next
+		resp, err := c.broadcastTxWithGas(ctx, msgs, &opt, opts...)
+		if err == nil && resp.TxResponse.Code == 0 {
+			seq.next++
+			return resp, nil
+		}
+
+		// the sequence expected by the chain counts the pending transactions in the mempool, while the sequence of
+		// the account queried from the chain counts the committed ones only, so the expected one is preferred. The
+		// sequence may have been consumed if the tx failed after the CheckTx, or its state is unknown, so it is
+		// resynchronized from the chain before the next transaction otherwise.
+		mismatch := isSequenceMismatch(resp, err)
+		if expected, ok := expectedSequence(resp, err); mismatch && ok {
+			seq.next = expected
+		} else {
+			seq.synced = false
+		}
+		if !mismatch || retries >= maxSequenceRetries {
+			return resp, err
+		}
+		c.logger.Warn("account sequence mismatch, resync the sequence and retry",
+			"address", signer.String(), "sequence", opt.Nonce, "expected", seq.next, "synced", seq.synced, "retries", retries)
+	}
+}
+
+// txSigner returns the address of the account which signs the transaction with the tx option.
+func (c *Client) txSigner(txOpt *types.TxOption) sdk.AccAddress {
+	if txOpt != nil && txOpt.OverrideKeyManager != nil {
+		return (*txOpt.OverrideKeyManager).GetAddr()
+	}
+	if c.defaultAccount != nil {
+		return c.defaultAccount.GetAddress()
+	}
+	return nil
+}
+
+// isSequenceMismatch returns whether the transaction is rejected because of the account sequence mismatch.
+func isSequenceMismatch(resp *tx.BroadcastTxResponse, err error) bool {
+	if resp != nil && resp.TxResponse != nil && resp.TxResponse.Codespace == sdkerrors.ErrWrongSequence.Codespace() &&
+		resp.TxResponse.Code == sdkerrors.ErrWrongSequence.ABCICode() {
+		return true
+	}
+	return err != nil && (errors.Is(err, sdkerrors.ErrWrongSequence) || strings.Contains(err.Error(), "account sequence mismatch"))
+}
+
+// expectedSequence returns the sequence expected by the chain in the log of the sequence mismatch.
+func expectedSequence(resp *tx.BroadcastTxResponse, err error) (uint64, bool) {
+	var logs []string
+	if resp != nil && resp.TxResponse != nil {
+		logs = append(logs, resp.TxResponse.RawLog)
+	}
+	if err != nil {
+		logs = append(logs, err.Error())
+	}
+	for _, log := range logs {
+		if m := sequenceMismatchRegexp.FindStringSubmatch(log); m != nil {
+			if sequence, err := strconv.ParseUint(m[1], 10, 64); err == nil {
+				return sequence, true
+			}
+		}
+	}
+	return 0, false
+}
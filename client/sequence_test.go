@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedSequence(t *testing.T) {
+	resp := &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{
+		Codespace: sdkerrors.ErrWrongSequence.Codespace(),
+		Code:      sdkerrors.ErrWrongSequence.ABCICode(),
+		RawLog:    "account sequence mismatch, expected 12, got 10: incorrect account sequence",
+	}}
+	require.True(t, isSequenceMismatch(resp, nil))
+	sequence, ok := expectedSequence(resp, nil)
+	require.True(t, ok)
+	require.Equal(t, uint64(12), sequence)
+
+	err := errors.New("rpc error: code = Unknown desc = account sequence mismatch, expected 3, got 0: incorrect account sequence")
+	require.True(t, isSequenceMismatch(nil, err))
+	sequence, ok = expectedSequence(nil, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(3), sequence)
+
+	_, ok = expectedSequence(&tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{RawLog: "out of gas"}}, nil)
+	require.False(t, ok)
+}
+
+func TestWithSequence(t *testing.T) {
+	_, ok := SequenceFromContext(context.Background())
+	require.False(t, ok)
+
+	sequence, ok := SequenceFromContext(WithSequence(context.Background(), 0))
+	require.True(t, ok, "the sequence 0 can be set explicitly")
+	require.Equal(t, uint64(0), sequence)
+}
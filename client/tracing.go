@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	sdkclient "github.com/evmos/evmos/v12/sdk/client"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// tracerName is the instrumentation scope name of the spans created by the Client.
+const tracerName = "github.com/zkMeLabs/mechain-go-sdk/client"
+
+// The attribute keys attached to the spans created by the Client.
+const (
+	AttrBucketName = attribute.Key("mechain.bucket_name")
+	AttrObjectName = attribute.Key("mechain.object_name")
+	AttrGroupName  = attribute.Key("mechain.group_name")
+	AttrSPID       = attribute.Key("mechain.sp_id")
+	AttrSPEndpoint = attribute.Key("mechain.sp_endpoint")
+	AttrTxHash     = attribute.Key("mechain.tx_hash")
+	AttrTxCode     = attribute.Key("mechain.tx_code")
+	AttrMsgCount   = attribute.Key("mechain.msg_count")
+	AttrHeight     = attribute.Key("mechain.block_height")
+	AttrBytesOut   = attribute.Key("mechain.bytes_out")
+	AttrBytesIn    = attribute.Key("mechain.bytes_in")
+	AttrHTTPMethod = attribute.Key("http.method")
+	AttrHTTPURL    = attribute.Key("http.url")
+	AttrHTTPStatus = attribute.Key("http.status_code")
+	AttrRPCMethod  = attribute.Key("rpc.method")
+)
+
+// startSpan starts a span for a high-level Client operation, it returns the context carrying the new span and the
+// operation name.
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return c.tracer.Start(withOperation(ctx, name), name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records the error(if any) to the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// bucketAttrs returns the span attributes that identify a bucket or an object.
+func bucketAttrs(bucketName, objectName string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{AttrBucketName.String(bucketName)}
+	if objectName != "" {
+		attrs = append(attrs, AttrObjectName.String(objectName))
+	}
+	return attrs
+}
+
+// startSPSpan starts a client span for a single HTTP request sent to a storage provider.
+func (c *Client) startSPSpan(ctx context.Context, method string, meta requestMeta, endpoint *url.URL) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		AttrHTTPMethod.String(method),
+		AttrSPEndpoint.String(endpoint.Host),
+		AttrBytesOut.Int64(meta.contentLength),
+	}
+	if meta.bucketName != "" {
+		attrs = append(attrs, bucketAttrs(meta.bucketName, meta.objectName)...)
+	}
+	if sp := c.spByEndpoint(endpoint); sp != nil {
+		attrs = append(attrs, AttrSPID.Int64(int64(sp.Id)))
+	}
+	return c.tracer.Start(ctx, "sp."+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// spanBody wraps the body of a SP response, it counts the bytes read from the body and calls done once when the body
+// is closed, so that the span of a request whose body is streamed to the caller covers the reading of the body and
+// records the bytes actually received, the content length is -1 for the chunked responses.
+type spanBody struct {
+	io.ReadCloser
+	status  int
+	err     error
+	bytesIn int64
+	once    sync.Once
+	done    func(status int, bytesIn int64, err error)
+}
+
+// attach wraps the body of the response.
+func (b *spanBody) attach(resp *http.Response) {
+	b.status = resp.StatusCode
+	b.ReadCloser = resp.Body
+	resp.Body = b
+}
+
+func (b *spanBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.bytesIn += int64(n)
+	return n, err
+}
+
+func (b *spanBody) Close() error {
+	var err error
+	if b.ReadCloser != nil {
+		err = b.ReadCloser.Close()
+	}
+	b.finish()
+	return err
+}
+
+// finish calls done if it has not been called, it is called directly if no response has been received.
+func (b *spanBody) finish() {
+	b.once.Do(func() {
+		b.done(b.status, b.bytesIn, b.err)
+	})
+}
+
+// spByEndpoint returns the storage provider which serves the given endpoint, nil if it is unknown.
+func (c *Client) spByEndpoint(endpoint *url.URL) *types.StorageProvider {
+	if endpoint == nil {
+		return nil
+	}
+	for _, sp := range c.storageProviders {
+		if sp.EndPoint != nil && sp.EndPoint.Host == endpoint.Host {
+			return sp
+		}
+	}
+	return nil
+}
+
+// injectTraceContext propagates the trace context of ctx into the headers of the SP request.
+func (c *Client) injectTraceContext(ctx context.Context, req *http.Request) {
+	c.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// startChainSpan starts a client span for a call to the Mechain blockchain.
+func (c *Client) startChainSpan(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, AttrRPCMethod.String(method))
+	return c.tracer.Start(ctx, "chain."+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// queryChain invokes a gRPC query of the chain client of the node to call inside a span named after the query method,
+// the state at the height set by WithHeight is queried if there is one. The query is a method expression of the chain
+// client, e.g. (*sdkclient.MechainClient).HeadBucket, so that the failure is reported against the node which served it.
+func queryChain[Req, Resp any](ctx context.Context, c *Client, method string,
+	query func(*sdkclient.MechainClient, context.Context, Req, ...grpc.CallOption) (Resp, error), req Req, opts ...grpc.CallOption,
+) (Resp, error) {
+	var attrs []attribute.KeyValue
+	if height, ok := HeightFromContext(ctx); ok {
+		attrs = append(attrs, AttrHeight.Int64(height))
+	}
+	ctx, span := c.startChainSpan(withHeightMetadata(ctx), method, attrs...)
+	endpoint := c.endpoints.get()
+	resp, err := query(endpoint.client, ctx, req, opts...)
+	c.endpoints.reportFailure(ctx, endpoint, err)
+	err = types.WrapChainError(err)
+	endSpan(span, err)
+	return resp, err
+}
+
+// callChain invokes a call to the node to call which is not a gRPC query inside a span named after the method.
+func callChain[Resp any](ctx context.Context, c *Client, method string,
+	call func(ctx context.Context, endpoint *chainEndpoint) (Resp, error),
+) (Resp, error) {
+	ctx, span := c.startChainSpan(ctx, method)
+	endpoint := c.endpoints.get()
+	resp, err := call(ctx, endpoint)
+	c.endpoints.reportFailure(ctx, endpoint, err)
+	err = types.WrapChainError(err)
+	endSpan(span, err)
+	return resp, err
+}
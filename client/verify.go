@@ -0,0 +1,285 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	dbm "github.com/cometbft/cometbft-db"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lighthttp "github.com/cometbft/cometbft/light/provider/http"
+	lrpc "github.com/cometbft/cometbft/light/rpc"
+	dbs "github.com/cometbft/cometbft/light/store/db"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/evmos/evmos/v12/types/resource"
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+const (
+	// DefaultTrustingPeriod is the trusting period of the light client if VerifiedQueryOption.TrustingPeriod is not set.
+	DefaultTrustingPeriod = 168 * time.Hour
+	// verifierInitTimeout is the max time to verify the trusted header when the Client is created.
+	verifierInitTimeout = time.Minute
+)
+
+// queryVerifier verifies the values of the store keys by the ABCI query proofs, the app hashes which the proofs are
+// verified against are taken from the headers verified by the light client. The proofs are queried from the node
+// selected by the endpoint pool, like the other calls.
+type queryVerifier struct {
+	pool *endpointPool
+	rpcs map[*chainEndpoint]proofQuerier
+}
+
+// proofQuerier queries the values of the store keys and verifies their proofs, it is implemented by the RPC client of
+// the light client.
+type proofQuerier interface {
+	ABCIQueryWithOptions(ctx context.Context, path string, data cmtbytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error)
+}
+
+func newQueryVerifier(chainID string, pool *endpointPool, opt VerifiedQueryOption) (*queryVerifier, error) {
+	if opt.TrustedHeight <= 0 {
+		return nil, errors.New("the trusted height of the verified query should be positive")
+	}
+	trustedHash, err := hex.DecodeString(opt.TrustedHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted hash %s: %w", opt.TrustedHash, err)
+	}
+	trustingPeriod := opt.TrustingPeriod
+	if trustingPeriod <= 0 {
+		trustingPeriod = DefaultTrustingPeriod
+	}
+	endpoints := make([]string, 0, pool.size())
+	for _, endpoint := range pool.endpoints {
+		endpoints = append(endpoints, endpoint.url)
+	}
+
+	primary, err := lighthttp.New(chainID, endpoints[0])
+	if err != nil {
+		return nil, err
+	}
+	witnessEndpoints := opt.Witnesses
+	if len(witnessEndpoints) == 0 {
+		witnessEndpoints = endpoints[1:]
+	}
+	if len(witnessEndpoints) == 0 {
+		// the light client requires a witness, the primary node cross-checks itself if there is no other node
+		witnessEndpoints = endpoints
+	}
+	witnesses := make([]provider.Provider, 0, len(witnessEndpoints))
+	for _, witnessEndpoint := range witnessEndpoints {
+		witness, err := lighthttp.New(chainID, witnessEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		witnesses = append(witnesses, witness)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), verifierInitTimeout)
+	defer cancel()
+	lc, err := light.NewClient(ctx, chainID, light.TrustOptions{
+		Period: trustingPeriod,
+		Height: opt.TrustedHeight,
+		Hash:   trustedHash,
+	}, primary, witnesses, dbs.New(dbm.NewMemDB(), chainID), light.SkippingVerification(light.DefaultTrustLevel))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the light client: %w", err)
+	}
+
+	v := &queryVerifier{pool: pool, rpcs: make(map[*chainEndpoint]proofQuerier, pool.size())}
+	for _, endpoint := range pool.endpoints {
+		next, err := endpoint.rpcClient()
+		if err != nil {
+			return nil, err
+		}
+		rpc := lrpc.NewClient(next, lc, lrpc.KeyPathFn(lrpc.DefaultMerkleKeyPathFn()))
+		rpc.RegisterOpDecoder(storetypes.ProofOpIAVLCommitment, storetypes.CommitmentOpDecoder)
+		rpc.RegisterOpDecoder(storetypes.ProofOpSimpleMerkleCommitment, storetypes.CommitmentOpDecoder)
+		v.rpcs[endpoint] = rpc
+	}
+	return v, nil
+}
+
+// proveValue returns the value of the key in the store at the height, it is empty if the key does not exist. Both
+// the value and the absence are verified by the proof.
+func (v *queryVerifier) proveValue(ctx context.Context, storeName string, key []byte, height int64) ([]byte, error) {
+	endpoint := v.pool.get()
+	resp, err := v.rpcs[endpoint].ABCIQueryWithOptions(ctx, fmt.Sprintf("/store/%s/key", storeName), key,
+		rpcclient.ABCIQueryOptions{Height: height, Prove: true})
+	if err != nil {
+		v.pool.reportFailure(ctx, endpoint, err)
+		return nil, types.WrapError(fmt.Errorf("failed to verify the proof of the %s store: %w", storeName, err), types.ErrUnverified)
+	}
+	return resp.Response.Value, nil
+}
+
+// verifiedQueryContext returns the ctx which queries the state at a fixed height, so that the result of the query and
+// the proofs are of the same state. The height set by WithHeight is kept.
+func (c *Client) verifiedQueryContext(ctx context.Context) (context.Context, error) {
+	if _, ok := HeightFromContext(ctx); ok {
+		return ctx, nil
+	}
+	status, err := c.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the state at a height is verified by the app hash in the header of the next height
+	return WithHeight(ctx, status.SyncInfo.LatestBlockHeight-1), nil
+}
+
+// verifyBucketInfo returns the info of the requested bucket proven at the height of the ctx. The proof is located by
+// the requested name, the bucket info returned by the node only provides the ID, and it is rejected if it is of
+// another bucket.
+func (c *Client) verifyBucketInfo(ctx context.Context, bucketName string, bucketInfo *storageTypes.BucketInfo) (*storageTypes.BucketInfo, error) {
+	if bucketInfo == nil {
+		return nil, unverifiedError("the node returns no bucket info")
+	}
+	if bucketInfo.BucketName != bucketName {
+		return nil, unverifiedError("the node returns the bucket %s instead of %s", bucketInfo.BucketName, bucketName)
+	}
+	height, _ := HeightFromContext(ctx)
+	id, err := c.verifier.proveValue(ctx, storageTypes.StoreKey, storageTypes.GetBucketKey(bucketName), height)
+	if err != nil {
+		return nil, err
+	}
+	if !sequenceEquals(id, bucketInfo.Id) {
+		return nil, unverifiedError("the ID of the bucket %s does not match the proof", bucketName)
+	}
+	value, err := c.verifier.proveValue(ctx, storageTypes.StoreKey, storageTypes.GetBucketByIDKey(bucketInfo.Id), height)
+	if err != nil {
+		return nil, err
+	}
+	verified := &storageTypes.BucketInfo{}
+	if len(value) == 0 || verified.Unmarshal(value) != nil || verified.BucketName != bucketName {
+		return nil, unverifiedError("the bucket %s does not match the proof", bucketName)
+	}
+	return verified, nil
+}
+
+// verifyObjectInfo returns the info of the requested object proven at the height of the ctx. The proof is located by
+// the requested names, the object info returned by the node only provides the ID, and it is rejected if it is of
+// another object.
+func (c *Client) verifyObjectInfo(ctx context.Context, bucketName, objectName string, objectInfo *storageTypes.ObjectInfo) (*storageTypes.ObjectInfo, error) {
+	if objectInfo == nil {
+		return nil, unverifiedError("the node returns no object info")
+	}
+	if objectInfo.BucketName != bucketName || objectInfo.ObjectName != objectName {
+		return nil, unverifiedError("the node returns the object %s/%s instead of %s/%s", objectInfo.BucketName,
+			objectInfo.ObjectName, bucketName, objectName)
+	}
+	height, _ := HeightFromContext(ctx)
+	id, err := c.verifier.proveValue(ctx, storageTypes.StoreKey, storageTypes.GetObjectKey(bucketName, objectName), height)
+	if err != nil {
+		return nil, err
+	}
+	if !sequenceEquals(id, objectInfo.Id) {
+		return nil, unverifiedError("the ID of the object %s does not match the proof", objectName)
+	}
+	value, err := c.verifier.proveValue(ctx, storageTypes.StoreKey, storageTypes.GetObjectByIDKey(objectInfo.Id), height)
+	if err != nil {
+		return nil, err
+	}
+	verified := &storageTypes.ObjectInfo{}
+	if len(value) == 0 || verified.Unmarshal(value) != nil || verified.BucketName != bucketName ||
+		verified.ObjectName != objectName {
+		return nil, unverifiedError("the object %s does not match the proof", objectName)
+	}
+	return verified, nil
+}
+
+// verifyBucketPolicy returns the policy of the principal on the requested bucket proven at the height of the ctx, the
+// bucket info should have been verified against the requested name to locate the policy.
+func (c *Client) verifyBucketPolicy(ctx context.Context, bucketName string, bucketInfo *storageTypes.BucketInfo,
+	principal sdk.AccAddress, policy *permTypes.Policy,
+) (*permTypes.Policy, error) {
+	if bucketInfo == nil || policy == nil {
+		return nil, unverifiedError("the node returns no policy of %s", principal.String())
+	}
+	if bucketInfo.BucketName != bucketName {
+		return nil, unverifiedError("the policy is located by the bucket %s instead of %s", bucketInfo.BucketName, bucketName)
+	}
+	height, _ := HeightFromContext(ctx)
+	key := permTypes.GetPolicyForAccountKey(bucketInfo.Id, resource.RESOURCE_TYPE_BUCKET, principal)
+	id, err := c.verifier.proveValue(ctx, permTypes.StoreKey, key, height)
+	if err != nil {
+		return nil, err
+	}
+	if !sequenceEquals(id, policy.Id) {
+		return nil, unverifiedError("the ID of the policy of %s does not match the proof", principal.String())
+	}
+	value, err := c.verifier.proveValue(ctx, permTypes.StoreKey, permTypes.GetPolicyByIDKey(policy.Id), height)
+	if err != nil {
+		return nil, err
+	}
+	verified := &permTypes.Policy{}
+	if len(value) == 0 || verified.Unmarshal(value) != nil || verified.ResourceType != resource.RESOURCE_TYPE_BUCKET ||
+		!verified.ResourceId.Equal(bucketInfo.Id) {
+		return nil, unverifiedError("the policy of %s does not match the proof", principal.String())
+	}
+	return verified, nil
+}
+
+// verifyNotFound returns the not-found error of the bucket or the object returned by the node after its absence is
+// proven at the height of the ctx, the other errors are returned as they are.
+func (c *Client) verifyNotFound(ctx context.Context, err error, bucketName, objectName string) error {
+	switch {
+	case errors.Is(err, types.ErrNoSuchBucket):
+		return c.proveAbsence(ctx, storageTypes.StoreKey, storageTypes.GetBucketKey(bucketName), err)
+	case errors.Is(err, types.ErrNoSuchObject) && objectName != "":
+		return c.proveAbsence(ctx, storageTypes.StoreKey, storageTypes.GetObjectKey(bucketName, objectName), err)
+	}
+	return err
+}
+
+// verifyPolicyNotFound returns the error of no policy of the principal on the bucket returned by the node after its
+// absence is proven at the height of the ctx, the other errors are returned as they are.
+func (c *Client) verifyPolicyNotFound(ctx context.Context, err error, bucketName string, principal sdk.AccAddress) error {
+	if !errors.Is(err, types.ErrNoSuchPolicy) {
+		return err
+	}
+	// the bucket is verified at the same height to locate the policy
+	bucketInfo, headErr := c.HeadBucket(ctx, bucketName)
+	if headErr != nil {
+		return headErr
+	}
+	key := permTypes.GetPolicyForAccountKey(bucketInfo.Id, resource.RESOURCE_TYPE_BUCKET, principal)
+	return c.proveAbsence(ctx, permTypes.StoreKey, key, err)
+}
+
+// proveAbsence returns the not-found error if the proof shows the key does not exist in the store at the height of
+// the ctx, otherwise an error matching types.ErrUnverified.
+func (c *Client) proveAbsence(ctx context.Context, storeName string, key []byte, notFound error) error {
+	height, _ := HeightFromContext(ctx)
+	value, err := c.verifier.proveValue(ctx, storeName, key, height)
+	if err != nil {
+		return err
+	}
+	if len(value) != 0 {
+		return unverifiedError("the node reports %v, but the proof shows it exists", notFound)
+	}
+	return notFound
+}
+
+// sequenceEquals reports whether the stored value is the encoding of the ID, the IDs are stored as big-endian bytes
+// or decimal strings.
+func sequenceEquals(value []byte, id sdkmath.Uint) bool {
+	if len(value) == 0 || id.BigInt() == nil {
+		return false
+	}
+	return new(big.Int).SetBytes(value).Cmp(id.BigInt()) == 0 || string(value) == id.String()
+}
+
+func unverifiedError(format string, args ...any) error {
+	return types.WrapError(fmt.Errorf(format, args...), types.ErrUnverified)
+}
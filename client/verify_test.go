@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evmos/evmos/v12/types/resource"
+	permTypes "github.com/evmos/evmos/v12/x/permission/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+func TestVerifyMissingResult(t *testing.T) {
+	// the nil results are rejected before any proof is queried
+	c := &Client{verifier: &queryVerifier{}}
+	ctx := context.Background()
+
+	_, err := c.verifyBucketInfo(ctx, "photos", nil)
+	require.ErrorIs(t, err, types.ErrUnverified)
+	_, err = c.verifyObjectInfo(ctx, "photos", "a.jpg", nil)
+	require.ErrorIs(t, err, types.ErrUnverified)
+	_, err = c.verifyBucketPolicy(ctx, "", &storageTypes.BucketInfo{}, sdk.AccAddress(make([]byte, 20)), nil)
+	require.ErrorIs(t, err, types.ErrUnverified)
+}
+
+func TestSequenceEquals(t *testing.T) {
+	id := sdkmath.NewUint(258)
+	require.True(t, sequenceEquals([]byte{1, 2}, id))
+	require.True(t, sequenceEquals([]byte("258"), id))
+	require.False(t, sequenceEquals([]byte{1, 3}, id))
+	require.False(t, sequenceEquals(nil, id))
+}
+
+// testStore is the state of the chain in the verify tests, the proofs of its values are taken as verified.
+type testStore struct {
+	values map[string][]byte
+	err    error
+}
+
+func (s *testStore) set(storeName string, key, value []byte) {
+	s.values[fmt.Sprintf("/store/%s/key", storeName)+string(key)] = value
+}
+
+func (s *testStore) putBucket(t *testing.T, info *storageTypes.BucketInfo) {
+	value, err := info.Marshal()
+	require.NoError(t, err)
+	s.set(storageTypes.StoreKey, storageTypes.GetBucketKey(info.BucketName), info.Id.BigInt().Bytes())
+	s.set(storageTypes.StoreKey, storageTypes.GetBucketByIDKey(info.Id), value)
+}
+
+func (s *testStore) putObject(t *testing.T, info *storageTypes.ObjectInfo) {
+	value, err := info.Marshal()
+	require.NoError(t, err)
+	s.set(storageTypes.StoreKey, storageTypes.GetObjectKey(info.BucketName, info.ObjectName), info.Id.BigInt().Bytes())
+	s.set(storageTypes.StoreKey, storageTypes.GetObjectByIDKey(info.Id), value)
+}
+
+func (s *testStore) putPolicy(t *testing.T, principal sdk.AccAddress, policy *permTypes.Policy) {
+	value, err := policy.Marshal()
+	require.NoError(t, err)
+	s.set(permTypes.StoreKey, permTypes.GetPolicyForAccountKey(policy.ResourceId, resource.RESOURCE_TYPE_BUCKET, principal),
+		policy.Id.BigInt().Bytes())
+	s.set(permTypes.StoreKey, permTypes.GetPolicyByIDKey(policy.Id), value)
+}
+
+func (s *testStore) ABCIQueryWithOptions(_ context.Context, path string, data cmtbytes.HexBytes,
+	opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if !opts.Prove {
+		return nil, errors.New("the proof is not requested")
+	}
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: s.values[path+string(data)], Height: opts.Height}}, nil
+}
+
+// newVerifyTestClient returns a Client of the node whose query results are verified against the store.
+func newVerifyTestClient(t *testing.T) (*testChain, *testStore, *Client) {
+	chain := newTestChain(t)
+	// the state at the height before the latest one is verified
+	chain.addBlock(testBlock{})
+	chain.addBlock(testBlock{})
+	cli := chain.newClient(t, Option{})
+	store := &testStore{values: make(map[string][]byte)}
+	cli.verifier = &queryVerifier{
+		pool: cli.endpoints,
+		rpcs: map[*chainEndpoint]proofQuerier{cli.endpoints.endpoints[0]: store},
+	}
+	return chain, store, cli
+}
+
+// handleResponse serves the response of the query path, it can be changed by the returned function.
+func handleResponse(chain *testChain, path string) func(resp proto.Message) {
+	var (
+		mu      sync.Mutex
+		current proto.Message
+	)
+	chain.handleQuery(path, func([]byte) (proto.Message, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return current, nil
+	})
+	return func(resp proto.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		current = resp
+	}
+}
+
+func TestVerifyBucketInfo(t *testing.T) {
+	chain, store, cli := newVerifyTestClient(t)
+	ctx := context.Background()
+	photos := &storageTypes.BucketInfo{BucketName: "photos", Id: sdkmath.NewUint(1), Visibility: storageTypes.VISIBILITY_TYPE_PUBLIC_READ}
+	other := &storageTypes.BucketInfo{BucketName: "other", Id: sdkmath.NewUint(2)}
+	store.putBucket(t, photos)
+	store.putBucket(t, other)
+	respond := handleResponse(chain, headBucketPath)
+
+	// the proven bucket info is returned instead of the one returned by the node
+	forged := *photos
+	forged.Visibility = storageTypes.VISIBILITY_TYPE_PRIVATE
+	respond(&storageTypes.QueryHeadBucketResponse{BucketInfo: &forged})
+	info, err := cli.HeadBucket(ctx, "photos")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.VISIBILITY_TYPE_PUBLIC_READ, info.Visibility)
+	require.Equal(t, photos.Id, info.Id)
+
+	for name, resp := range map[string]*storageTypes.BucketInfo{
+		// another bucket, whose proof is valid, is substituted for the requested one
+		"substituted bucket": other,
+		"forged ID":          {BucketName: "photos", Id: other.Id},
+		"absent bucket":      {BucketName: "photos", Id: sdkmath.NewUint(3)},
+	} {
+		respond(&storageTypes.QueryHeadBucketResponse{BucketInfo: resp})
+		_, err = cli.HeadBucket(ctx, "photos")
+		require.ErrorIs(t, err, types.ErrUnverified, name)
+	}
+
+	// the bucket stored under the ID is of another name
+	store.set(storageTypes.StoreKey, storageTypes.GetBucketKey("renamed"), photos.Id.BigInt().Bytes())
+	respond(&storageTypes.QueryHeadBucketResponse{BucketInfo: &storageTypes.BucketInfo{BucketName: "renamed", Id: photos.Id}})
+	_, err = cli.HeadBucket(ctx, "renamed")
+	require.ErrorIs(t, err, types.ErrUnverified)
+
+	store.err = errors.New("unavailable")
+	respond(&storageTypes.QueryHeadBucketResponse{BucketInfo: photos})
+	_, err = cli.HeadBucket(ctx, "photos")
+	require.ErrorIs(t, err, types.ErrUnverified)
+}
+
+func TestVerifyObjectInfo(t *testing.T) {
+	chain, store, cli := newVerifyTestClient(t)
+	ctx := context.Background()
+	a := &storageTypes.ObjectInfo{BucketName: "photos", ObjectName: "a.jpg", Id: sdkmath.NewUint(5), PayloadSize: 100}
+	b := &storageTypes.ObjectInfo{BucketName: "photos", ObjectName: "b.jpg", Id: sdkmath.NewUint(6)}
+	inDocs := &storageTypes.ObjectInfo{BucketName: "docs", ObjectName: "a.jpg", Id: sdkmath.NewUint(7)}
+	store.putObject(t, a)
+	store.putObject(t, b)
+	store.putObject(t, inDocs)
+	respond := handleResponse(chain, headObjectPath)
+
+	forged := *a
+	forged.PayloadSize = 1
+	respond(&storageTypes.QueryHeadObjectResponse{ObjectInfo: &forged})
+	detail, err := cli.HeadObject(ctx, "photos", "a.jpg")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), detail.ObjectInfo.PayloadSize)
+	require.Equal(t, a.Id, detail.ObjectInfo.Id)
+
+	for name, resp := range map[string]*storageTypes.ObjectInfo{
+		"substituted object": b,
+		"substituted bucket": inDocs,
+		"forged ID":          {BucketName: "photos", ObjectName: "a.jpg", Id: b.Id},
+		"absent object":      {BucketName: "photos", ObjectName: "a.jpg", Id: sdkmath.NewUint(8)},
+	} {
+		respond(&storageTypes.QueryHeadObjectResponse{ObjectInfo: resp})
+		_, err = cli.HeadObject(ctx, "photos", "a.jpg")
+		require.ErrorIs(t, err, types.ErrUnverified, name)
+	}
+}
+
+func TestVerifyBucketPolicy(t *testing.T) {
+	chain, store, cli := newVerifyTestClient(t)
+	ctx := context.Background()
+	owner, _ := newTestOwners(t)
+	principal, err := sdk.AccAddressFromHexUnsafe(owner)
+	require.NoError(t, err)
+	photos := &storageTypes.BucketInfo{BucketName: "photos", Id: sdkmath.NewUint(1)}
+	other := &storageTypes.BucketInfo{BucketName: "other", Id: sdkmath.NewUint(2)}
+	store.putBucket(t, photos)
+	store.putBucket(t, other)
+	policy := &permTypes.Policy{Id: sdkmath.NewUint(9), ResourceType: resource.RESOURCE_TYPE_BUCKET, ResourceId: photos.Id}
+	otherPolicy := &permTypes.Policy{Id: sdkmath.NewUint(10), ResourceType: resource.RESOURCE_TYPE_BUCKET, ResourceId: other.Id}
+	store.putPolicy(t, principal, policy)
+	store.putPolicy(t, principal, otherPolicy)
+	respondBucket := handleResponse(chain, headBucketPath)
+	respondPolicy := handleResponse(chain, "/mechain.storage.Query/QueryPolicyForAccount")
+
+	respondBucket(&storageTypes.QueryHeadBucketResponse{BucketInfo: photos})
+	respondPolicy(&storageTypes.QueryPolicyForAccountResponse{Policy: policy})
+	verified, err := cli.GetBucketPolicy(ctx, "photos", owner)
+	require.NoError(t, err)
+	require.Equal(t, policy.Id, verified.Id)
+
+	// the policy of another bucket is substituted
+	respondPolicy(&storageTypes.QueryPolicyForAccountResponse{Policy: otherPolicy})
+	_, err = cli.GetBucketPolicy(ctx, "photos", owner)
+	require.ErrorIs(t, err, types.ErrUnverified)
+
+	// the bucket locating the policy is substituted
+	respondBucket(&storageTypes.QueryHeadBucketResponse{BucketInfo: other})
+	_, err = cli.GetBucketPolicy(ctx, "photos", owner)
+	require.ErrorIs(t, err, types.ErrUnverified)
+	_, err = cli.verifyBucketPolicy(WithHeight(ctx, 1), "photos", other, principal, otherPolicy)
+	require.ErrorIs(t, err, types.ErrUnverified)
+}
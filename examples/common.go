@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -37,24 +36,11 @@ func handleErr(err error, funcName string) {
 }
 
 func waitObjectSeal(cli client.IClient, bucketName, objectName string) {
-	ctx := context.Background()
 	// wait for the object to be sealed
-	timeout := time.After(15 * time.Second)
-	ticker := time.NewTicker(2 * time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
 
-	for {
-		select {
-		case <-timeout:
-			err := errors.New("object not sealed after 15 seconds")
-			handleErr(err, "HeadObject")
-		case <-ticker.C:
-			objectDetail, err := cli.HeadObject(ctx, bucketName, objectName)
-			handleErr(err, "HeadObject")
-			if objectDetail.ObjectInfo.GetObjectStatus().String() == "OBJECT_STATUS_SEALED" {
-				ticker.Stop()
-				fmt.Printf("put object %s successfully \n", objectName)
-				return
-			}
-		}
-	}
+	_, err := cli.WaitForObjectSealed(ctx, bucketName, objectName)
+	handleErr(err, "WaitForObjectSealed")
+	fmt.Printf("put object %s successfully \n", objectName)
 }
@@ -0,0 +1,182 @@
+// Package prommetrics provides a Prometheus implementation of the client.MetricsSink.
+package prommetrics
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace is the namespace of the metrics if Option.Namespace is not set.
+const DefaultNamespace = "mechain_sdk"
+
+// defaultBuckets are the histogram buckets of the metrics which are not measured in seconds.
+var defaultBuckets = map[string][]float64{
+	"wait_for_tx_retries": {0, 1, 2, 3, 5, 8, 13, 21, 34},
+}
+
+// Option - The optional configurations of the Prometheus metrics sink.
+type Option struct {
+	// Registerer is the registry to which the metrics are registered, prometheus.DefaultRegisterer is used if it is not set.
+	Registerer prometheus.Registerer
+	// Namespace is the prefix of the metric names, DefaultNamespace is used if it is not set.
+	Namespace string
+	// Buckets overrides the histogram buckets by metric name, prometheus.DefBuckets is used for the other histograms.
+	Buckets map[string][]float64
+	// OnError is called with the errors of registering the metrics and of resolving the labels, as the observations
+	// of the metrics sink can not return errors. The errors are logged by the standard logger if it is not set.
+	OnError func(err error)
+}
+
+// Sink - The Prometheus implementation of the client.MetricsSink, it registers a metric vector on the first
+// observation of each metric name.
+type Sink struct {
+	registerer prometheus.Registerer
+	namespace  string
+	buckets    map[string][]float64
+	onError    func(err error)
+
+	mu         sync.Mutex
+	counters   map[string]*prometheus.CounterVec
+	histograms map[string]*prometheus.HistogramVec
+}
+
+// New - Create a Prometheus metrics sink which can be used as client.Option.Metrics.
+//
+// - opt: The optional configurations of the sink.
+//
+// - ret: The Prometheus metrics sink.
+func New(opt Option) *Sink {
+	s := &Sink{
+		registerer: opt.Registerer,
+		namespace:  opt.Namespace,
+		buckets:    make(map[string][]float64),
+		onError:    opt.OnError,
+		counters:   make(map[string]*prometheus.CounterVec),
+		histograms: make(map[string]*prometheus.HistogramVec),
+	}
+	if s.registerer == nil {
+		s.registerer = prometheus.DefaultRegisterer
+	}
+	if s.namespace == "" {
+		s.namespace = DefaultNamespace
+	}
+	if s.onError == nil {
+		s.onError = func(err error) {
+			log.Printf("prommetrics: %v", err)
+		}
+	}
+	for name, buckets := range defaultBuckets {
+		s.buckets[name] = buckets
+	}
+	for name, buckets := range opt.Buckets {
+		s.buckets[name] = buckets
+	}
+	return s
+}
+
+// IncCounter adds the value to the counter identified by name and labels.
+func (s *Sink) IncCounter(name string, value float64, labels map[string]string) {
+	vec := s.counterVec(name, labels)
+	if vec == nil {
+		return
+	}
+	counter, err := vec.GetMetricWith(labels)
+	if err != nil {
+		s.onError(fmt.Errorf("resolve the labels of %s: %w", name, err))
+		return
+	}
+	counter.Add(value)
+}
+
+// ObserveHistogram records the value in the histogram identified by name and labels.
+func (s *Sink) ObserveHistogram(name string, value float64, labels map[string]string) {
+	vec := s.histogramVec(name, labels)
+	if vec == nil {
+		return
+	}
+	histogram, err := vec.GetMetricWith(labels)
+	if err != nil {
+		s.onError(fmt.Errorf("resolve the labels of %s: %w", name, err))
+		return
+	}
+	histogram.Observe(value)
+}
+
+// counterVec returns the counter vector of the metric name, it is registered with the label names of the first
+// observation. It returns nil if the vector can not be registered, the error is reported by onError.
+func (s *Sink) counterVec(name string, labels map[string]string) *prometheus.CounterVec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if vec, ok := s.counters[name]; ok {
+		return vec
+	}
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: s.namespace,
+		Name:      name,
+		Help:      "The " + name + " counter reported by the mechain go sdk.",
+	}, labelNames(labels))
+	if err := s.registerer.Register(vec); err != nil {
+		are, ok := err.(prometheus.AlreadyRegisteredError)
+		if ok {
+			vec, ok = are.ExistingCollector.(*prometheus.CounterVec)
+		}
+		if !ok {
+			// the failure is reported once, the observations of the metric are dropped afterwards
+			s.onError(fmt.Errorf("register %s: %w", name, err))
+			s.counters[name] = nil
+			return nil
+		}
+	}
+	s.counters[name] = vec
+	return vec
+}
+
+// histogramVec returns the histogram vector of the metric name, it is registered with the label names of the first
+// observation. It returns nil if the vector can not be registered, the error is reported by onError.
+func (s *Sink) histogramVec(name string, labels map[string]string) *prometheus.HistogramVec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if vec, ok := s.histograms[name]; ok {
+		return vec
+	}
+	buckets, ok := s.buckets[name]
+	if !ok {
+		buckets = prometheus.DefBuckets
+	}
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: s.namespace,
+		Name:      name,
+		Help:      "The " + name + " histogram reported by the mechain go sdk.",
+		Buckets:   buckets,
+	}, labelNames(labels))
+	if err := s.registerer.Register(vec); err != nil {
+		are, ok := err.(prometheus.AlreadyRegisteredError)
+		if ok {
+			vec, ok = are.ExistingCollector.(*prometheus.HistogramVec)
+		}
+		if !ok {
+			// the failure is reported once, the observations of the metric are dropped afterwards
+			s.onError(fmt.Errorf("register %s: %w", name, err))
+			s.histograms[name] = nil
+			return nil
+		}
+	}
+	s.histograms[name] = vec
+	return vec
+}
+
+// labelNames returns the sorted names of the labels.
+func labelNames(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
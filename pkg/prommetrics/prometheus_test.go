@@ -0,0 +1,46 @@
+package prommetrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkObserve(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	var errs []error
+	sink := New(Option{Registerer: registry, OnError: func(err error) { errs = append(errs, err) }})
+
+	labels := map[string]string{"sp": "sp0", "method": "GET"}
+	sink.IncCounter("sp_bytes_in_total", 3, labels)
+	sink.IncCounter("sp_bytes_in_total", 4, labels)
+	sink.ObserveHistogram("wait_for_tx_retries", 2, map[string]string{"outcome": "success"})
+
+	require.Empty(t, errs)
+	require.Equal(t, 7.0, testutil.ToFloat64(sink.counters["sp_bytes_in_total"].With(labels)))
+	require.Equal(t, 2, testutil.CollectAndCount(registry))
+}
+
+func TestSinkReportsErrors(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	// a collector of another type holds the name, so the counter can not be registered
+	require.NoError(t, registry.Register(prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: DefaultNamespace,
+		Name:      "sp_requests_total",
+		Help:      "The gauge holding the name.",
+	})))
+	var errs []error
+	sink := New(Option{Registerer: registry, OnError: func(err error) { errs = append(errs, err) }})
+
+	sink.IncCounter("sp_requests_total", 1, map[string]string{"sp": "sp0"})
+	sink.IncCounter("sp_requests_total", 1, map[string]string{"sp": "sp0"})
+	require.Len(t, errs, 1, "the registration failure is reported once")
+	require.Contains(t, errs[0].Error(), "register sp_requests_total")
+
+	sink.ObserveHistogram("sp_request_duration_seconds", 1, map[string]string{"sp": "sp0"})
+	sink.ObserveHistogram("sp_request_duration_seconds", 1, map[string]string{"method": "GET"})
+	require.Len(t, errs, 2)
+	require.Contains(t, errs[1].Error(), "resolve the labels of sp_request_duration_seconds")
+}
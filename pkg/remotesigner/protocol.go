@@ -0,0 +1,45 @@
+// Package remotesigner provides a types.Signer which delegates the signing to a signing daemon, and a reference
+// implementation of the daemon.
+//
+// The daemon serves the net/rpc protocol with the JSON codec over a Unix socket, so that the private keys are held by
+// the daemon process only and never loaded into the memory of the application. The protocol has no authentication,
+// the access to the daemon is restricted by the permission of the socket.
+package remotesigner
+
+// serviceName is the name of the rpc service served by the signing daemon.
+const serviceName = "Signer"
+
+// The kinds of the messages to be signed, the daemon can apply different policies to them.
+const (
+	SignKindTx      = "tx"
+	SignKindRequest = "request"
+)
+
+// PubKeyArgs is the argument of the Signer.PubKey rpc.
+type PubKeyArgs struct {
+	// Address is the HEX-encoded address of the key.
+	Address string
+}
+
+// PubKeyReply is the reply of the Signer.PubKey rpc.
+type PubKeyReply struct {
+	// Type is the type of the key, e.g. "eth_secp256k1".
+	Type string
+	// PubKey is the compressed public key.
+	PubKey []byte
+}
+
+// SignArgs is the argument of the Signer.Sign rpc.
+type SignArgs struct {
+	// Address is the HEX-encoded address of the key.
+	Address string
+	// Kind is the kind of the message, one of SignKindTx and SignKindRequest.
+	Kind string
+	// Msg is the message to be signed.
+	Msg []byte
+}
+
+// SignReply is the reply of the Signer.Sign rpc.
+type SignReply struct {
+	Signature []byte
+}
@@ -0,0 +1,185 @@
+package remotesigner
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// Server - The reference signing daemon, it signs the messages with the signers it holds.
+//
+// It is meant for local development and tests, a production daemon is expected to keep the keys in an HSM or a
+// KMS and to apply its own signing policies.
+type Server struct {
+	signers map[string]types.Signer
+
+	mu       sync.Mutex
+	listener net.Listener
+}
+
+// NewServer - Create a signing daemon which signs with the given signers, e.g. *types.Account.
+//
+// - signers: The signers held by the daemon, they are looked up by address.
+//
+// - ret: The signing daemon.
+func NewServer(signers ...types.Signer) *Server {
+	s := &Server{signers: make(map[string]types.Signer, len(signers))}
+	for _, signer := range signers {
+		s.signers[signer.GetAddress().String()] = signer
+	}
+	return s
+}
+
+// ListenAndServe - Listen on the Unix socket and serve the signing requests until Close is called.
+//
+// The daemon signs for any process which can connect to it, so it only listens on a Unix socket which is accessible
+// to the owner. A daemon serving over the network must authenticate the remote signers itself, e.g. by passing a
+// TLS listener which requires the client certificates to Serve.
+//
+// - socketPath: The path of the Unix socket, a stale socket file is removed. The socket is created in a private
+// directory beside the path and moved to it after its permission is restricted, so it is never accessible to the
+// others, and it is removed by Close.
+//
+// - ret: Return error if failed to listen, nil after Close is called.
+func (s *Server) ListenAndServe(socketPath string) error {
+	l, err := listenUnix(socketPath)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve - Serve the signing requests accepted by the listener until Close is called.
+//
+// - l: The listener which accepts the connections of the remote signers.
+//
+// - ret: Return nil after Close is called, otherwise the error which stops the daemon.
+func (s *Server) Serve(l net.Listener) error {
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName(serviceName, &service{signers: s.signers}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.listener = l
+	s.mu.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go rpcServer.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// Close - Stop accepting the signing requests.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Close()
+}
+
+// listenUnix listens on the Unix socket at the path which is only accessible to the owner.
+func listenUnix(socketPath string) (net.Listener, error) {
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	// the directory is created with 0700, the socket is not accessible to the others before it is chmod-ed
+	dir, err := os.MkdirTemp(filepath.Dir(socketPath), ".remotesigner-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	tmpPath := filepath.Join(dir, "socket")
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmpPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	l.SetUnlinkOnClose(false)
+	if err = os.Chmod(tmpPath, 0o600); err == nil {
+		err = os.Rename(tmpPath, socketPath)
+	}
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	return &unixListener{UnixListener: l, path: socketPath}, nil
+}
+
+// unixListener removes the socket file from the path it has been moved to when it is closed.
+type unixListener struct {
+	*net.UnixListener
+	path string
+}
+
+func (l *unixListener) Close() error {
+	err := l.UnixListener.Close()
+	if removeErr := os.Remove(l.path); removeErr != nil && !os.IsNotExist(removeErr) && err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// service implements the rpc methods of the signing daemon.
+type service struct {
+	signers map[string]types.Signer
+}
+
+func (s *service) signer(address string) (types.Signer, error) {
+	addr, err := sdk.AccAddressFromHexUnsafe(address)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := s.signers[addr.String()]
+	if !ok {
+		return nil, fmt.Errorf("no key for address %s", address)
+	}
+	return signer, nil
+}
+
+// PubKey returns the public key of the address.
+func (s *service) PubKey(args *PubKeyArgs, reply *PubKeyReply) error {
+	signer, err := s.signer(args.Address)
+	if err != nil {
+		return err
+	}
+	pubKey := signer.GetPubKey()
+	reply.Type = pubKey.Type()
+	reply.PubKey = pubKey.Bytes()
+	return nil
+}
+
+// Sign signs the message with the key of the address.
+func (s *service) Sign(args *SignArgs, reply *SignReply) error {
+	signer, err := s.signer(args.Address)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(args.Kind) {
+	case SignKindTx:
+		reply.Signature, err = signer.SignTx(args.Msg)
+	case SignKindRequest:
+		reply.Signature, err = signer.SignRequest(args.Msg)
+	default:
+		return fmt.Errorf("unknown sign kind %s", args.Kind)
+	}
+	return err
+}
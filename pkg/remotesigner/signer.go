@@ -0,0 +1,156 @@
+package remotesigner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+	"time"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/evmos/evmos/v12/crypto/ethsecp256k1"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// dialTimeout is the timeout of connecting to the signing daemon.
+const dialTimeout = 5 * time.Second
+
+// RemoteSigner - The types.Signer which delegates the signing to a signing daemon.
+//
+// It is safe for concurrent use, the connection to the daemon is re-established if it has been shut down.
+type RemoteSigner struct {
+	network string
+	address string
+	addr    sdk.AccAddress
+	pubKey  cryptotypes.PubKey
+
+	mu     sync.Mutex
+	client *rpc.Client
+}
+
+var _ types.Signer = (*RemoteSigner)(nil)
+
+// Dial - Connect to the signing daemon and create the signer of the account.
+//
+// - network: The network of the daemon, "unix" for the daemon started by Server.ListenAndServe.
+//
+// - address: The path of the Unix socket, or the address of the daemon on the network.
+//
+// - accountAddr: The HEX-encoded address of the account whose key is held by the daemon.
+//
+// - ret1: The remote signer, it can be used by types.NewAccountFromSigner.
+//
+// - ret2: Return error if failed to connect to the daemon or the daemon does not hold the key of the account.
+func Dial(network, address, accountAddr string) (*RemoteSigner, error) {
+	addr, err := sdk.AccAddressFromHexUnsafe(accountAddr)
+	if err != nil {
+		return nil, err
+	}
+	s := &RemoteSigner{
+		network: network,
+		address: address,
+		addr:    addr,
+	}
+
+	var reply PubKeyReply
+	if err = s.call(serviceName+".PubKey", &PubKeyArgs{Address: addr.String()}, &reply); err != nil {
+		s.Close()
+		return nil, err
+	}
+	if reply.Type != (&ethsecp256k1.PubKey{}).Type() {
+		s.Close()
+		return nil, fmt.Errorf("unsupported key type %s", reply.Type)
+	}
+	s.pubKey = &ethsecp256k1.PubKey{Key: reply.PubKey}
+	if !sdk.AccAddress(s.pubKey.Address()).Equals(addr) {
+		s.Close()
+		return nil, errors.New("the public key returned by the signing daemon does not match the account address")
+	}
+	return s, nil
+}
+
+// GetAddress - Get the address of the account.
+func (s *RemoteSigner) GetAddress() sdk.AccAddress {
+	return s.addr
+}
+
+// GetPubKey - Get the public key of the account.
+func (s *RemoteSigner) GetPubKey() cryptotypes.PubKey {
+	return s.pubKey
+}
+
+// SignTx - Sign the sign bytes of a transaction by the signing daemon.
+func (s *RemoteSigner) SignTx(signBytes []byte) ([]byte, error) {
+	return s.sign(SignKindTx, signBytes)
+}
+
+// SignRequest - Sign the message of an SP request by the signing daemon.
+func (s *RemoteSigner) SignRequest(msg []byte) ([]byte, error) {
+	return s.sign(SignKindRequest, msg)
+}
+
+// Close - Close the connection to the signing daemon.
+func (s *RemoteSigner) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil {
+		return nil
+	}
+	err := s.client.Close()
+	s.client = nil
+	return err
+}
+
+func (s *RemoteSigner) sign(kind string, msg []byte) ([]byte, error) {
+	var reply SignReply
+	if err := s.call(serviceName+".Sign", &SignArgs{Address: s.addr.String(), Kind: kind, Msg: msg}, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Signature, nil
+}
+
+// call invokes the rpc of the daemon, it reconnects once if the connection has been lost, e.g. the daemon restarted.
+func (s *RemoteSigner) call(method string, args, reply interface{}) error {
+	client, err := s.getClient()
+	if err != nil {
+		return err
+	}
+	err = client.Call(method, args, reply)
+	if !errors.Is(err, rpc.ErrShutdown) && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	s.resetClient(client)
+	if client, err = s.getClient(); err != nil {
+		return err
+	}
+	return client.Call(method, args, reply)
+}
+
+func (s *RemoteSigner) getClient() (*rpc.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client != nil {
+		return s.client, nil
+	}
+	conn, err := net.DialTimeout(s.network, s.address, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the signing daemon: %w", err)
+	}
+	s.client = jsonrpc.NewClient(conn)
+	return s.client, nil
+}
+
+func (s *RemoteSigner) resetClient(client *rpc.Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == client {
+		s.client.Close()
+		s.client = nil
+	}
+}
@@ -0,0 +1,136 @@
+package remotesigner_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zkMeLabs/mechain-go-sdk/pkg/remotesigner"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// newTestAccount returns a new account.
+func newTestAccount(t *testing.T) *types.Account {
+	account, _, err := types.NewAccount("test")
+	require.NoError(t, err)
+	return account
+}
+
+// serve starts the daemon which holds the account on a Unix socket and returns the path of the socket.
+func serve(t *testing.T, account *types.Account) string {
+	socketPath := filepath.Join(t.TempDir(), "signer.sock")
+	srv := remotesigner.NewServer(account)
+	done := make(chan error, 1)
+	go func() { done <- srv.ListenAndServe(socketPath) }()
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(socketPath)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	t.Cleanup(func() {
+		require.NoError(t, srv.Close())
+		require.NoError(t, <-done)
+	})
+	return socketPath
+}
+
+func TestRoundTrip(t *testing.T) {
+	account := newTestAccount(t)
+	socketPath := serve(t, account)
+
+	info, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "the socket is only accessible to the owner")
+	entries, err := os.ReadDir(filepath.Dir(socketPath))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "the private directory of the socket is removed")
+
+	signer, err := remotesigner.Dial("unix", socketPath, account.GetAddress().String())
+	require.NoError(t, err)
+	defer signer.Close()
+	require.Equal(t, account.GetAddress(), signer.GetAddress())
+	require.True(t, account.GetPubKey().Equals(signer.GetPubKey()))
+
+	signBytes := []byte("sign bytes of a tx")
+	signature, err := signer.SignTx(signBytes)
+	require.NoError(t, err)
+	require.True(t, signer.GetPubKey().VerifySignature(signBytes, signature))
+
+	digest := crypto.Keccak256([]byte("message of an SP request"))
+	signature, err = signer.SignRequest(digest)
+	require.NoError(t, err)
+	expected, err := account.SignRequest(digest)
+	require.NoError(t, err)
+	require.Equal(t, expected, signature)
+
+	// the account signs through the daemon
+	remote := types.NewAccountFromSigner("remote", signer)
+	signature, err = remote.SignTx(signBytes)
+	require.NoError(t, err)
+	require.True(t, account.GetPubKey().VerifySignature(signBytes, signature))
+}
+
+func TestUnknownAddress(t *testing.T) {
+	socketPath := serve(t, newTestAccount(t))
+	_, err := remotesigner.Dial("unix", socketPath, newTestAccount(t).GetAddress().String())
+	require.ErrorContains(t, err, "no key for address")
+}
+
+// connListener records the connections it accepts.
+type connListener struct {
+	net.Listener
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.mu.Lock()
+		l.conns = append(l.conns, conn)
+		l.mu.Unlock()
+	}
+	return conn, err
+}
+
+// closeConns closes the accepted connections as the daemon does when it restarts.
+func (l *connListener) closeConns() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, conn := range l.conns {
+		conn.Close()
+	}
+	return len(l.conns)
+}
+
+func TestReconnect(t *testing.T) {
+	account := newTestAccount(t)
+	socketPath := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	listener := &connListener{Listener: l}
+	srv := remotesigner.NewServer(account)
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(listener) }()
+	defer func() {
+		require.NoError(t, srv.Close())
+		require.NoError(t, <-done)
+	}()
+
+	signer, err := remotesigner.Dial("unix", socketPath, account.GetAddress().String())
+	require.NoError(t, err)
+	defer signer.Close()
+	require.Equal(t, 1, listener.closeConns())
+
+	// the connection has been shut down by the daemon, the signer reconnects
+	signBytes := []byte("sign bytes of a tx")
+	signature, err := signer.SignTx(signBytes)
+	require.NoError(t, err)
+	require.True(t, account.GetPubKey().VerifySignature(signBytes, signature))
+	require.Equal(t, 2, listener.closeConns())
+}
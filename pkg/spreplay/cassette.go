@@ -0,0 +1,225 @@
+package spreplay
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"unicode/utf8"
+
+	httplib "github.com/zkMeLabs/mechain-common/go/http"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// cassetteVersion is the version of the format of the cassette files.
+const cassetteVersion = 1
+
+// normalizedValue replaces the values of the volatile headers and query parameters in the cassettes.
+const normalizedValue = "<normalized>"
+
+// bodyEncodingBase64 marks the bodies which are not valid UTF-8 and are base64 encoded in the cassettes.
+const bodyEncodingBase64 = "base64"
+
+// volatileHeaders are the headers carrying the signatures and the dates of the requests, they change on every request
+// so their values are normalized when recording and they are ignored when matching.
+var volatileHeaders = []string{
+	types.HTTPHeaderAuthorization,
+	types.HTTPHeaderDate,
+	httplib.HTTPHeaderExpiryTimestamp,
+}
+
+// volatileQueries are the query parameters carrying the signatures and the dates of the presigned URLs.
+var volatileQueries = []string{
+	types.HTTPHeaderAuthorization,
+	httplib.HTTPHeaderExpiryTimestamp,
+}
+
+// volatileResponseHeaders are the headers of the responses whose values are normalized when recording.
+var volatileResponseHeaders = []string{
+	"Date",
+}
+
+// defaultIgnoredHeaders are the headers ignored when matching besides the volatile headers, they depend on the
+// platform or the trace context rather than the request itself.
+var defaultIgnoredHeaders = []string{
+	types.HTTPHeaderUserAgent,
+	"Traceparent",
+	"Tracestate",
+}
+
+// Cassette - The SP request and response pairs recorded in a cassette file.
+type Cassette struct {
+	// Version is the version of the format of the cassette file.
+	Version int `json:"version"`
+	// Interactions are the recorded pairs in the order of the requests.
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction - A recorded pair of the SP request and response.
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+// Request - A recorded SP request, the volatile headers and query parameters are normalized.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	// BodySHA256 is the hex encoded sha256 of the body, the body itself is not kept in the cassette as it can be the
+	// payload of an upload.
+	BodySHA256 string `json:"body_sha256"`
+}
+
+// Response - A recorded SP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	// BodyEncoding is "base64" if the body is not valid UTF-8, the body is kept as it is otherwise, so that the XML
+	// responses are readable and editable in the cassette files.
+	BodyEncoding string `json:"body_encoding,omitempty"`
+	// BodyOmitted reports whether the body is left out since it is larger than Option.MaxBodySize, the interaction
+	// can't be replayed.
+	BodyOmitted bool `json:"body_omitted,omitempty"`
+}
+
+// LoadCassette - Load the cassette from the file.
+//
+// - path: The path of the cassette file.
+//
+// - ret1: The cassette loaded from the file.
+//
+// - ret2: Return error if the file can't be read or decoded, otherwise return nil.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cassette := &Cassette{}
+	if err = json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("decode cassette %s failed: %w", path, err)
+	}
+	if cassette.Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported version %d of cassette %s", cassette.Version, path)
+	}
+	return cassette, nil
+}
+
+// Save - Save the cassette to the file, the parent directories are created if they don't exist.
+//
+// - path: The path of the cassette file.
+//
+// - ret: Return error if the file can't be written, otherwise return nil.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), types.FilePermMode)
+}
+
+// newRequest records the request with the body, the volatile headers and query parameters are normalized.
+func newRequest(req *http.Request, body []byte) *Request {
+	header := req.Header.Clone()
+	for _, key := range volatileHeaders {
+		if header.Get(key) != "" {
+			header.Set(key, normalizedValue)
+		}
+	}
+	if len(header) == 0 {
+		header = nil
+	}
+	return &Request{
+		Method:     req.Method,
+		URL:        normalizeURL(req.URL),
+		Header:     header,
+		BodySHA256: bodySHA256(body),
+	}
+}
+
+// newResponse records the response with the body, the volatile headers are normalized.
+func newResponse(resp *http.Response, body []byte) *Response {
+	header := resp.Header.Clone()
+	for _, key := range volatileResponseHeaders {
+		if header.Get(key) != "" {
+			header.Set(key, normalizedValue)
+		}
+	}
+	if len(header) == 0 {
+		header = nil
+	}
+	recorded := &Response{StatusCode: resp.StatusCode, Header: header}
+	if utf8.Valid(body) {
+		recorded.Body = string(body)
+	} else {
+		recorded.Body = base64.StdEncoding.EncodeToString(body)
+		recorded.BodyEncoding = bodyEncodingBase64
+	}
+	return recorded
+}
+
+// body returns the decoded body of the recorded response.
+func (r *Response) body() ([]byte, error) {
+	switch r.BodyEncoding {
+	case "":
+		return []byte(r.Body), nil
+	case bodyEncodingBase64:
+		return base64.StdEncoding.DecodeString(r.Body)
+	default:
+		return nil, fmt.Errorf("unsupported body encoding %q", r.BodyEncoding)
+	}
+}
+
+// normalizeURL returns the URL with the values of the volatile query parameters normalized and the query parameters
+// sorted, so that the URLs of the presigned requests are stable.
+func normalizeURL(u *url.URL) string {
+	normalized := *u
+	if normalized.RawQuery != "" {
+		query := normalized.Query()
+		for _, key := range volatileQueries {
+			if query.Has(key) {
+				query.Set(key, normalizedValue)
+			}
+		}
+		normalized.RawQuery = query.Encode()
+	}
+	return normalized.String()
+}
+
+// bodySHA256 returns the hex encoded sha256 of the body.
+func bodySHA256(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// matchHeader reports whether the headers are the same except those ignored.
+func matchHeader(recorded, actual http.Header, ignored map[string]bool) bool {
+	return headerString(recorded, ignored) == headerString(actual, ignored)
+}
+
+// headerString returns the canonical form of the headers except those ignored.
+func headerString(header http.Header, ignored map[string]bool) string {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		if !ignored[http.CanonicalHeaderKey(key)] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	s := ""
+	for _, key := range keys {
+		s += fmt.Sprintf("%s: %q\n", http.CanonicalHeaderKey(key), header[key])
+	}
+	return s
+}
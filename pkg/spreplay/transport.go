@@ -0,0 +1,266 @@
+// Package spreplay provides a record/replay http.RoundTripper for the requests sent to SP, which can be set as the
+// Transport of the client.Option.
+//
+// In the record mode, the requests are sent to the real SP by the underlying transport, and the request and response
+// pairs are kept in a cassette which is written to a file by Save. The signatures and the dates of the requests, i.e.
+// the Authorization, the X-Gnfd-Date and the X-Gnfd-Expiry-Timestamp headers(and query parameters of the presigned
+// URLs), are normalized in the cassette as they change on every request. The request bodies are read into memory to
+// be hashed, only their hashes are kept in the cassette. The response bodies up to Option.MaxBodySize are buffered
+// in memory and kept in the cassette, the larger ones, e.g. the payloads of the downloads, are streamed to the caller
+// and left out of the cassette.
+//
+// In the replay mode, no request is sent, the responses are served from the cassette file. A request is served by the
+// first unused interaction of the same method, URL, body and headers, the volatile headers are ignored, so that the
+// same requests are served by the recorded responses in order and the replay is deterministic. This allows the tests
+// of the XML parsing of the APIs, e.g. ListObjects, ListGroup and GetChallengeInfo, to be pinned to the responses of a
+// real SP.
+package spreplay
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Mode - The mode of the Transport.
+type Mode int
+
+const (
+	// ModeReplay serves the responses from the cassette file without sending any request.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests by the underlying transport and records them into the cassette.
+	ModeRecord
+)
+
+// DefaultMaxBodySize is the max size of the response bodies kept in the cassette if Option.MaxBodySize is not set.
+const DefaultMaxBodySize = 1024 * 1024
+
+var (
+	// ErrNoInteraction is returned in the replay mode if no unused interaction in the cassette matches the request.
+	ErrNoInteraction = errors.New("no recorded interaction matches the request")
+	// ErrBodyNotRecorded is returned in the replay mode if the interaction matching the request has its response body
+	// left out of the cassette since it is larger than Option.MaxBodySize.
+	ErrBodyNotRecorded = errors.New("the response body is not recorded")
+)
+
+// Option - Configurations for the Transport.
+type Option struct {
+	// Mode is the mode of the Transport, it is ModeReplay by default.
+	Mode Mode
+	// Transport is the underlying transport to send the requests in the record mode, http.DefaultTransport is used if
+	// it is not set.
+	Transport http.RoundTripper
+	// IgnoreHeaders are the headers ignored when matching the requests in the replay mode, besides the volatile ones,
+	// the User-Agent and the trace context headers.
+	IgnoreHeaders []string
+	// MaxBodySize is the max size of the response bodies kept in the cassette in the record mode, DefaultMaxBodySize
+	// is used if it is not set. A larger body is passed to the caller without being buffered, and the interaction is
+	// recorded without the body.
+	MaxBodySize int64
+}
+
+// Transport - The record/replay http.RoundTripper for the requests sent to SP.
+type Transport struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	ignored   map[string]bool
+	maxBody   int64
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New - Create a Transport with the cassette file.
+//
+// - path: The path of the cassette file, it is loaded in the replay mode and written by Save in the record mode.
+//
+// - option: The Option for the Transport.
+//
+// - ret1: The Transport to be set as the Transport of the client.Option.
+//
+// - ret2: Return error if the cassette file can't be loaded in the replay mode, otherwise return nil.
+func New(path string, option Option) (*Transport, error) {
+	t := &Transport{
+		path:      path,
+		mode:      option.Mode,
+		transport: option.Transport,
+		ignored:   make(map[string]bool),
+		maxBody:   option.MaxBodySize,
+	}
+	if t.transport == nil {
+		t.transport = http.DefaultTransport
+	}
+	if t.maxBody <= 0 {
+		t.maxBody = DefaultMaxBodySize
+	}
+	for _, keys := range [][]string{volatileHeaders, defaultIgnoredHeaders, option.IgnoreHeaders} {
+		for _, key := range keys {
+			t.ignored[http.CanonicalHeaderKey(key)] = true
+		}
+	}
+
+	switch option.Mode {
+	case ModeRecord:
+		t.cassette = &Cassette{Version: cassetteVersion, Interactions: make([]*Interaction, 0)}
+	case ModeReplay:
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		t.cassette = cassette
+		t.used = make([]bool, len(cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unsupported mode %d", option.Mode)
+	}
+	return t, nil
+}
+
+// RoundTrip - Send the request by the underlying transport and record it in the record mode, or serve it from the
+// cassette in the replay mode.
+//
+// - req: The request to send.
+//
+// - ret1: The response of the request.
+//
+// - ret2: Return error if the request fails, or ErrNoInteraction if the request is not recorded in the replay mode.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.mode == ModeRecord {
+		return t.record(req, body)
+	}
+	return t.replay(req, body)
+}
+
+// Save - Write the recorded interactions into the cassette file, it does nothing in the replay mode.
+//
+// - ret: Return error if the cassette file can't be written, otherwise return nil.
+func (t *Transport) Save() error {
+	if t.mode != ModeRecord {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cassette.Save(t.path)
+}
+
+// Unused - Return the interactions in the cassette which have not been served in the replay mode, the tests can
+// check that all the recorded requests are sent.
+//
+// - ret: The unused interactions.
+func (t *Transport) Unused() []*Interaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	unused := make([]*Interaction, 0)
+	for i, used := range t.used {
+		if !used {
+			unused = append(unused, t.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// record sends the request by the underlying transport and records the request and the response. The response body
+// is buffered if it is not larger than t.maxBody, otherwise the rest of it is streamed to the caller and the
+// response is recorded without the body.
+func (t *Transport) record(req *http.Request, body []byte) (*http.Response, error) {
+	outReq := req.Clone(req.Context())
+	if req.Body != nil {
+		outReq.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := t.transport.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, t.maxBody+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	var recorded *Response
+	if int64(len(respBody)) > t.maxBody {
+		recorded = newResponse(resp, nil)
+		recorded.BodyOmitted = true
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(respBody), resp.Body), resp.Body}
+	} else {
+		_ = resp.Body.Close()
+		recorded = newResponse(resp, respBody)
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	}
+
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, &Interaction{
+		Request:  newRequest(req, body),
+		Response: recorded,
+	})
+	t.mu.Unlock()
+	return resp, nil
+}
+
+// replay serves the request by the first unused interaction matching it.
+func (t *Transport) replay(req *http.Request, body []byte) (*http.Response, error) {
+	recorded := newRequest(req, body)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, interaction := range t.cassette.Interactions {
+		if t.used[i] || !t.match(interaction.Request, recorded) {
+			continue
+		}
+		if interaction.Response.BodyOmitted {
+			return nil, fmt.Errorf("%w: %s %s", ErrBodyNotRecorded, recorded.Method, recorded.URL)
+		}
+		respBody, err := interaction.Response.body()
+		if err != nil {
+			return nil, err
+		}
+		t.used[i] = true
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		header.Set("Content-Length", strconv.Itoa(len(respBody)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, recorded.Method, recorded.URL)
+}
+
+// match reports whether the recorded request matches the actual one.
+func (t *Transport) match(recorded, actual *Request) bool {
+	return recorded.Method == actual.Method &&
+		recorded.URL == actual.URL &&
+		recorded.BodySHA256 == actual.BodySHA256 &&
+		matchHeader(recorded.Header, actual.Header, t.ignored)
+}
+
+// readBody reads the body of the request into memory to hash it, in both modes. The bodies of the uploads are
+// buffered as well, so the payloads recorded or replayed should be small.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
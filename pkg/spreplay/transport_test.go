@@ -0,0 +1,163 @@
+package spreplay_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/pkg/spreplay"
+	"github.com/zkMeLabs/mechain-go-sdk/pkg/sptest"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// testMaxBodySize is the max size of the response bodies kept in the cassettes recorded by the tests.
+const testMaxBodySize = 256
+
+// exchange is a request sent in the tests and the response it gets.
+type exchange struct {
+	method string
+	path   string
+	body   string
+	// status and respBody are the response, err is set instead if the request fails.
+	status   int
+	respBody []byte
+	err      error
+}
+
+// newTestServer returns a Server with a public bucket holding a small binary object and a large object.
+func newTestServer(t *testing.T) *sptest.Server {
+	srv, err := sptest.NewServer(sptest.Option{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, srv.Close()) })
+
+	owner, _, err := types.NewAccount("owner")
+	require.NoError(t, err)
+	srv.AddBucket(storageTypes.BucketInfo{
+		Owner:      owner.GetAddress().String(),
+		BucketName: "bucket",
+		Visibility: storageTypes.VISIBILITY_TYPE_PUBLIC_READ,
+	})
+	for objectName, payload := range map[string][]byte{
+		"small": {0xff, 0xfe, 0x00, 0x01},
+		"large": bytes.Repeat([]byte("large payload "), testMaxBodySize),
+	} {
+		_, err = srv.AddObject(storageTypes.ObjectInfo{BucketName: "bucket", ObjectName: objectName})
+		require.NoError(t, err)
+		require.NoError(t, srv.WriteObject("bucket", objectName, payload))
+	}
+	return srv
+}
+
+// send sends the requests by the transport, the requests carry the date header as the Client does.
+func send(t *testing.T, transport http.RoundTripper, baseURL, date string) []*exchange {
+	exchanges := []*exchange{
+		{method: http.MethodGet, path: "/bucket/small"},
+		{method: http.MethodGet, path: "/bucket?max-keys=10&prefix=s"},
+		{method: http.MethodGet, path: "/bucket/missing"},
+		{method: http.MethodPut, path: "/bucket/small", body: "payload"},
+		{method: http.MethodGet, path: "/bucket/large"},
+	}
+	httpClient := &http.Client{Transport: transport}
+	for _, e := range exchanges {
+		req, err := http.NewRequest(e.method, baseURL+e.path, strings.NewReader(e.body))
+		require.NoError(t, err)
+		req.Header.Set(types.HTTPHeaderDate, date)
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			e.err = err
+			continue
+		}
+		e.status = resp.StatusCode
+		e.respBody, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	return exchanges
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := spreplay.New(path, spreplay.Option{Mode: spreplay.ModeRecord, MaxBodySize: testMaxBodySize})
+	require.NoError(t, err)
+	recorded := send(t, recorder, srv.URL(), "2024-01-01T00:00:00Z")
+	require.NoError(t, recorder.Save())
+	for _, e := range recorded {
+		require.NoError(t, e.err)
+	}
+	require.Equal(t, []byte{0xff, 0xfe, 0x00, 0x01}, recorded[0].respBody)
+	require.Equal(t, http.StatusNotFound, recorded[2].status)
+	require.Equal(t, http.StatusForbidden, recorded[3].status, "the anonymous upload is denied")
+	require.Len(t, recorded[4].respBody, len("large payload ")*testMaxBodySize, "the large body is passed to the caller")
+
+	cassette, err := spreplay.LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, len(recorded))
+	require.Equal(t, []string{"<normalized>"}, cassette.Interactions[0].Request.Header.Values(types.HTTPHeaderDate))
+	require.Equal(t, "base64", cassette.Interactions[0].Response.BodyEncoding)
+	require.Contains(t, cassette.Interactions[1].Response.Body, "<Name>bucket</Name>", "the XML is readable")
+	require.True(t, cassette.Interactions[4].Response.BodyOmitted)
+	require.Empty(t, cassette.Interactions[4].Response.Body)
+
+	// the requests are served in replay even though the server is gone and the dates differ
+	require.NoError(t, srv.Close())
+	player, err := spreplay.New(path, spreplay.Option{})
+	require.NoError(t, err)
+	replayed := send(t, player, srv.URL(), "2024-01-02T00:00:00Z")
+	for i, e := range replayed[:4] {
+		require.NoError(t, e.err)
+		require.Equal(t, recorded[i].status, e.status)
+		require.Equal(t, recorded[i].respBody, e.respBody)
+	}
+	require.ErrorIs(t, replayed[4].err, spreplay.ErrBodyNotRecorded)
+	require.Len(t, player.Unused(), 1)
+
+	// each interaction is served once
+	_, err = (&http.Client{Transport: player}).Get(srv.URL() + "/bucket/small")
+	require.ErrorIs(t, err, spreplay.ErrNoInteraction)
+}
+
+func TestReplayMatchesBody(t *testing.T) {
+	srv := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := spreplay.New(path, spreplay.Option{Mode: spreplay.ModeRecord})
+	require.NoError(t, err)
+	send(t, recorder, srv.URL(), "2024-01-01T00:00:00Z")
+	require.NoError(t, recorder.Save())
+
+	player, err := spreplay.New(path, spreplay.Option{})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPut, srv.URL()+"/bucket/small", strings.NewReader("another payload"))
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: player}).Do(req)
+	require.ErrorIs(t, err, spreplay.ErrNoInteraction)
+}
+
+func TestReplaySampleCassette(t *testing.T) {
+	player, err := spreplay.New(filepath.Join("testdata", "list_objects.json"), spreplay.Option{})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "http://sp.example/bucket?prefix=dir%2F&max-keys=10", nil)
+	require.NoError(t, err)
+	req.Header.Set(types.HTTPHeaderAuthorization, "GNFD1-ECDSA, Signature=03")
+	resp, err := (&http.Client{Transport: player}).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result types.ListObjectsResult
+	require.NoError(t, xml.NewDecoder(resp.Body).Decode(&result))
+	require.Len(t, result.Objects, 2)
+	require.Equal(t, "dir/a.txt", result.Objects[0].ObjectInfo.ObjectName)
+	require.Equal(t, uint64(2048), result.Objects[1].ObjectInfo.PayloadSize)
+	require.Equal(t, "10", result.MaxKeys)
+	require.Empty(t, player.Unused())
+}
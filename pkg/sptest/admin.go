@@ -0,0 +1,188 @@
+package sptest
+
+import (
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/evmos/evmos/v12/types/common"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// serveAdmin serves the admin APIs of the v1 and v2 versions, the requests should be signed.
+func (s *Server) serveAdmin(w http.ResponseWriter, r *http.Request) {
+	signer, err := s.authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if signer == "" {
+		writeError(w, errAccessDenied)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, types.AdminURLPrefix)
+	switch {
+	case r.Method != http.MethodGet:
+		writeError(w, errNotImplemented)
+	case path == types.AdminURLV1Version+"/get-approval", path == types.AdminURLV2Version+"/get-approval":
+		s.getApproval(w, r, signer)
+	case path == types.AdminURLV1Version+"/"+types.ChallengeUrl:
+		s.challenge(w, r, false)
+	case path == types.AdminURLV2Version+"/"+types.ChallengeUrl:
+		s.challenge(w, r, true)
+	default:
+		writeError(w, errNotImplemented)
+	}
+}
+
+// getApproval signs the approval of the message of creating a bucket, creating an object or migrating a bucket, the
+// message should be sent by the signer of the request.
+func (s *Server) getApproval(w http.ResponseWriter, r *http.Request, signer string) {
+	unsignedBytes, err := hex.DecodeString(r.Header.Get(types.HTTPHeaderUnsignedMsg))
+	if err != nil {
+		writeError(w, invalidArgument("invalid unsigned msg: %v", err))
+		return
+	}
+
+	var (
+		msg           proto.Message
+		creator       string
+		approval      *common.Approval
+		approvalBytes func() []byte
+	)
+	switch action := r.URL.Query().Get("action"); action {
+	case types.CreateBucketAction:
+		m := &storageTypes.MsgCreateBucket{}
+		if err = storageTypes.ModuleCdc.UnmarshalJSON(unsignedBytes, m); err == nil {
+			if m.PrimarySpApproval == nil {
+				m.PrimarySpApproval = &common.Approval{}
+			}
+			if m.PrimarySpApproval.GlobalVirtualGroupFamilyId == 0 {
+				m.PrimarySpApproval.GlobalVirtualGroupFamilyId = s.familyID
+			}
+		}
+		msg, creator, approval, approvalBytes = m, m.Creator, m.PrimarySpApproval, m.GetApprovalBytes
+	case types.CreateObjectAction:
+		m := &storageTypes.MsgCreateObject{}
+		if err = storageTypes.ModuleCdc.UnmarshalJSON(unsignedBytes, m); err == nil && m.PrimarySpApproval == nil {
+			m.PrimarySpApproval = &common.Approval{}
+		}
+		msg, creator, approval, approvalBytes = m, m.Creator, m.PrimarySpApproval, m.GetApprovalBytes
+	case types.MigrateBucketAction:
+		m := &storageTypes.MsgMigrateBucket{}
+		if err = storageTypes.ModuleCdc.UnmarshalJSON(unsignedBytes, m); err == nil && m.DstPrimarySpApproval == nil {
+			m.DstPrimarySpApproval = &common.Approval{}
+		}
+		msg, creator, approval, approvalBytes = m, m.Operator, m.DstPrimarySpApproval, m.GetApprovalBytes
+	default:
+		writeError(w, invalidArgument("unsupported action: %q", action))
+		return
+	}
+	if err != nil {
+		writeError(w, invalidArgument("invalid unsigned msg: %v", err))
+		return
+	}
+	if creator != signer {
+		writeError(w, errAccessDenied)
+		return
+	}
+
+	approval.ExpiredHeight = s.expired
+	if approval.Sig, err = s.operator.Sign(approvalBytes()); err != nil {
+		writeError(w, err)
+		return
+	}
+	signedBytes, err := storageTypes.ModuleCdc.MarshalJSON(msg)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set(types.HTTPHeaderSignedMsg, hex.EncodeToString(signedBytes))
+	w.WriteHeader(http.StatusOK)
+}
+
+// challenge returns the piece of the object and the hashes of the pieces the challenged SP keeps, the emulator only
+// keeps the primary replica whose pieces are the segments of the payload. The v1 version returns the hashes in the
+// headers and the piece in the body, the v2 version returns all of them in the XML body.
+func (s *Server) challenge(w http.ResponseWriter, r *http.Request, v2 bool) {
+	objectID, err := strconv.ParseUint(r.Header.Get(types.HTTPHeaderObjectID), 10, 64)
+	if err != nil {
+		writeError(w, invalidArgument("invalid object id: %v", err))
+		return
+	}
+	pieceIndex, err := strconv.Atoi(r.Header.Get(types.HTTPHeaderPieceIndex))
+	if err != nil || pieceIndex < 0 {
+		writeError(w, invalidArgument("invalid piece index: %q", r.Header.Get(types.HTTPHeaderPieceIndex)))
+		return
+	}
+	redundancyIndex, err := strconv.Atoi(r.Header.Get(types.HTTPHeaderRedundancyIndex))
+	if err != nil || redundancyIndex != types.PrimaryRedundancyIndex {
+		writeError(w, invalidArgument("only the primary replica(redundancy index %d) is kept by the emulator", types.PrimaryRedundancyIndex))
+		return
+	}
+
+	s.mu.Lock()
+	o := s.objectsByID[objectID]
+	if o == nil {
+		s.mu.Unlock()
+		writeError(w, errNoSuchObject)
+		return
+	}
+	if o.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		s.mu.Unlock()
+		writeError(w, errNotSealed)
+		return
+	}
+	hashes, integrityHash, err := s.pieceHashes(o)
+	var piece []byte
+	if err == nil && pieceIndex >= len(hashes) {
+		err = invalidArgument("the piece index %d exceeds the %d pieces of the object", pieceIndex, len(hashes))
+	}
+	if err == nil {
+		piece, err = s.readPiece(o, pieceIndex)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	pieceHashes := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		pieceHashes = append(pieceHashes, hex.EncodeToString(hash))
+	}
+	if v2 {
+		writeXML(w, &types.ChallengeV2Result{
+			ObjectID:        strconv.FormatUint(objectID, 10),
+			RedundancyIndex: strconv.Itoa(redundancyIndex),
+			PieceIndex:      strconv.Itoa(pieceIndex),
+			IntegrityHash:   hex.EncodeToString(integrityHash),
+			PieceHash:       strings.Join(pieceHashes, ","),
+			PieceData:       hex.EncodeToString(piece),
+		})
+		return
+	}
+	w.Header().Set(types.HTTPHeaderIntegrityHash, hex.EncodeToString(integrityHash))
+	w.Header().Set(types.HTTPHeaderPieceHash, strings.Join(pieceHashes, ","))
+	w.Header().Set(types.HTTPHeaderContentType, types.ContentDefault)
+	w.Header().Set(types.HTTPHeaderContentLength, strconv.Itoa(len(piece)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(piece)
+}
+
+// readPiece reads the piece of the primary replica, which is the segment of the payload.
+func (s *Server) readPiece(o *object, pieceIndex int) ([]byte, error) {
+	f, err := os.Open(s.payloadPath(o))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(io.NewSectionReader(f, int64(pieceIndex)*s.segmentSize, s.segmentSize))
+}
@@ -0,0 +1,354 @@
+package sptest
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	httplib "github.com/zkMeLabs/mechain-common/go/http"
+	"github.com/zkMeLabs/mechain-go-sdk/client"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// The headers of the off-chain-auth requests.
+const (
+	headerAppDomain    = "X-Gnfd-App-Domain"
+	headerRegNonce     = "X-Gnfd-App-Reg-Nonce"
+	headerRegPublicKey = "X-Gnfd-App-Reg-Public-Key"
+)
+
+// authState is the off-chain-auth state of a user in an app domain.
+type authState struct {
+	// nonce is the nonce of the GNFD1-EDDSA key registered last, the next registration should use nonce+1.
+	nonce int32
+	// publicKey is the GNFD1-EDDSA key registered last, it replaces the former one.
+	publicKey string
+	expiry    time.Time
+	// keysV2 maps the GNFD2-EDDSA keys to their expiration time, a user can register several keys in a domain.
+	keysV2 map[string]time.Time
+}
+
+// authKey returns the key of the off-chain-auth state of the user in the app domain.
+func authKey(userAddress, domain string) string {
+	return userAddress + "/" + domain
+}
+
+// authState returns the off-chain-auth state of the user in the app domain, it is created if it does not exist.
+// s.mu should be held.
+func (s *Server) authState(userAddress, domain string) *authState {
+	key := authKey(userAddress, domain)
+	state := s.auth[key]
+	if state == nil {
+		state = &authState{keysV2: make(map[string]time.Time)}
+		s.auth[key] = state
+	}
+	return state
+}
+
+// authenticate verifies the signature of the request and returns the address of the signer. The requests without
+// signatures are anonymous, whose signer is empty, they can only access the public resources.
+func (s *Server) authenticate(r *http.Request) (string, error) {
+	if r.URL.Query().Has(types.HTTPHeaderAuthorization) {
+		signer, err := client.VerifyPresignedRequest(r)
+		if err != nil {
+			return "", unauthorized("%v", err)
+		}
+		return signer.String(), nil
+	}
+
+	authStr := r.Header.Get(types.HTTPHeaderAuthorization)
+	if authStr == "" {
+		return "", nil
+	}
+	if err := s.checkExpiry(r.Header.Get(httplib.HTTPHeaderExpiryTimestamp)); err != nil {
+		return "", err
+	}
+	signature, err := parseSignature(authStr)
+	if err != nil {
+		return "", err
+	}
+	unsignedMsg := httplib.GetMsgToSignInGNFD1Auth(r)
+
+	switch {
+	case strings.HasPrefix(authStr, httplib.Gnfd1Ecdsa):
+		if len(unsignedMsg) != crypto.DigestLength {
+			unsignedMsg = crypto.Keccak256(unsignedMsg)
+		}
+		return recoverSigner(unsignedMsg, signature)
+	case strings.HasPrefix(authStr, httplib.Gnfd1Eddsa), strings.HasPrefix(authStr, httplib.Gnfd2Eddsa):
+		return s.verifyOffChainAuth(r, strings.HasPrefix(authStr, httplib.Gnfd2Eddsa), unsignedMsg, signature)
+	default:
+		return "", unauthorized("unsupported authorization: %s", authStr)
+	}
+}
+
+// checkExpiry checks the expiry timestamp of a signed request, the request should not have expired nor expire too
+// far in the future.
+func (s *Server) checkExpiry(expiryStr string) error {
+	expiry, err := time.Parse(time.RFC3339, expiryStr)
+	if err != nil {
+		return unauthorized("invalid expiry timestamp: %q", expiryStr)
+	}
+	now := s.now()
+	if now.After(expiry) {
+		return unauthorized("the request has expired at %s", expiryStr)
+	}
+	if expiry.Sub(now) > time.Second*time.Duration(httplib.MaxExpiryAgeInSec) {
+		return unauthorized("the expiry timestamp %s is too far in the future", expiryStr)
+	}
+	return nil
+}
+
+// parseSignature returns the signature in the authorization, e.g. "GNFD1-ECDSA, Signature=...".
+func parseSignature(authStr string) ([]byte, error) {
+	index := strings.LastIndex(authStr, "Signature=")
+	if index < 0 {
+		return nil, unauthorized("the signature is missing in the authorization")
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(authStr[index+len("Signature="):]), "0x"))
+	if err != nil {
+		return nil, unauthorized("invalid signature: %v", err)
+	}
+	return signature, nil
+}
+
+// recoverSigner returns the address of the account which signs the digest by the secp256k1 signature.
+func recoverSigner(digest, signature []byte) (string, error) {
+	if len(signature) == crypto.SignatureLength && signature[crypto.RecoveryIDOffset] >= 27 {
+		// the signatures of the wallets use 27 and 28 as the recovery ID
+		signature = append([]byte(nil), signature...)
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(digest, signature)
+	if err != nil {
+		return "", unauthorized("failed to recover the signer: %v", err)
+	}
+	return sdk.AccAddress(crypto.PubkeyToAddress(*pubKey).Bytes()).String(), nil
+}
+
+// verifyOffChainAuth verifies the GNFD1-EDDSA or GNFD2-EDDSA signature by the keys registered by the user in the
+// app domain, and returns the address of the user.
+func (s *Server) verifyOffChainAuth(r *http.Request, v2 bool, unsignedMsg, signature []byte) (string, error) {
+	user, err := sdk.AccAddressFromHexUnsafe(r.Header.Get(types.HTTPHeaderUserAddress))
+	if err != nil {
+		return "", unauthorized("invalid user address: %v", err)
+	}
+	domain := r.Header.Get(headerAppDomain)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.authState(user.String(), domain)
+	now := s.now()
+	if !v2 {
+		if state.publicKey == "" || now.After(state.expiry) {
+			return "", unauthorized("no GNFD1-EDDSA key is registered by %s in %s", user, domain)
+		}
+		keyBytes, err := hex.DecodeString(state.publicKey)
+		if err != nil {
+			return "", err
+		}
+		var publicKey eddsa.PublicKey
+		if _, err = publicKey.SetBytes(keyBytes); err != nil {
+			return "", err
+		}
+		if ok, err := publicKey.Verify(signature, unsignedMsg, mimc.NewMiMC()); err != nil || !ok {
+			return "", unauthorized("the GNFD1-EDDSA signature does not match the key registered by %s", user)
+		}
+		return user.String(), nil
+	}
+
+	for key, expiry := range state.keysV2 {
+		publicKey, err := hex.DecodeString(key)
+		if err != nil || now.After(expiry) {
+			continue
+		}
+		if len(publicKey) == ed25519.PublicKeySize && ed25519.Verify(publicKey, unsignedMsg, signature) {
+			return user.String(), nil
+		}
+	}
+	return "", unauthorized("the GNFD2-EDDSA signature does not match the keys registered by %s", user)
+}
+
+// serveAuth serves the off-chain-auth APIs.
+func (s *Server) serveAuth(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/auth/request_nonce" && r.Method == http.MethodGet:
+		s.requestNonce(w, r)
+	case r.URL.Path == "/auth/update_key" && r.Method == http.MethodPost:
+		s.updateKey(w, r, false)
+	case r.URL.Path == "/auth/update_key_v2" && r.Method == http.MethodPost:
+		s.updateKey(w, r, true)
+	case r.URL.Path == "/auth/keys_v2" && r.Method == http.MethodGet:
+		s.listKeysV2(w, r)
+	case r.URL.Path == "/auth/delete_keys_v2" && r.Method == http.MethodPost:
+		s.deleteKeysV2(w, r)
+	default:
+		writeError(w, errNotImplemented)
+	}
+}
+
+// requestNonceResp is the response of the request_nonce API.
+type requestNonceResp struct {
+	XMLName          xml.Name `xml:"RequestNonceResp"`
+	CurrentNonce     int32    `xml:"CurrentNonce"`
+	NextNonce        int32    `xml:"NextNonce"`
+	CurrentPublicKey string   `xml:"CurrentPublicKey"`
+	ExpiryDate       int64    `xml:"ExpiryDate"`
+}
+
+// requestNonce returns the nonce the user should use to register the next GNFD1-EDDSA key in the app domain.
+func (s *Server) requestNonce(w http.ResponseWriter, r *http.Request) {
+	user, err := sdk.AccAddressFromHexUnsafe(r.Header.Get(types.HTTPHeaderUserAddress))
+	if err != nil {
+		writeError(w, invalidArgument("invalid user address: %v", err))
+		return
+	}
+	s.mu.Lock()
+	state := s.authState(user.String(), r.Header.Get(headerAppDomain))
+	resp := &requestNonceResp{
+		CurrentNonce:     state.nonce,
+		NextNonce:        state.nonce + 1,
+		CurrentPublicKey: state.publicKey,
+	}
+	if !state.expiry.IsZero() {
+		resp.ExpiryDate = state.expiry.UnixMilli()
+	}
+	s.mu.Unlock()
+	writeXML(w, resp)
+}
+
+// updateKey registers a GNFD1-EDDSA or GNFD2-EDDSA key of the user in the app domain. The request is signed by the
+// GNFD1-ETH-PERSONAL_SIGN signature of the user over the message which declares the key, as the wallets do.
+func (s *Server) updateKey(w http.ResponseWriter, r *http.Request, v2 bool) {
+	user, err := sdk.AccAddressFromHexUnsafe(r.Header.Get(types.HTTPHeaderUserAddress))
+	if err != nil {
+		writeError(w, invalidArgument("invalid user address: %v", err))
+		return
+	}
+	domain := r.Header.Get(headerAppDomain)
+	publicKey := r.Header.Get(headerRegPublicKey)
+	if domain == "" || publicKey == "" {
+		writeError(w, invalidArgument("the app domain and the public key should be set"))
+		return
+	}
+	expiry, err := time.Parse(time.RFC3339, r.Header.Get(httplib.HTTPHeaderExpiryTimestamp))
+	if err != nil || s.now().After(expiry) {
+		writeError(w, invalidArgument("invalid expiry timestamp: %q", r.Header.Get(httplib.HTTPHeaderExpiryTimestamp)))
+		return
+	}
+
+	authStr, ok := strings.CutPrefix(r.Header.Get(types.HTTPHeaderAuthorization), httplib.Gnfd1EthPersonalSign+",SignedMsg=")
+	index := strings.LastIndex(authStr, ",Signature=")
+	if !ok || index < 0 {
+		writeError(w, unauthorized("the authorization should be signed by %s", httplib.Gnfd1EthPersonalSign))
+		return
+	}
+	signedMsg := strings.ReplaceAll(authStr[:index], "\\n", "\n")
+	signature, err := hexutil.Decode(authStr[index+len(",Signature="):])
+	if err != nil {
+		writeError(w, unauthorized("invalid signature: %v", err))
+		return
+	}
+	signer, err := recoverSigner(accounts.TextHash([]byte(signedMsg)), signature)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if signer != user.String() {
+		writeError(w, unauthorized("the message is signed by %s instead of %s", signer, user))
+		return
+	}
+	if !strings.Contains(signedMsg, "Register your identity public key "+publicKey) {
+		writeError(w, unauthorized("the signed message does not declare the public key %s", publicKey))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.authState(user.String(), domain)
+	if v2 {
+		state.keysV2[publicKey] = expiry
+	} else {
+		nonce, err := strconv.ParseInt(r.Header.Get(headerRegNonce), 10, 32)
+		if err != nil || int32(nonce) != state.nonce+1 || !strings.HasSuffix(signedMsg, "with nonce: "+strconv.FormatInt(nonce, 10)) {
+			writeError(w, unauthorized("the nonce should be %d", state.nonce+1))
+			return
+		}
+		state.nonce, state.publicKey, state.expiry = int32(nonce), publicKey, expiry
+	}
+	w.Header().Set(types.HTTPHeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, `{"result":true}`)
+}
+
+// listKeysV2Resp is the response of the keys_v2 API.
+type listKeysV2Resp struct {
+	XMLName    xml.Name `xml:"ListUserPublicKeyV2Resp"`
+	PublicKeys []string `xml:"Result"`
+}
+
+// listKeysV2 lists the GNFD2-EDDSA keys registered by the user in the app domain which have not expired.
+func (s *Server) listKeysV2(w http.ResponseWriter, r *http.Request) {
+	user, err := sdk.AccAddressFromHexUnsafe(r.Header.Get(types.HTTPHeaderUserAddress))
+	if err != nil {
+		writeError(w, invalidArgument("invalid user address: %v", err))
+		return
+	}
+	s.mu.Lock()
+	resp := &listKeysV2Resp{PublicKeys: make([]string, 0)}
+	now := s.now()
+	for key, expiry := range s.authState(user.String(), r.Header.Get(headerAppDomain)).keysV2 {
+		if !now.After(expiry) {
+			resp.PublicKeys = append(resp.PublicKeys, key)
+		}
+	}
+	s.mu.Unlock()
+	sort.Strings(resp.PublicKeys)
+	writeXML(w, resp)
+}
+
+// deleteKeysV2Resp is the response of the delete_keys_v2 API.
+type deleteKeysV2Resp struct {
+	XMLName xml.Name `xml:"DeleteUserPublicKeyV2Resp"`
+	Result  bool     `xml:"Result"`
+}
+
+// deleteKeysV2 deletes the GNFD2-EDDSA keys of the user in the app domain, the request should be signed by the user.
+func (s *Server) deleteKeysV2(w http.ResponseWriter, r *http.Request) {
+	signer, err := s.authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	user, err := sdk.AccAddressFromHexUnsafe(r.Header.Get(types.HTTPHeaderUserAddress))
+	if err != nil || signer != user.String() {
+		writeError(w, errAccessDenied)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	state := s.authState(user.String(), r.Header.Get(headerAppDomain))
+	for _, key := range strings.Split(string(body), ",") {
+		delete(state.keysV2, strings.TrimSpace(key))
+	}
+	s.mu.Unlock()
+	writeXML(w, &deleteKeysV2Resp{Result: true})
+}
@@ -0,0 +1,62 @@
+package sptest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// spError is an error response of the SP, it is decoded by the Client into types.ErrResponse.
+type spError struct {
+	statusCode int
+	code       string
+	message    string
+}
+
+func (e *spError) Error() string {
+	return fmt.Sprintf("%s: %s", e.code, e.message)
+}
+
+// The error responses the handlers return.
+var (
+	errNoSuchBucket   = &spError{http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist."}
+	errNoSuchObject   = &spError{http.StatusNotFound, "NoSuchObject", "The specified object does not exist."}
+	errNoSuchGroup    = &spError{http.StatusNotFound, "NoSuchGroup", "The specified group does not exist."}
+	errAccessDenied   = &spError{http.StatusForbidden, "AccessDenied", "Access Denied"}
+	errNotImplemented = &spError{http.StatusNotImplemented, "NotImplemented", "The API is not implemented by the emulator."}
+	errNoUploadRecord = &spError{http.StatusNotFound, "NoUploadingRecord", "no uploading record"}
+	errNotSealed      = &spError{http.StatusForbidden, "ObjectNotSealed", "The object has not been sealed."}
+)
+
+// invalidArgument returns the error response of an invalid request.
+func invalidArgument(format string, args ...interface{}) error {
+	return &spError{http.StatusBadRequest, "InvalidArgument", fmt.Sprintf(format, args...)}
+}
+
+// unauthorized returns the error response of a request whose signature fails the verification.
+func unauthorized(format string, args ...interface{}) error {
+	return &spError{http.StatusUnauthorized, "SignatureDoesNotMatch", fmt.Sprintf(format, args...)}
+}
+
+// errorResponse is the XML body of the error responses.
+type errorResponse struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+// writeError writes the error response, the errors other than *spError are internal errors.
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*spError)
+	if !ok {
+		e = &spError{http.StatusInternalServerError, "InternalError", err.Error()}
+	}
+	body, _ := xml.Marshal(&errorResponse{Code: e.code, Message: e.message})
+	w.Header().Set(types.HTTPHeaderContentType, types.ContentTypeXML)
+	w.Header().Set(types.HTTPHeaderContentLength, strconv.Itoa(len(body)))
+	w.WriteHeader(e.statusCode)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,227 @@
+package sptest
+
+import (
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// The limits of the pagination of the groups, the same as the SP's.
+const (
+	defaultGroupsLimit = 50
+	maxGroupsLimit     = 1000
+)
+
+// listBucketsResponse is the response of the ListBuckets API.
+type listBucketsResponse struct {
+	XMLName xml.Name `xml:"GfSpGetUserBucketsResponse"`
+	types.ListBucketsResult
+}
+
+// listGroupsResponse is the response of the ListGroup API.
+type listGroupsResponse struct {
+	XMLName xml.Name `xml:"GfSpGetGroupListResponse"`
+	types.ListGroupsResult
+}
+
+// groupMembersResponse is the response of the ListGroupMembers API.
+type groupMembersResponse struct {
+	XMLName xml.Name `xml:"GfSpGetGroupMembersResponse"`
+	types.GroupMembersResult
+}
+
+// groupsResponse is the response of the ListGroupsByAccount and the ListGroupsByOwner APIs.
+type groupsResponse struct {
+	XMLName xml.Name
+	types.GroupsResult
+}
+
+// userAddress returns the address in the user address header, which the lists are for.
+func userAddress(r *http.Request) (string, error) {
+	addr, err := sdk.AccAddressFromHexUnsafe(r.Header.Get(types.HTTPHeaderUserAddress))
+	if err != nil {
+		return "", invalidArgument("invalid user address: %v", err)
+	}
+	return addr.String(), nil
+}
+
+// groupsLimit returns the number of the groups to list by the limit parameter.
+func groupsLimit(r *http.Request) int {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		return defaultGroupsLimit
+	}
+	if limit > maxGroupsLimit {
+		return maxGroupsLimit
+	}
+	return limit
+}
+
+// sortedGroups returns the groups matching the filter in the order of their IDs. s.mu should be held.
+func (s *Server) sortedGroups(match func(g *group) bool) []*group {
+	groups := make([]*group, 0)
+	for _, g := range s.groups {
+		if match(g) {
+			groups = append(groups, g)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].info.Id.LT(groups[j].info.Id)
+	})
+	return groups
+}
+
+// listBuckets lists the buckets owned by the user in the order of their names.
+func (s *Server) listBuckets(w http.ResponseWriter, r *http.Request) {
+	owner, err := userAddress(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mu.Lock()
+	result := types.ListBucketsResult{Buckets: make([]*types.BucketMetaWithVGF, 0)}
+	for _, b := range s.buckets {
+		if b.info.Owner == owner {
+			info := *b.info
+			result.Buckets = append(result.Buckets, &types.BucketMetaWithVGF{BucketInfo: &info})
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(result.Buckets, func(i, j int) bool {
+		return result.Buckets[i].BucketInfo.BucketName < result.Buckets[j].BucketInfo.BucketName
+	})
+	writeXML(w, &listBucketsResponse{ListBucketsResult: result})
+}
+
+// listGroups searches the groups whose names start with the prefix and contain the name after the prefix.
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	name, prefix, sourceType := query.Get("name"), query.Get("prefix"), query.Get("source-type")
+	offset, err := strconv.Atoi(query.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	limit := groupsLimit(r)
+
+	s.mu.Lock()
+	groups := s.sortedGroups(func(g *group) bool {
+		groupName, ok := strings.CutPrefix(g.info.GroupName, prefix)
+		return ok && strings.Contains(groupName, name) && (sourceType == "" || g.info.SourceType.String() == sourceType)
+	})
+	result := types.ListGroupsResult{Groups: make([]*types.GroupMeta, 0), Count: int64(len(groups))}
+	for i := offset; i < len(groups) && i < offset+limit; i++ {
+		info := *groups[i].info
+		result.Groups = append(result.Groups, &types.GroupMeta{
+			Group:           &info,
+			NumberOfMembers: int64(len(groups[i].members)),
+			Operator:        info.Owner,
+		})
+	}
+	s.mu.Unlock()
+	writeXML(w, &listGroupsResponse{ListGroupsResult: result})
+}
+
+// listGroupsOf lists the groups matching the filter after the group ID of the start-after parameter, the account ID
+// and the expiration time of the entries are those of the account.
+func (s *Server) listGroupsOf(w http.ResponseWriter, r *http.Request, root string, account string, match func(g *group) bool) {
+	startAfter, err := strconv.ParseUint(r.URL.Query().Get("start-after"), 10, 64)
+	if err != nil {
+		startAfter = 0
+	}
+	limit := groupsLimit(r)
+
+	s.mu.Lock()
+	result := types.GroupsResult{Groups: make([]*types.GroupMembers, 0)}
+	for _, g := range s.sortedGroups(match) {
+		if g.info.Id.Uint64() <= startAfter {
+			continue
+		}
+		if len(result.Groups) == limit {
+			break
+		}
+		result.Groups = append(result.Groups, groupMember(g, account))
+	}
+	s.mu.Unlock()
+	writeXML(w, &groupsResponse{XMLName: xml.Name{Local: root}, GroupsResult: result})
+}
+
+// listOwnedGroups lists the groups owned by the user.
+func (s *Server) listOwnedGroups(w http.ResponseWriter, r *http.Request) {
+	owner, err := userAddress(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.listGroupsOf(w, r, "GfSpGetUserOwnedGroupsResponse", owner, func(g *group) bool {
+		return g.info.Owner == owner
+	})
+}
+
+// listUserGroups lists the groups the user is a member of, including those the user's membership has expired.
+func (s *Server) listUserGroups(w http.ResponseWriter, r *http.Request) {
+	member, err := userAddress(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.listGroupsOf(w, r, "GfSpGetUserGroupsResponse", member, func(g *group) bool {
+		_, ok := g.members[member]
+		return ok
+	})
+}
+
+// listGroupMembers lists the members of the group in the order of their addresses, after the address of the
+// start-after parameter.
+func (s *Server) listGroupMembers(w http.ResponseWriter, r *http.Request) {
+	groupID, err := strconv.ParseUint(r.URL.Query().Get("group-id"), 10, 64)
+	if err != nil {
+		writeError(w, invalidArgument("invalid group-id: %v", err))
+		return
+	}
+	startAfter := r.URL.Query().Get("start-after")
+	limit := groupsLimit(r)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g := s.groups[groupID]
+	if g == nil {
+		writeError(w, errNoSuchGroup)
+		return
+	}
+	members := make([]string, 0, len(g.members))
+	for member := range g.members {
+		if member > startAfter {
+			members = append(members, member)
+		}
+	}
+	sort.Strings(members)
+	result := types.GroupMembersResult{Groups: make([]*types.GroupMembers, 0)}
+	for _, member := range members {
+		if len(result.Groups) == limit {
+			break
+		}
+		result.Groups = append(result.Groups, groupMember(g, member))
+	}
+	writeXML(w, &groupMembersResponse{GroupMembersResult: result})
+}
+
+// groupMember returns the entry of the account in the group, the expiration time is empty if the account is not a
+// member, e.g. the owner.
+func groupMember(g *group, account string) *types.GroupMembers {
+	info := *g.info
+	entry := &types.GroupMembers{
+		Group:     &info,
+		Operator:  info.Owner,
+		AccountID: account,
+	}
+	if expiration, ok := g.members[account]; ok {
+		entry.ExpirationTime = strconv.FormatInt(expiration.Unix(), 10)
+	}
+	return entry
+}
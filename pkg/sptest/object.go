@@ -0,0 +1,377 @@
+package sptest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// listObjectsMaxKeys is the max number of the objects listed by a request, the same as the SP's.
+const listObjectsMaxKeys = 1000
+
+// isPublic reports whether the object can be read by anyone.
+func isPublic(b *bucket, o *object) bool {
+	visibility := o.info.Visibility
+	if visibility == storageTypes.VISIBILITY_TYPE_INHERIT {
+		visibility = b.info.Visibility
+	}
+	return visibility == storageTypes.VISIBILITY_TYPE_PUBLIC_READ
+}
+
+// uploadTarget returns the object the payload is uploaded to, the signer should be the owner of the bucket or the
+// creator of the object, and the object should be waiting for its payload. s.mu should be held.
+func (s *Server) uploadTarget(signer, bucketName, objectName string) (*object, error) {
+	o, err := s.getObject(bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	if signer == "" || (signer != s.buckets[bucketName].info.Owner && signer != o.info.Creator) {
+		return nil, errAccessDenied
+	}
+	if o.info.ObjectStatus != storageTypes.OBJECT_STATUS_CREATED {
+		return nil, invalidArgument("the object is in the %s status instead of waiting for the payload", o.info.ObjectStatus)
+	}
+	return o, nil
+}
+
+// putObject receives the whole payload of an object.
+func (s *Server) putObject(w http.ResponseWriter, r *http.Request, signer, bucketName, objectName string) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.uploadTarget(signer, bucketName, objectName)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err = os.WriteFile(s.payloadPath(o), payload, types.FilePermMode); err != nil {
+		writeError(w, err)
+		return
+	}
+	o.uploaded, o.uploading = int64(len(payload)), true
+	if err = s.seal(o); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// putObjectPart receives a part of the payload of an object uploaded by the resumable upload, the part should
+// start at the end of the parts received.
+func (s *Server) putObjectPart(w http.ResponseWriter, r *http.Request, signer, bucketName, objectName string) {
+	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if err != nil {
+		writeError(w, invalidArgument("invalid offset: %v", err))
+		return
+	}
+	complete, err := strconv.ParseBool(r.URL.Query().Get("complete"))
+	if err != nil {
+		writeError(w, invalidArgument("invalid complete: %v", err))
+		return
+	}
+	part, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.uploadTarget(signer, bucketName, objectName)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if offset != 0 && offset != o.uploaded {
+		writeError(w, invalidArgument("the offset %d does not match the uploaded size %d", offset, o.uploaded))
+		return
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if offset == 0 {
+		flag |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(s.payloadPath(o), flag, types.FilePermMode)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	_, err = f.Write(part)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	o.uploaded, o.uploading = offset+int64(len(part)), true
+
+	if complete {
+		if err = s.seal(o); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// seal verifies the payload received against the payload size and the checksum of the object and seals the object.
+// The payload is discarded if it is invalid, so that it can be uploaded again. s.mu should be held.
+func (s *Server) seal(o *object) error {
+	err := func() error {
+		if o.uploaded != int64(o.info.PayloadSize) {
+			return invalidArgument("the payload size %d does not match the object's %d", o.uploaded, o.info.PayloadSize)
+		}
+		if len(o.info.Checksums) == 0 {
+			return nil
+		}
+		_, integrityHash, err := s.pieceHashes(o)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(integrityHash, o.info.Checksums[0]) {
+			return invalidArgument("the payload does not match the checksum of the object")
+		}
+		return nil
+	}()
+	if err != nil {
+		o.uploaded, o.uploading = 0, false
+		_ = os.Remove(s.payloadPath(o))
+		return err
+	}
+	o.info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	o.uploading = false
+	return nil
+}
+
+// pieceHashes returns the hashes of the segments of the payload and their integrity hash, as the primary SP
+// computes.
+func (s *Server) pieceHashes(o *object) ([][]byte, []byte, error) {
+	f, err := os.Open(s.payloadPath(o))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var hashes [][]byte
+	integrity := sha256.New()
+	buf := make([]byte, s.segmentSize)
+	for {
+		n, err := io.ReadFull(f, buf)
+		if n > 0 {
+			hash := sha256.Sum256(buf[:n])
+			hashes = append(hashes, hash[:])
+			integrity.Write(hash[:])
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return hashes, integrity.Sum(nil), nil
+}
+
+// getUploadProgress returns the upload progress of the object.
+func (s *Server) getUploadProgress(w http.ResponseWriter, bucketName, objectName string) {
+	s.mu.Lock()
+	o, err := s.getObject(bucketName, objectName)
+	var progress string
+	switch {
+	case err != nil:
+	case o.info.ObjectStatus == storageTypes.OBJECT_STATUS_SEALED:
+		progress = "object is sealed"
+	case o.uploading:
+		progress = fmt.Sprintf("object is uploading, %d of %d bytes received", o.uploaded, o.info.PayloadSize)
+	default:
+		progress = "object is waiting for the payload"
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeXML(w, &types.UploadProgress{ProgressDescription: progress})
+}
+
+// getUploadOffset returns the offset the resumable upload of the object should continue from.
+func (s *Server) getUploadOffset(w http.ResponseWriter, bucketName, objectName string) {
+	s.mu.Lock()
+	o, err := s.getObject(bucketName, objectName)
+	if err == nil && !o.uploading {
+		err = errNoUploadRecord
+	}
+	var offset uint64
+	if err == nil {
+		offset = uint64(o.uploaded)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeXML(w, &types.UploadOffset{Offset: offset})
+}
+
+// getObjectPayload serves the payload of a sealed object, or the range of it. The private objects can only be read
+// by their owners.
+func (s *Server) getObjectPayload(w http.ResponseWriter, r *http.Request, signer, bucketName, objectName string) {
+	s.mu.Lock()
+	o, err := s.getObject(bucketName, objectName)
+	if err == nil && !isPublic(s.buckets[bucketName], o) && signer != o.info.Owner {
+		err = errAccessDenied
+	}
+	if err == nil && o.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		err = errNotSealed
+	}
+	var (
+		f           *os.File
+		size        int64
+		contentType string
+	)
+	if err == nil {
+		f, err = os.Open(s.payloadPath(o))
+		size, contentType = int64(o.info.PayloadSize), o.info.ContentType
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer f.Close()
+
+	start, end, ranged, err := parseRange(r.Header.Get(types.HTTPHeaderRange), size)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set(types.HTTPHeaderContentType, contentType)
+	w.Header().Set(types.HTTPHeaderContentLength, strconv.FormatInt(end-start, 10))
+	if ranged {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, size))
+		w.WriteHeader(http.StatusPartialContent)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	_, _ = io.Copy(w, io.NewSectionReader(f, start, end-start))
+}
+
+// parseRange parses the range header, e.g. "bytes=0-99" or "bytes=100-", and returns the half-open range of the
+// payload to serve. The whole payload is served if the header is not set.
+func parseRange(rangeStr string, size int64) (start, end int64, ranged bool, err error) {
+	if rangeStr == "" {
+		return 0, size, false, nil
+	}
+	spec, ok := strings.CutPrefix(rangeStr, "bytes=")
+	first, last, found := strings.Cut(spec, "-")
+	if !ok || !found {
+		return 0, 0, false, invalidArgument("invalid range: %s", rangeStr)
+	}
+	if start, err = strconv.ParseInt(first, 10, 64); err != nil || start < 0 || start >= size {
+		return 0, 0, false, invalidArgument("invalid range: %s", rangeStr)
+	}
+	end = size
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return 0, 0, false, invalidArgument("invalid range: %s", rangeStr)
+		}
+		// the end of the header is inclusive
+		end++
+		if end > size {
+			end = size
+		}
+	}
+	return start, end, true, nil
+}
+
+// listObjectsResponse is the response of the ListObjects API.
+type listObjectsResponse struct {
+	XMLName xml.Name `xml:"GfSpListObjectsByBucketNameResponse"`
+	types.ListObjectsResult
+}
+
+// listObjects lists the objects of the bucket in the order of their names. The objects under the same common prefix
+// are rolled up into the common prefix if the delimiter is set.
+func (s *Server) listObjects(w http.ResponseWriter, r *http.Request, bucketName string) {
+	query := r.URL.Query()
+	prefix, delimiter, startAfter := query.Get("prefix"), query.Get("delimiter"), query.Get("start-after")
+	maxKeys, err := strconv.ParseUint(query.Get("max-keys"), 10, 64)
+	if err != nil || maxKeys == 0 || maxKeys > listObjectsMaxKeys {
+		maxKeys = listObjectsMaxKeys
+	}
+	// the continuation token is the base64-encoded name of the object the listing continues from
+	var continueFrom string
+	if token := query.Get("continuation-token"); token != "" {
+		name, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			writeError(w, invalidArgument("invalid continuation-token: %v", err))
+			return
+		}
+		continueFrom = string(name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.buckets[bucketName]
+	if b == nil {
+		writeError(w, errNoSuchBucket)
+		return
+	}
+	names := make([]string, 0, len(b.objects))
+	for name := range b.objects {
+		if strings.HasPrefix(name, prefix) && name > startAfter && name >= continueFrom {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := types.ListObjectsResult{
+		Objects:           make([]*types.ObjectMeta, 0),
+		CommonPrefixes:    make([]string, 0),
+		MaxKeys:           strconv.FormatUint(maxKeys, 10),
+		Name:              bucketName,
+		Prefix:            prefix,
+		Delimiter:         delimiter,
+		ContinuationToken: query.Get("continuation-token"),
+	}
+	var count uint64
+	for _, name := range names {
+		// the objects under the common prefix listed last have been rolled up
+		if n := len(result.CommonPrefixes); n > 0 && strings.HasPrefix(name, result.CommonPrefixes[n-1]) {
+			continue
+		}
+		if count == maxKeys {
+			result.IsTruncated = true
+			result.NextContinuationToken = base64.StdEncoding.EncodeToString([]byte(name))
+			break
+		}
+		count++
+		if delimiter != "" {
+			if index := strings.Index(name[len(prefix):], delimiter); index >= 0 {
+				result.CommonPrefixes = append(result.CommonPrefixes, name[:len(prefix)+index+len(delimiter)])
+				continue
+			}
+		}
+		info := *b.objects[name].info
+		result.Objects = append(result.Objects, &types.ObjectMeta{ObjectInfo: &info})
+	}
+	result.KeyCount = strconv.FormatUint(count, 10)
+	writeXML(w, &listObjectsResponse{ListObjectsResult: result})
+}
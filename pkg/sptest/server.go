@@ -0,0 +1,423 @@
+// Package sptest provides an embeddable storage provider emulator, so that the integration tests of the download,
+// the resumable upload and the failover logic of the Client can run without a real SP.
+//
+// The emulator serves the REST API of the SP used by the Client on a httptest.Server: the object upload(including
+// the resumable upload) and download(including the range download), the upload progress, the lists of the objects,
+// the buckets and the groups, the approvals of creating buckets and objects, the challenge admin APIs and the
+// off-chain-auth nonce and key registration. The requests are authenticated by the GNFD1 and GNFD2 signatures as the
+// SP does, and the payloads are stored on disk.
+//
+// The chain is not emulated, the buckets, the objects and the groups the tests rely on are added by AddBucket,
+// AddObject and AddGroup as if they have been created on chain.
+package sptest
+
+import (
+	"encoding/xml"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+// defaultSegmentSize is the default size of the segments the payloads are split into, the same as the chain's
+// default.
+const defaultSegmentSize = 16 * 1024 * 1024
+
+// Option - Configurations for the Server.
+type Option struct {
+	// Operator is the operator account of the SP which signs the approvals, a new account is created if it is not set.
+	Operator *types.Account
+	// Dir is the directory the payloads are stored in, a temporary directory removed by Close is used if it is not
+	// set.
+	Dir string
+	// SegmentSize is the size of the segments the payloads are split into, which decides the piece hashes of the
+	// challenges and the checksums the payloads are verified against. 16MiB is used if it is not set.
+	SegmentSize int64
+	// FamilyID is the global virtual group family ID set in the approvals of creating buckets.
+	FamilyID uint32
+	// ApprovalExpiredHeight is the expired height set in the approvals, the approvals never expire if it is not set.
+	ApprovalExpiredHeight uint64
+	// Now returns the current time, which decides the expiration of the signatures and the registered keys.
+	// time.Now is used if it is not set.
+	Now func() time.Time
+}
+
+// Server - The storage provider emulator.
+type Server struct {
+	srv         *httptest.Server
+	operator    *types.Account
+	dir         string
+	removeDir   bool
+	segmentSize int64
+	familyID    uint32
+	expired     uint64
+	now         func() time.Time
+
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	objectsByID map[uint64]*object
+	groups      map[uint64]*group
+	sequences   map[string]uint64
+	// auth keeps the off-chain-auth state by the user address and the app domain.
+	auth map[string]*authState
+}
+
+// bucket is a bucket and its objects.
+type bucket struct {
+	info    *storageTypes.BucketInfo
+	objects map[string]*object
+}
+
+// object is an object and its payload.
+type object struct {
+	info *storageTypes.ObjectInfo
+	// uploaded is the size of the payload received, it is the offset of the resumable upload.
+	uploaded int64
+	// uploading reports whether the payload is being received, it is false before the first part arrives.
+	uploading bool
+}
+
+// group is a group and its members.
+type group struct {
+	info *storageTypes.GroupInfo
+	// members maps the addresses of the members to their expiration time.
+	members map[string]time.Time
+}
+
+// NewServer - Create and start a storage provider emulator.
+//
+// - option: The optional configurations for the Server.
+//
+// - ret1: The started Server, it should be closed by Close.
+//
+// - ret2: Return error if failed to create the operator account or the storage directory.
+func NewServer(option Option) (*Server, error) {
+	s := &Server{
+		operator:    option.Operator,
+		dir:         option.Dir,
+		segmentSize: option.SegmentSize,
+		familyID:    option.FamilyID,
+		expired:     option.ApprovalExpiredHeight,
+		now:         option.Now,
+		buckets:     make(map[string]*bucket),
+		objectsByID: make(map[uint64]*object),
+		groups:      make(map[uint64]*group),
+		sequences:   make(map[string]uint64),
+		auth:        make(map[string]*authState),
+	}
+	if s.operator == nil {
+		operator, _, err := types.NewAccount("sptest-operator")
+		if err != nil {
+			return nil, err
+		}
+		s.operator = operator
+	}
+	if s.dir == "" {
+		dir, err := os.MkdirTemp("", "sptest")
+		if err != nil {
+			return nil, err
+		}
+		s.dir, s.removeDir = dir, true
+	}
+	if s.segmentSize <= 0 {
+		s.segmentSize = defaultSegmentSize
+	}
+	if s.expired == 0 {
+		s.expired = math.MaxUint64
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	s.srv = httptest.NewServer(s)
+	return s, nil
+}
+
+// URL - Get the endpoint of the Server, e.g. "http://127.0.0.1:40123", which can be used as the SP endpoint of the
+// Client.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Host - Get the host of the Server, e.g. "127.0.0.1:40123".
+func (s *Server) Host() string {
+	return s.srv.Listener.Addr().String()
+}
+
+// OperatorAddress - Get the address of the operator account which signs the approvals.
+func (s *Server) OperatorAddress() sdk.AccAddress {
+	return s.operator.GetAddress()
+}
+
+// Close - Stop the Server, and remove the storage directory if it is a temporary one.
+func (s *Server) Close() error {
+	s.srv.Close()
+	if s.removeDir {
+		return os.RemoveAll(s.dir)
+	}
+	return nil
+}
+
+// AddBucket - Add a bucket as if it has been created on chain. The ID is assigned if it is not set.
+//
+// - info: The bucket info, the owner and the bucket name should be set.
+//
+// - ret: The bucket info added.
+func (s *Server) AddBucket(info storageTypes.BucketInfo) *storageTypes.BucketInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if info.Id.IsNil() || info.Id.IsZero() {
+		info.Id = s.nextID("bucket")
+	}
+	if info.Visibility == storageTypes.VISIBILITY_TYPE_UNSPECIFIED {
+		info.Visibility = storageTypes.VISIBILITY_TYPE_PRIVATE
+	}
+	s.buckets[info.BucketName] = &bucket{info: &info, objects: make(map[string]*object)}
+	return &info
+}
+
+// AddObject - Add an object as if it has been created on chain, the bucket of the object should have been added.
+// The ID is assigned if it is not set.
+//
+// The objects in the created status wait for their payloads, they are sealed once their payloads are uploaded. The
+// payloads are verified against the first checksum of the object if it is set, the checksum is the integrity hash of
+// the segments as the primary SP computes. The objects in the sealed status are served with the zero-filled payloads
+// unless their payloads are written by WriteObject.
+//
+// - info: The object info, the bucket name, the object name and the payload size should be set.
+//
+// - ret1: The object info added.
+//
+// - ret2: Return error if the bucket does not exist.
+func (s *Server) AddObject(info storageTypes.ObjectInfo) (*storageTypes.ObjectInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.buckets[info.BucketName]
+	if b == nil {
+		return nil, errNoSuchBucket
+	}
+	if info.Id.IsNil() || info.Id.IsZero() {
+		info.Id = s.nextID("object")
+	}
+	if info.Owner == "" {
+		info.Owner = b.info.Owner
+	}
+	if info.Creator == "" {
+		info.Creator = info.Owner
+	}
+	if info.Visibility == storageTypes.VISIBILITY_TYPE_UNSPECIFIED {
+		info.Visibility = storageTypes.VISIBILITY_TYPE_INHERIT
+	}
+	if info.ContentType == "" {
+		info.ContentType = types.ContentDefault
+	}
+	o := &object{info: &info}
+	if info.ObjectStatus == storageTypes.OBJECT_STATUS_SEALED {
+		o.uploaded = int64(info.PayloadSize)
+		if err := os.WriteFile(s.payloadPath(o), nil, types.FilePermMode); err != nil {
+			return nil, err
+		}
+		if err := os.Truncate(s.payloadPath(o), o.uploaded); err != nil {
+			return nil, err
+		}
+	}
+	b.objects[info.ObjectName] = o
+	s.objectsByID[info.Id.Uint64()] = o
+	return &info, nil
+}
+
+// WriteObject - Write the payload of an object as if it has been uploaded and sealed.
+//
+// - bucketName: The name of the bucket.
+//
+// - objectName: The name of the object.
+//
+// - payload: The payload of the object, the payload size of the object is updated to its length.
+//
+// - ret: Return error if the object does not exist or failed to write the payload.
+func (s *Server) WriteObject(bucketName, objectName string, payload []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.getObject(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(s.payloadPath(o), payload, types.FilePermMode); err != nil {
+		return err
+	}
+	o.info.PayloadSize = uint64(len(payload))
+	o.info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	o.uploaded, o.uploading = int64(len(payload)), false
+	return nil
+}
+
+// HeadObject - Get the object info kept by the Server, e.g. to check whether the object has been sealed.
+//
+// - bucketName: The name of the bucket.
+//
+// - objectName: The name of the object.
+//
+// - ret1: The object info.
+//
+// - ret2: Return error if the object does not exist.
+func (s *Server) HeadObject(bucketName, objectName string) (*storageTypes.ObjectInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.getObject(bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	info := *o.info
+	return &info, nil
+}
+
+// AddGroup - Add a group as if it has been created on chain. The ID is assigned if it is not set.
+//
+// - info: The group info, the owner and the group name should be set.
+//
+// - members: The HEX-encoded addresses of the members, they never expire.
+//
+// - ret1: The group info added.
+//
+// - ret2: Return error if the address of a member is invalid.
+func (s *Server) AddGroup(info storageTypes.GroupInfo, members ...string) (*storageTypes.GroupInfo, error) {
+	g := &group{members: make(map[string]time.Time, len(members))}
+	for _, member := range members {
+		addr, err := sdk.AccAddressFromHexUnsafe(member)
+		if err != nil {
+			return nil, err
+		}
+		g.members[addr.String()] = storageTypes.MaxTimeStamp
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if info.Id.IsNil() || info.Id.IsZero() {
+		info.Id = s.nextID("group")
+	}
+	g.info = &info
+	s.groups[info.Id.Uint64()] = g
+	return &info, nil
+}
+
+// nextID returns the next ID of the kind of the resources. s.mu should be held.
+func (s *Server) nextID(kind string) sdkmath.Uint {
+	s.sequences[kind]++
+	return sdkmath.NewUint(s.sequences[kind])
+}
+
+// getObject returns the object. s.mu should be held.
+func (s *Server) getObject(bucketName, objectName string) (*object, error) {
+	b := s.buckets[bucketName]
+	if b == nil {
+		return nil, errNoSuchBucket
+	}
+	o := b.objects[objectName]
+	if o == nil {
+		return nil, errNoSuchObject
+	}
+	return o, nil
+}
+
+// payloadPath returns the path of the file the payload of the object is stored in.
+func (s *Server) payloadPath(o *object) string {
+	return filepath.Join(s.dir, o.info.Id.String())
+}
+
+// ServeHTTP routes the requests to the handlers by the path and the query parameters, as the SP does.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	query := r.URL.Query()
+
+	switch {
+	case strings.HasPrefix(path, "/auth/"):
+		s.serveAuth(w, r)
+		return
+	case strings.HasPrefix(path, types.AdminURLPrefix+"/"):
+		s.serveAdmin(w, r)
+		return
+	}
+
+	bucketName, objectName := s.virtualHostBucket(r.Host), ""
+	if bucketName == "" {
+		bucketName, objectName, _ = strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	} else {
+		objectName = strings.TrimPrefix(path, "/")
+	}
+
+	signer, err := s.authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch {
+	case bucketName == "" && r.Method == http.MethodGet:
+		switch {
+		case query.Has("group-query"):
+			s.listGroups(w, r)
+		case query.Has("owned-groups"):
+			s.listOwnedGroups(w, r)
+		case query.Has("user-groups"):
+			s.listUserGroups(w, r)
+		case query.Has("group-members"):
+			s.listGroupMembers(w, r)
+		default:
+			s.listBuckets(w, r)
+		}
+	case objectName == "" && r.Method == http.MethodGet:
+		s.listObjects(w, r, bucketName)
+	case objectName == "":
+		writeError(w, errNotImplemented)
+	case r.Method == http.MethodPut:
+		s.putObject(w, r, signer, bucketName, objectName)
+	case r.Method == http.MethodPost && query.Has("offset"):
+		s.putObjectPart(w, r, signer, bucketName, objectName)
+	case r.Method == http.MethodGet && query.Has("upload-progress"):
+		s.getUploadProgress(w, bucketName, objectName)
+	case r.Method == http.MethodGet && query.Has("upload-context"):
+		s.getUploadOffset(w, bucketName, objectName)
+	case r.Method == http.MethodGet:
+		s.getObjectPayload(w, r, signer, bucketName, objectName)
+	default:
+		writeError(w, errNotImplemented)
+	}
+}
+
+// virtualHostBucket returns the bucket name of a virtual-hosted-style request, e.g. "bucket.localhost:40123", it is
+// empty for a path-style request.
+func (s *Server) virtualHostBucket(host string) string {
+	_, port, err := net.SplitHostPort(s.Host())
+	if err != nil {
+		return ""
+	}
+	for _, suffix := range []string{"." + s.Host(), ".localhost:" + port} {
+		if bucketName, ok := strings.CutSuffix(host, suffix); ok {
+			return bucketName
+		}
+	}
+	return ""
+}
+
+// writeXML writes the response body in XML.
+func writeXML(w http.ResponseWriter, v interface{}) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set(types.HTTPHeaderContentType, types.ContentTypeXML)
+	w.Header().Set(types.HTTPHeaderContentLength, strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,285 @@
+package sptest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+
+	spTypes "github.com/evmos/evmos/v12/x/sp/types"
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+	virtualGroupTypes "github.com/evmos/evmos/v12/x/virtualgroup/types"
+	"github.com/zkMeLabs/mechain-go-sdk/client"
+	"github.com/zkMeLabs/mechain-go-sdk/pkg/sptest"
+	"github.com/zkMeLabs/mechain-go-sdk/types"
+)
+
+const (
+	testChainID = "mechain_5151-1"
+	// testSegmentSize is small so that the resumable upload splits a small payload into several parts.
+	testSegmentSize = 1024
+	testSpID        = 1
+	testFamilyID    = 1
+)
+
+// queryPath returns the ABCI query path of the request of a gRPC query service, e.g.
+// "/mechain.storage.Query/HeadBucket" for QueryHeadBucketRequest.
+func queryPath(req proto.Message) string {
+	name := proto.MessageName(req)
+	index := strings.LastIndex(name, ".")
+	method := strings.TrimSuffix(strings.TrimPrefix(name[index+1:], "Query"), "Request")
+	return "/" + name[:index] + ".Query/" + method
+}
+
+// chainStub serves the ABCI queries the Client makes to find the SP of a bucket and the status of an object, as the
+// RPC endpoint of the chain does. The Server is the primary SP of the bucket, the objects are those of the Server.
+type chainStub struct {
+	srv    *httptest.Server
+	sp     *sptest.Server
+	bucket *storageTypes.BucketInfo
+}
+
+func newChainStub(t *testing.T, sp *sptest.Server, bucket *storageTypes.BucketInfo) *chainStub {
+	c := &chainStub{sp: sp, bucket: bucket}
+	c.srv = httptest.NewServer(c)
+	t.Cleanup(c.srv.Close)
+	return c
+}
+
+// ServeHTTP answers the JSON-RPC abci_query requests.
+func (c *chainStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params struct {
+			Path string `json:"path"`
+			Data string `json:"data"`
+		} `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if req.Method != "abci_query" {
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "Method not found", "data": req.Method}
+	} else {
+		response := map[string]interface{}{"code": 0, "height": "1"}
+		value, err := c.query(req.Params.Path, req.Params.Data)
+		if err != nil {
+			response["code"], response["codespace"], response["log"] = 1, "sptest", err.Error()
+		} else {
+			response["value"] = value
+		}
+		resp["result"] = map[string]interface{}{"response": response}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// query returns the encoded response of the query.
+func (c *chainStub) query(path, data string) ([]byte, error) {
+	reqBytes, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	switch path {
+	case queryPath(&storageTypes.QueryParamsRequest{}):
+		return proto.Marshal(&storageTypes.QueryParamsResponse{Params: storageTypes.Params{
+			VersionedParams: storageTypes.VersionedParams{MaxSegmentSize: testSegmentSize},
+		}})
+	case queryPath(&storageTypes.QueryHeadBucketRequest{}):
+		req := &storageTypes.QueryHeadBucketRequest{}
+		if err = proto.Unmarshal(reqBytes, req); err != nil {
+			return nil, err
+		}
+		if req.BucketName != c.bucket.BucketName {
+			return nil, errors.New("no such bucket")
+		}
+		return proto.Marshal(&storageTypes.QueryHeadBucketResponse{BucketInfo: c.bucket})
+	case queryPath(&storageTypes.QueryHeadObjectRequest{}):
+		req := &storageTypes.QueryHeadObjectRequest{}
+		if err = proto.Unmarshal(reqBytes, req); err != nil {
+			return nil, err
+		}
+		info, err := c.sp.HeadObject(req.BucketName, req.ObjectName)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(&storageTypes.QueryHeadObjectResponse{ObjectInfo: info})
+	case queryPath(&virtualGroupTypes.QueryGlobalVirtualGroupFamilyRequest{}):
+		return proto.Marshal(&virtualGroupTypes.QueryGlobalVirtualGroupFamilyResponse{
+			GlobalVirtualGroupFamily: &virtualGroupTypes.GlobalVirtualGroupFamily{Id: testFamilyID, PrimarySpId: testSpID},
+		})
+	case queryPath(&spTypes.QueryStorageProvidersRequest{}):
+		operator := c.sp.OperatorAddress().String()
+		return proto.Marshal(&spTypes.QueryStorageProvidersResponse{Sps: []*spTypes.StorageProvider{{
+			Id:              testSpID,
+			OperatorAddress: operator,
+			FundingAddress:  operator,
+			SealAddress:     operator,
+			ApprovalAddress: operator,
+			GcAddress:       operator,
+			Endpoint:        c.sp.URL(),
+			Status:          spTypes.STATUS_IN_SERVICE,
+		}}})
+	}
+	return nil, fmt.Errorf("unknown query path %s", path)
+}
+
+// newTestClient returns a Server with a bucket owned by a new account, and a Client of the account which routes the
+// requests of the bucket to the Server.
+func newTestClient(t *testing.T, option client.Option) (*sptest.Server, client.IClient, *types.Account) {
+	srv, err := sptest.NewServer(sptest.Option{SegmentSize: testSegmentSize, FamilyID: testFamilyID})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, srv.Close()) })
+
+	owner, _, err := types.NewAccount("owner")
+	require.NoError(t, err)
+	bucket := srv.AddBucket(storageTypes.BucketInfo{
+		Owner:                      owner.GetAddress().String(),
+		BucketName:                 "bucket",
+		GlobalVirtualGroupFamilyId: testFamilyID,
+		BucketStatus:               storageTypes.BUCKET_STATUS_CREATED,
+	})
+	chain := newChainStub(t, srv, bucket)
+
+	option.DefaultAccount = owner
+	cli, err := client.New(testChainID, chain.srv.URL, option)
+	require.NoError(t, err)
+	return srv, cli, owner
+}
+
+// addCreatedObject adds an object waiting for the payload.
+func addCreatedObject(t *testing.T, srv *sptest.Server, objectName string, size int) {
+	_, err := srv.AddObject(storageTypes.ObjectInfo{
+		BucketName:   "bucket",
+		ObjectName:   objectName,
+		PayloadSize:  uint64(size),
+		ObjectStatus: storageTypes.OBJECT_STATUS_CREATED,
+	})
+	require.NoError(t, err)
+}
+
+// newPayload returns a payload of the size whose bytes differ by their offsets.
+func newPayload(size int) []byte {
+	payload := make([]byte, size)
+	for i := range payload {
+		payload[i] = byte(i % 251)
+	}
+	return payload
+}
+
+// getObject downloads the object, or the range of it if start and end are not negative.
+func getObject(t *testing.T, cli client.IClient, objectName string, start, end int64) []byte {
+	var opts types.GetObjectOptions
+	if start >= 0 {
+		require.NoError(t, opts.SetRange(start, end))
+	}
+	body, _, err := cli.GetObject(context.Background(), "bucket", objectName, opts)
+	require.NoError(t, err)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	return data
+}
+
+func TestPutAndGetObject(t *testing.T) {
+	srv, cli, _ := newTestClient(t, client.Option{})
+	ctx := context.Background()
+	payload := newPayload(testSegmentSize / 2)
+	addCreatedObject(t, srv, "object", len(payload))
+
+	// the requests are signed by GNFD1-ECDSA
+	require.NoError(t, cli.PutObject(ctx, "bucket", "object", int64(len(payload)), bytes.NewReader(payload), types.PutObjectOptions{PartSize: testSegmentSize}))
+	info, err := srv.HeadObject("bucket", "object")
+	require.NoError(t, err)
+	require.Equal(t, storageTypes.OBJECT_STATUS_SEALED, info.ObjectStatus)
+
+	require.Equal(t, payload, getObject(t, cli, "object", -1, -1))
+	require.Equal(t, payload[10:20], getObject(t, cli, "object", 10, 19))
+
+	// the private object can not be read by another account
+	other, _, err := types.NewAccount("other")
+	require.NoError(t, err)
+	_, _, err = cli.GetObject(client.WithAccount(ctx, other), "bucket", "object", types.GetObjectOptions{})
+	require.ErrorIs(t, err, types.ErrAccessDenied)
+}
+
+func TestPutObjectResumable(t *testing.T) {
+	srv, cli, _ := newTestClient(t, client.Option{})
+	ctx := context.Background()
+	payload := newPayload(3*testSegmentSize + 100)
+	addCreatedObject(t, srv, "object", len(payload))
+	opts := types.PutObjectOptions{PartSize: testSegmentSize}
+
+	// the upload is interrupted before the second part
+	interrupted := errors.New("interrupted")
+	var parts []int
+	client.UploadSegmentHooker = func(id int) error {
+		parts = append(parts, id)
+		if id == 2 && len(parts) == 2 {
+			return interrupted
+		}
+		return nil
+	}
+	t.Cleanup(func() { client.UploadSegmentHooker = client.DefaultUploadSegment })
+
+	err := cli.PutObject(ctx, "bucket", "object", int64(len(payload)), bytes.NewReader(payload), opts)
+	require.ErrorIs(t, err, interrupted)
+	progress, err := cli.GetObjectUploadProgress(ctx, "bucket", "object")
+	require.NoError(t, err)
+	require.Contains(t, progress, fmt.Sprintf("%d of %d bytes", testSegmentSize, len(payload)))
+
+	// the upload resumes from the second part
+	parts = nil
+	require.NoError(t, cli.PutObject(ctx, "bucket", "object", int64(len(payload)), bytes.NewReader(payload), opts))
+	require.Equal(t, []int{2, 3, 4}, parts)
+	require.Equal(t, payload, getObject(t, cli, "object", -1, -1))
+	require.Equal(t, payload[testSegmentSize-5:testSegmentSize+5], getObject(t, cli, "object", testSegmentSize-5, testSegmentSize+4))
+}
+
+func TestGetObjectOffChainAuthV2(t *testing.T) {
+	// the GNFD2-EDDSA key is registered to the SP when the Client is created
+	srv, cli, _ := newTestClient(t, client.Option{OffChainAuthOptionV2: &client.OffChainAuthOptionV2{
+		Seed:                 "sptest-seed",
+		Domain:               "https://sptest.example",
+		ShouldRegisterPubKey: true,
+	}})
+	ctx := context.Background()
+	payload := newPayload(100)
+	addCreatedObject(t, srv, "object", len(payload))
+	require.NoError(t, srv.WriteObject("bucket", "object", payload))
+
+	body, stat, err := cli.GetObject(ctx, "bucket", "object", types.GetObjectOptions{Range: "bytes=50-"})
+	require.NoError(t, err)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, payload[50:], data)
+	require.Equal(t, "object", stat.ObjectName)
+}
+
+func TestGetObjectUnregisteredKey(t *testing.T) {
+	srv, cli, _ := newTestClient(t, client.Option{OffChainAuthOptionV2: &client.OffChainAuthOptionV2{
+		Seed:   "sptest-seed",
+		Domain: "https://sptest.example",
+	}})
+	addCreatedObject(t, srv, "object", 1)
+	require.NoError(t, srv.WriteObject("bucket", "object", []byte{1}))
+
+	_, _, err := cli.GetObject(context.Background(), "bucket", "object", types.GetObjectOptions{})
+	var resp types.ErrResponse
+	require.ErrorAs(t, err, &resp)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -16,8 +16,6 @@ import (
 	"strconv"
 	"strings"
 	"unicode/utf8"
-
-	"github.com/rs/zerolog/log"
 )
 
 var EmptyURL = url.URL{}
@@ -138,10 +136,8 @@ func AddQueryValues(s string, qs url.Values) (string, error) {
 // CloseResponse closes the response body
 func CloseResponse(resp *http.Response) {
 	if resp != nil && resp.Body != nil {
-		_, err := io.Copy(io.Discard, resp.Body)
-		if err != nil {
-			log.Info().Msg("close resp copy error" + err.Error())
-		}
+		// drain the body so that the connection can be reused, the drain error is irrelevant as the body is closed anyway
+		_, _ = io.Copy(io.Discard, resp.Body)
 		resp.Body.Close()
 	}
 }
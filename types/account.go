@@ -2,16 +2,32 @@ package types
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/bls"
 
 	"cosmossdk.io/math"
 
 	"github.com/cometbft/cometbft/crypto/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+	"github.com/evmos/evmos/v12/crypto/hd"
 	"github.com/evmos/evmos/v12/sdk/keys"
 )
 
+const (
+	// DefaultHDPath is the BIP-44 path of the key derived by NewAccountFromMnemonic.
+	DefaultHDPath = "m/44'/60'/0'/0/0"
+
+	// hdHardenedIndex is the first hardened index of BIP-32, the address indexes must be below it.
+	hdHardenedIndex = 1 << 31
+
+	// mnemonicEntropySize is the entropy bits of the generated mnemonic, which has 24 words.
+	mnemonicEntropySize = 256
+)
+
 // Account indicates the user's identity information used for interaction with Mechain.
 type Account struct {
 	name string
@@ -64,6 +80,123 @@ func NewAccountFromMnemonic(name, mnemonic string) (*Account, error) {
 	}, nil
 }
 
+// HDOptions indicates the options of deriving accounts from a mnemonic.
+type HDOptions struct {
+	// Passphrase is the optional BIP-39 passphrase, which is also known as the 25th word.
+	Passphrase string
+	// Account is the account level of the BIP-44 path, the derived path is m/44'/60'/{Account}'/0/{index}.
+	Account uint32
+}
+
+// NewMnemonic - Generate a random 24 words BIP-39 mnemonic.
+//
+// -ret1: The mnemonic string.
+//
+// -ret2: Error message.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropySize)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// HDPath - Get the BIP-44 path of the account and address index, e.g. m/44'/60'/0'/0/1.
+func HDPath(account, index uint32) string {
+	return fmt.Sprintf("m/44'/60'/%d'/0/%d", account, index)
+}
+
+// NewAccountFromMnemonicWithPath - Create account instance according to mnemonic, BIP-39 passphrase and BIP-32 path.
+//
+// -name: Account name.
+//
+// -mnemonic: The mnemonic string.
+//
+// -passphrase: The BIP-39 passphrase, it can be empty.
+//
+// -hdPath: The BIP-32 derivation path, e.g. DefaultHDPath.
+//
+// -ret1: The pointer of the created account instance.
+//
+// -ret2: Error message if the mnemonic or the path is not correct, otherwise returns nil.
+func NewAccountFromMnemonicWithPath(name, mnemonic, passphrase, hdPath string) (*Account, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	derivedPriv, err := hd.EthSecp256k1.Derive()(mnemonic, passphrase, hdPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the key of path %s: %w", hdPath, err)
+	}
+	km, err := keys.NewPrivateKeyManager(hex.EncodeToString(derivedPriv))
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		name: name,
+		km:   km,
+	}, nil
+}
+
+// NewAccountsFromMnemonic - Derive the accounts of consecutive address indexes from mnemonic, so that each worker can
+// use its own account derived from the same seed.
+//
+// -mnemonic: The mnemonic string.
+//
+// -from: The first address index to derive.
+//
+// -count: The number of the accounts to derive.
+//
+// -opts: The optional BIP-39 passphrase and BIP-44 account level, at most one HDOptions is accepted.
+//
+// -ret1: The derived accounts, the account of address index i is named by its path HDPath(opts.Account, i).
+//
+// -ret2: Error message if the mnemonic is not correct, otherwise returns nil.
+func NewAccountsFromMnemonic(mnemonic string, from, count uint32, opts ...HDOptions) ([]*Account, error) {
+	var opt HDOptions
+	switch len(opts) {
+	case 0:
+	case 1:
+		opt = opts[0]
+	default:
+		return nil, errors.New("too many hd options")
+	}
+	if uint64(from)+uint64(count) > uint64(hdHardenedIndex) {
+		return nil, fmt.Errorf("the address indexes [%d, %d) exceed the non-hardened range", from, uint64(from)+uint64(count))
+	}
+
+	accounts := make([]*Account, 0, count)
+	for i := from; i < from+count; i++ {
+		path := HDPath(opt.Account, i)
+		account, err := NewAccountFromMnemonicWithPath(path, mnemonic, opt.Passphrase, path)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// NewAccountWithMnemonic - Create a random new account with a generated mnemonic, the key is derived by DefaultHDPath.
+//
+// -name: The account name.
+//
+// -ret1: The pointer of the created account instance.
+//
+// -ret2: The mnemonic of the created account.
+//
+// -ret3: Error message.
+func NewAccountWithMnemonic(name string) (*Account, string, error) {
+	mnemonic, err := NewMnemonic()
+	if err != nil {
+		return nil, "", err
+	}
+	account, err := NewAccountFromMnemonicWithPath(name, mnemonic, "", DefaultHDPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return account, mnemonic, nil
+}
+
 // NewAccount - Create a random new account.
 //
 // -name: The account name.
@@ -121,3 +254,20 @@ func (a *Account) GetAddress() sdk.AccAddress {
 func (a *Account) Sign(unsignBytes []byte) ([]byte, error) {
 	return a.km.Sign(unsignBytes)
 }
+
+// GetPubKey - Get the public key of the account.
+func (a *Account) GetPubKey() cryptotypes.PubKey {
+	return a.km.GetPrivKey().PubKey()
+}
+
+// SignTx - Use the account's private key to sign the sign bytes of a transaction.
+func (a *Account) SignTx(signBytes []byte) ([]byte, error) {
+	return a.km.GetPrivKey().Sign(signBytes)
+}
+
+// SignRequest - Use the account's private key to sign the message of an SP request.
+func (a *Account) SignRequest(msg []byte) ([]byte, error) {
+	return a.km.Sign(msg)
+}
+
+var _ Signer = (*Account)(nil)
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// testMnemonic is the well-known development mnemonic, its addresses are listed by the Ethereum dev tools.
+const testMnemonic = "test test test test test test test test test test test junk"
+
+// testAddresses are the addresses of testMnemonic at m/44'/60'/0'/0/{index}.
+var testAddresses = []string{
+	"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+	"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+	"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+	"0x90F79bf6EB2c4f870365E785982E1f101E93b906",
+}
+
+func requireAddress(t *testing.T, expected string, account *Account) {
+	require.Equal(t, common.HexToAddress(expected).Bytes(), account.GetAddress().Bytes())
+}
+
+func TestNewMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic()
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(mnemonic), 24)
+	require.True(t, bip39.IsMnemonicValid(mnemonic))
+
+	another, err := NewMnemonic()
+	require.NoError(t, err)
+	require.NotEqual(t, mnemonic, another)
+
+	account, mnemonic, err := NewAccountWithMnemonic("account")
+	require.NoError(t, err)
+	derived, err := NewAccountFromMnemonicWithPath("derived", mnemonic, "", DefaultHDPath)
+	require.NoError(t, err)
+	require.Equal(t, account.GetAddress(), derived.GetAddress())
+}
+
+func TestNewAccountFromMnemonicWithPath(t *testing.T) {
+	for index, address := range testAddresses {
+		account, err := NewAccountFromMnemonicWithPath("account", testMnemonic, "", HDPath(0, uint32(index)))
+		require.NoError(t, err)
+		requireAddress(t, address, account)
+	}
+
+	account, err := NewAccountFromMnemonic("account", testMnemonic)
+	require.NoError(t, err)
+	requireAddress(t, testAddresses[0], account)
+
+	// the passphrase derives another seed
+	account, err = NewAccountFromMnemonicWithPath("account", testMnemonic, "passphrase", DefaultHDPath)
+	require.NoError(t, err)
+	require.NotEqual(t, common.HexToAddress(testAddresses[0]).Bytes(), account.GetAddress().Bytes())
+	again, err := NewAccountFromMnemonicWithPath("account", testMnemonic, "passphrase", DefaultHDPath)
+	require.NoError(t, err)
+	require.Equal(t, account.GetAddress(), again.GetAddress())
+
+	_, err = NewAccountFromMnemonicWithPath("account", "test test test", "", DefaultHDPath)
+	require.ErrorContains(t, err, "invalid mnemonic")
+	_, err = NewAccountFromMnemonicWithPath("account", testMnemonic, "", "m/44'/60'/0'/0/x")
+	require.Error(t, err)
+}
+
+func TestNewAccountsFromMnemonic(t *testing.T) {
+	accounts, err := NewAccountsFromMnemonic(testMnemonic, 1, 3)
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	for i, account := range accounts {
+		requireAddress(t, testAddresses[i+1], account)
+		require.Equal(t, HDPath(0, uint32(i+1)), account.name)
+	}
+
+	// the account level and the passphrase derive other accounts
+	withAccount, err := NewAccountsFromMnemonic(testMnemonic, 1, 1, HDOptions{Account: 1})
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/60'/1'/0/1", withAccount[0].name)
+	require.NotEqual(t, accounts[0].GetAddress(), withAccount[0].GetAddress())
+	withPassphrase, err := NewAccountsFromMnemonic(testMnemonic, 1, 1, HDOptions{Passphrase: "passphrase"})
+	require.NoError(t, err)
+	require.NotEqual(t, accounts[0].GetAddress(), withPassphrase[0].GetAddress())
+
+	accounts, err = NewAccountsFromMnemonic(testMnemonic, hdHardenedIndex-1, 1)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	_, err = NewAccountsFromMnemonic(testMnemonic, hdHardenedIndex-1, 2)
+	require.ErrorContains(t, err, "non-hardened range")
+	_, err = NewAccountsFromMnemonic(testMnemonic, hdHardenedIndex, 0)
+	require.NoError(t, err, "no account is derived")
+
+	_, err = NewAccountsFromMnemonic(testMnemonic, 0, 1, HDOptions{}, HDOptions{})
+	require.ErrorContains(t, err, "too many hd options")
+	_, err = NewAccountsFromMnemonic("test test test", 0, 1)
+	require.ErrorContains(t, err, "invalid mnemonic")
+}
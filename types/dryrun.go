@@ -0,0 +1,26 @@
+package types
+
+import (
+	"errors"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ErrDryRun is matched by the errors returned in the dry-run mode instead of sending a request with side effects to SP,
+// or waiting for a transaction which is not broadcast.
+var ErrDryRun = errors.New("dry run")
+
+// DryRunResult is the result of a transaction simulated by DryRunTx or DryRunRawTx, the transaction is not broadcast.
+type DryRunResult struct {
+	// Msgs is the messages of the transaction.
+	Msgs []sdk.Msg
+	// GasUsed is the gas used in the simulation.
+	GasUsed uint64
+	// GasLimit is the estimated gas limit of the transaction.
+	GasLimit uint64
+	// Fee is the estimated fee of the transaction, it is empty if the gas price is unknown.
+	Fee sdk.Coins
+	// Events is the events emitted in the simulation.
+	Events []abci.Event
+}
@@ -7,6 +7,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
 )
 
 const unknownErr = "unknown error"
@@ -16,6 +23,187 @@ var (
 	ErrorProposalIDNotFound     = errors.New("Proposal ID not found ")
 )
 
+// The kinds of the errors returned by the Client, use errors.Is to check whether an error is of the kind.
+var (
+	ErrNoSuchBucket     = errors.New("no such bucket")
+	ErrNoSuchObject     = errors.New("no such object")
+	ErrNoSuchPolicy     = errors.New("no such policy")
+	ErrAccessDenied     = errors.New("access denied")
+	ErrQuotaExceeded    = errors.New("quota exceeded")
+	ErrObjectNotSealed  = errors.New("object not sealed")
+	ErrTxFailed         = errors.New("transaction failed")
+	ErrSPUnavailable    = errors.New("storage provider unavailable")
+	ErrSimulationFailed = errors.New("transaction simulation failed")
+	ErrRejected         = errors.New("request rejected")
+	ErrUnverified       = errors.New("query result not verified")
+)
+
+// kindError attaches an error kind to an error without changing its message.
+type kindError struct {
+	err  error
+	kind error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.err, e.kind}
+}
+
+// WrapError attaches the error kind(one of the Err* errors) to err, so that errors.Is(err, kind) reports true.
+// The message of err is kept unchanged, nil is returned if err is nil.
+func WrapError(err, kind error) error {
+	if err == nil || kind == nil || errors.Is(err, kind) {
+		return err
+	}
+	return &kindError{err: err, kind: kind}
+}
+
+// WrapChainError attaches the error kind to the error returned by the Mechain blockchain according to the codespace
+// and the code of the registered error it carries. err is returned as it is if its kind is unknown.
+func WrapChainError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return WrapError(err, messageErrorKind(err.Error()))
+}
+
+// chainErrorKinds are the error kinds of the registered errors of the Mechain blockchain.
+var chainErrorKinds = []struct {
+	err  *errorsmod.Error
+	kind error
+}{
+	{storageTypes.ErrNoSuchBucket, ErrNoSuchBucket},
+	{storageTypes.ErrNoSuchObject, ErrNoSuchObject},
+	{storageTypes.ErrNoSuchPolicy, ErrNoSuchPolicy},
+	{storageTypes.ErrAccessDenied, ErrAccessDenied},
+	{storageTypes.ErrObjectNotSealed, ErrObjectNotSealed},
+}
+
+// chainErrorKind returns the error kind of the registered error of the codespace and the code, nil if it is unknown.
+func chainErrorKind(codespace string, code uint32) error {
+	for _, k := range chainErrorKinds {
+		if k.err.Codespace() == codespace && k.err.ABCICode() == code {
+			return k.kind
+		}
+	}
+	return nil
+}
+
+// chainCodeRegexp matches the codespace and the code of the registered error in the message of a gRPC status, e.g.
+// "codespace storage code 1100: No such bucket".
+var chainCodeRegexp = regexp.MustCompile(`codespace (\w+) code (\d+)`)
+
+// messageErrorKind returns the error kind of the registered error whose codespace and code are in the message, nil
+// if there is none or it is unknown.
+func messageErrorKind(msg string) error {
+	m := chainCodeRegexp.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	code, err := strconv.ParseUint(m[2], 10, 32)
+	if err != nil {
+		return nil
+	}
+	return chainErrorKind(m[1], uint32(code))
+}
+
+// descErrorKind returns the error kind of the registered error whose description is wrapped in the message, e.g.
+// "failed to execute message; message index: 0: bucket name: b: No such bucket", nil if it is unknown. It is used
+// for the messages which lose the codespace and the code, i.e. the failures of the simulation.
+func descErrorKind(msg string) error {
+	for _, k := range chainErrorKinds {
+		desc := ": " + k.err.Error()
+		if strings.HasSuffix(msg, desc) || strings.Contains(msg, desc+" With gas wanted") {
+			return k.kind
+		}
+	}
+	return nil
+}
+
+// TxFailedError is returned when a transaction has been rejected by the Mechain blockchain with a non-zero code.
+// It matches ErrTxFailed, and the error kind of the registered error of its codespace and code.
+type TxFailedError struct {
+	// Op is the operation which sent the transaction, e.g. "createObject txn".
+	Op        string
+	TxHash    string
+	Code      uint32
+	Codespace string
+	Log       string
+}
+
+// Error returns the error msg
+func (e *TxFailedError) Error() string {
+	return fmt.Sprintf("the %s has failed with response code: %d, codespace:%s", e.Op, e.Code, e.Codespace)
+}
+
+// Is reports whether the error matches the target error kind.
+func (e *TxFailedError) Is(target error) bool {
+	if target == ErrTxFailed {
+		return true
+	}
+	kind := chainErrorKind(e.Codespace, e.Code)
+	return kind != nil && target == kind
+}
+
+// SimulationError is returned when the simulation of a transaction reverts, the transaction is not broadcast.
+// It matches ErrSimulationFailed, and the error kind of the registered error in its reason.
+type SimulationError struct {
+	// Reason is the message of the chain explaining why the transaction reverts.
+	Reason string
+	Err    error
+}
+
+// Error returns the error msg
+func (e *SimulationError) Error() string {
+	return "the simulation of the transaction has failed: " + e.Reason
+}
+
+// Unwrap returns the error returned by the simulation.
+func (e *SimulationError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error matches the target error kind.
+func (e *SimulationError) Is(target error) bool {
+	if target == ErrSimulationFailed {
+		return true
+	}
+	kind := messageErrorKind(e.Reason)
+	if kind == nil {
+		kind = descErrorKind(e.Reason)
+	}
+	return kind != nil && target == kind
+}
+
+// RejectedError is returned by the waiters when the request has been rejected instead of being completed, e.g. the
+// seal of an object is rejected by the SP. It matches ErrRejected.
+type RejectedError struct {
+	// Op is the operation which has been rejected, e.g. "seal object".
+	Op string
+	// Reason explains why the operation is considered rejected.
+	Reason string
+	// Err is the error which indicates the rejection if there is one, e.g. the object does not exist.
+	Err error
+}
+
+// Error returns the error msg
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("the %s has been rejected: %s", e.Op, e.Reason)
+}
+
+// Unwrap returns the error which indicates the rejection.
+func (e *RejectedError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error matches the target error kind.
+func (e *RejectedError) Is(target error) bool {
+	return target == ErrRejected
+}
+
 // ErrResponse define the information of the error response
 type ErrResponse struct {
 	XMLName    xml.Name `xml:"Error"`
@@ -30,6 +218,39 @@ func (r ErrResponse) Error() string {
 		r.StatusCode, r.Code, r.Message)
 }
 
+// Is reports whether the error response matches the target error kind.
+func (r ErrResponse) Is(target error) bool {
+	kind := r.kind()
+	return kind != nil && target == kind
+}
+
+// kind returns the error kind of the error response based on its code, message and status code.
+func (r ErrResponse) kind() error {
+	switch r.Code {
+	case "NoSuchBucket":
+		return ErrNoSuchBucket
+	case "NoSuchObject":
+		return ErrNoSuchObject
+	case "AccessDenied":
+		return ErrAccessDenied
+	case "QuotaExceeded":
+		return ErrQuotaExceeded
+	}
+	if strings.Contains(strings.ToLower(r.Message), NoSuchObjectErr) {
+		return ErrNoSuchObject
+	}
+	if kind := messageErrorKind(r.Message); kind != nil {
+		return kind
+	}
+	switch r.StatusCode {
+	case http.StatusForbidden:
+		return ErrAccessDenied
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrSPUnavailable
+	}
+	return nil
+}
+
 // ConstructErrResponse  checks the response is an error response
 func ConstructErrResponse(r *http.Response, bucketName, objectName string) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
@@ -89,7 +310,6 @@ func ConstructErrResponse(r *http.Response, bucketName, objectName string) error
 			if len(errBody) > 0 {
 				msg = string(errBody)
 			}
-			fmt.Println("default error msg :", msg)
 			errResp = ErrResponse{
 				StatusCode: r.StatusCode,
 				Code:       unknownErr,
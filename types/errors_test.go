@@ -0,0 +1,44 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/status"
+
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+)
+
+func TestWrapChainError(t *testing.T) {
+	err := WrapChainError(status.Convert(errorsmod.Wrapf(storageTypes.ErrNoSuchBucket, "bucket name: %s", "b")).Err())
+	require.ErrorIs(t, err, ErrNoSuchBucket)
+
+	// the kind is not guessed from the words of the message
+	err = WrapChainError(status.Convert(errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "access denied, quota exceeded, not sealed")).Err())
+	for _, kind := range []error{ErrAccessDenied, ErrQuotaExceeded, ErrObjectNotSealed} {
+		require.False(t, errors.Is(err, kind), kind)
+	}
+}
+
+func TestTxFailedErrorKind(t *testing.T) {
+	err := &TxFailedError{
+		Code:      storageTypes.ErrObjectNotSealed.ABCICode(),
+		Codespace: storageTypes.ErrObjectNotSealed.Codespace(),
+		Log:       "failed to execute message; message index: 0: object name: o: Object not sealed",
+	}
+	require.ErrorIs(t, err, ErrTxFailed)
+	require.ErrorIs(t, err, ErrObjectNotSealed)
+
+	err = &TxFailedError{Code: sdkerrors.ErrInsufficientFee.ABCICode(), Codespace: sdkerrors.ErrInsufficientFee.Codespace(), Log: "quota"}
+	require.False(t, errors.Is(err, ErrQuotaExceeded))
+}
+
+func TestSimulationErrorKind(t *testing.T) {
+	cause := errorsmod.Wrapf(storageTypes.ErrNoSuchObject, "object name: %s", "o")
+	err := &SimulationError{Reason: "message index: 0: " + cause.Error() + " With gas wanted: '0' and gas used: '1' "}
+	require.ErrorIs(t, err, ErrSimulationFailed)
+	require.ErrorIs(t, err, ErrNoSuchObject)
+}
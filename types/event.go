@@ -0,0 +1,102 @@
+package types
+
+import (
+	"github.com/cosmos/gogoproto/proto"
+
+	storagetypes "github.com/evmos/evmos/v12/x/storage/types"
+)
+
+// The types of the events delivered by Subscribe, they are the names of the typed events emitted by the chain.
+const (
+	EventTypeCreateBucket       = "EventCreateBucket"       // *storagetypes.EventCreateBucket
+	EventTypeCreateObject       = "EventCreateObject"       // *storagetypes.EventCreateObject
+	EventTypeSealObject         = "EventSealObject"         // *storagetypes.EventSealObject
+	EventTypeCreateGroup        = "EventCreateGroup"        // *storagetypes.EventCreateGroup
+	EventTypeDeleteObject       = "EventDeleteObject"       // *storagetypes.EventDeleteObject
+	EventTypeUpdateGroupMember  = "EventUpdateGroupMember"  // *storagetypes.EventUpdateGroupMember
+	EventTypePutPolicy          = "EventPutPolicy"          // *permtypes.EventPutPolicy
+	EventTypeStreamRecordUpdate = "EventStreamRecordUpdate" // *paymenttypes.EventStreamRecordUpdate
+
+	EventTypeRejectSealObject          = "EventRejectSealObject"          // *storagetypes.EventRejectSealObject
+	EventTypeCancelUpdateObjectContent = "EventCancelUpdateObjectContent" // *storagetypes.EventCancelUpdateObjectContent
+	EventTypeCompleteMigrationBucket   = "EventCompleteMigrationBucket"   // *storagetypes.EventCompleteMigrationBucket
+	EventTypeRejectMigrateBucket       = "EventRejectMigrateBucket"       // *storagetypes.EventRejectMigrateBucket
+	EventTypeCancelMigrationBucket     = "EventCancelMigrationBucket"     // *storagetypes.EventCancelMigrationBucket
+)
+
+// Event indicates a typed event emitted by the chain and delivered by Subscribe.
+type Event struct {
+	Height int64  // Height is the height of the block which emits the event.
+	TxHash string // TxHash is the hash of the transaction which emits the event, it is empty for the block events.
+	Type   string // Type is one of the EventType constants.
+	// Data is the decoded event, its type is noted by the EventType constants, e.g. *storagetypes.EventCreateBucket.
+	Data proto.Message
+}
+
+// SubscribeOptions indicates the filters and the configurations of Subscribe. An event is delivered only if it
+// matches all the filters, the events which do not have the field of a filter are dropped by the filter, e.g. the
+// stream record events are dropped if BucketName is set.
+type SubscribeOptions struct {
+	EventTypes   []string // EventTypes defines the types of the events to deliver, all the types are delivered if it is empty.
+	BucketName   string   // BucketName defines the name of the bucket of the events.
+	Owner        string   // Owner defines the HEX-encoded address of the owner of the buckets, objects or groups.
+	ObjectPrefix string   // ObjectPrefix defines the prefix of the names of the objects.
+	// FromHeight defines the height to deliver the events from, the events of the blocks after the latest block are
+	// delivered if it is not set.
+	FromHeight int64
+	BufferSize int // BufferSize defines the capacity of the event channel, 100 is used if it is not set.
+}
+
+// TxResult indicates the typed events emitted by a transaction, it is decoded by DecodeTxResult or
+// DecodeBroadcastTxResponse.
+type TxResult struct {
+	TxHash string
+	Height int64
+	Index  uint32 // Index is the index of the transaction in the block.
+	// Events is the typed events of the transaction in the order they are emitted, the events of all the modules are
+	// included, e.g. *storagetypes.EventCreateBucket and *paymenttypes.EventStreamRecordUpdate.
+	Events []*Event
+}
+
+// FindTxEvent - Find the first event of the type T in the transaction result.
+//
+// -result: The typed events of the transaction.
+//
+// -ret1: The first event of the type T, e.g. *storagetypes.EventCreateBucket.
+//
+// -ret2: Whether the event is found.
+func FindTxEvent[T proto.Message](result *TxResult) (T, bool) {
+	var zero T
+	if result == nil {
+		return zero, false
+	}
+	for _, event := range result.Events {
+		if data, ok := event.Data.(T); ok {
+			return data, true
+		}
+	}
+	return zero, false
+}
+
+// CreateBucketResult indicates the result of creating a bucket.
+type CreateBucketResult struct {
+	TxHash string
+	// Event is the event of creating the bucket, e.g. the bucket ID, the owner and the payment address. It is nil if
+	// the transaction is not waited for in asynchronous mode.
+	Event *storagetypes.EventCreateBucket
+}
+
+// CreateObjectResult indicates the result of creating an object.
+type CreateObjectResult struct {
+	TxHash string
+	// Event is the event of creating the object, e.g. the object ID, the owner and the checksums. It is nil if the
+	// transaction is not waited for in asynchronous mode.
+	Event *storagetypes.EventCreateObject
+}
+
+// CreateGroupResult indicates the result of creating a group.
+type CreateGroupResult struct {
+	TxHash string
+	// Event is the event of creating the group, e.g. the group ID and the owner.
+	Event *storagetypes.EventCreateGroup
+}
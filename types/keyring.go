@@ -0,0 +1,150 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const keyringFileExt = ".json"
+
+// ErrKeyNotFound is returned when the named account does not exist in the keyring.
+var ErrKeyNotFound = errors.New("key not found in the keyring")
+
+// KeyInfo indicates the public information of an account stored in the keyring.
+type KeyInfo struct {
+	Name    string
+	Address sdk.AccAddress
+}
+
+// Keyring - A directory based keyring, each named account is stored as an Ethereum keystore v3 file in the directory.
+type Keyring struct {
+	dir string
+}
+
+// NewKeyring - Create a keyring stored in the directory, the directory is created if it does not exist.
+//
+// -dir: The directory of the keyring.
+//
+// -ret1: The pointer of the keyring.
+//
+// -ret2: Error message if the directory can not be created, otherwise returns nil.
+func NewKeyring(dir string) (*Keyring, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Keyring{dir: dir}, nil
+}
+
+// List - List the accounts stored in the keyring, the keystores are not decrypted.
+func (k *Keyring) List() ([]KeyInfo, error) {
+	entries, err := os.ReadDir(k.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]KeyInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyringFileExt {
+			continue
+		}
+		keyJSON, err := os.ReadFile(filepath.Join(k.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var ks keystoreJSON
+		if err = json.Unmarshal(keyJSON, &ks); err != nil {
+			return nil, fmt.Errorf("invalid keystore file %s: %v", entry.Name(), err)
+		}
+		addr, err := sdk.AccAddressFromHexUnsafe(strings.TrimPrefix(ks.Address, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid address in keystore file %s: %v", entry.Name(), err)
+		}
+		infos = append(infos, KeyInfo{
+			Name:    strings.TrimSuffix(entry.Name(), keyringFileExt),
+			Address: addr,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// Add - Encrypt the account with the passphrase and store it in the keyring under the name.
+//
+// -name: The name of the account in the keyring.
+//
+// -account: The account to be stored.
+//
+// -passphrase: The passphrase to encrypt the account.
+//
+// -ret: Error message if the name is already used or the account can not be exported, otherwise returns nil.
+func (k *Keyring) Add(name string, account *Account, passphrase string) error {
+	path, err := k.path(name)
+	if err != nil {
+		return err
+	}
+	keyJSON, err := account.ExportKeystore(passphrase)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("key %s already exists in the keyring", name)
+		}
+		return err
+	}
+	if _, err = f.Write(keyJSON); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// Get - Decrypt the named account stored in the keyring.
+//
+// -name: The name of the account in the keyring.
+//
+// -passphrase: The passphrase which encrypts the account.
+//
+// -ret1: The pointer of the account instance.
+//
+// -ret2: Error message if the account does not exist or can not be decrypted, otherwise returns nil.
+func (k *Keyring) Get(name, passphrase string) (*Account, error) {
+	path, err := k.path(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, name)
+	}
+	return NewAccountFromKeystore(path, passphrase)
+}
+
+// Remove - Remove the named account from the keyring.
+func (k *Keyring) Remove(name string) error {
+	path, err := k.path(name)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, name)
+	}
+	return err
+}
+
+// path returns the path of the keystore file of the name.
+func (k *Keyring) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid key name %q", name)
+	}
+	return filepath.Join(k.dir, name+keyringFileExt), nil
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring(t *testing.T) {
+	useLightScrypt(t)
+	dir := filepath.Join(t.TempDir(), "keyring")
+	keyring, err := NewKeyring(dir)
+	require.NoError(t, err)
+
+	alice, _, err := NewAccount("alice")
+	require.NoError(t, err)
+	bob, _, err := NewAccount("bob")
+	require.NoError(t, err)
+	require.NoError(t, keyring.Add("bob", bob, "bob passphrase"))
+	require.NoError(t, keyring.Add("alice", alice, "alice passphrase"))
+	require.ErrorContains(t, keyring.Add("alice", bob, "passphrase"), "already exists")
+
+	// the files which are not keystores are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("notes"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "backup.json"), 0o700))
+
+	infos, err := keyring.List()
+	require.NoError(t, err)
+	require.Equal(t, []KeyInfo{
+		{Name: "alice", Address: alice.GetAddress()},
+		{Name: "bob", Address: bob.GetAddress()},
+	}, infos)
+
+	account, err := keyring.Get("alice", "alice passphrase")
+	require.NoError(t, err)
+	require.Equal(t, alice.GetAddress(), account.GetAddress())
+	_, err = keyring.Get("alice", "bob passphrase")
+	require.ErrorIs(t, err, ErrKeystoreWrongPassphrase)
+	_, err = keyring.Get("carol", "passphrase")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	require.NoError(t, keyring.Remove("bob"))
+	require.ErrorIs(t, keyring.Remove("bob"), ErrKeyNotFound)
+	_, err = keyring.Get("bob", "bob passphrase")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	infos, err = keyring.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+
+	// a keystore file with an invalid content fails the listing
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o600))
+	_, err = keyring.List()
+	require.ErrorContains(t, err, "broken.json")
+}
+
+func TestKeyringInvalidName(t *testing.T) {
+	dir := t.TempDir()
+	keyring, err := NewKeyring(filepath.Join(dir, "keyring"))
+	require.NoError(t, err)
+	account, _, err := NewAccount("account")
+	require.NoError(t, err)
+
+	for _, name := range []string{"", ".hidden", "..", "../escaped", "dir/name", filepath.Join(dir, "absolute")} {
+		require.ErrorContains(t, keyring.Add(name, account, "passphrase"), "invalid key name", name)
+		_, err = keyring.Get(name, "passphrase")
+		require.ErrorContains(t, err, "invalid key name", name)
+		require.ErrorContains(t, keyring.Remove(name), "invalid key name", name)
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "nothing is written out of the keyring")
+}
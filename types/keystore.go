@@ -0,0 +1,183 @@
+package types
+
+import (
+	"crypto/aes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/evmos/evmos/v12/sdk/keys"
+)
+
+const (
+	keystoreVersion = 3
+
+	// KeystoreScryptN is the N parameter of the scrypt key derivation used by ExportKeystore, it uses 256MB memory.
+	KeystoreScryptN = keystore.StandardScryptN
+	// KeystoreScryptP is the P parameter of the scrypt key derivation used by ExportKeystore.
+	KeystoreScryptP = keystore.StandardScryptP
+)
+
+// The limits of the key derivation parameters of the keystores to import, so that a crafted keystore file can not
+// exhaust the memory or the CPU. They are far above the parameters used by the Ethereum clients.
+const (
+	maxKeystoreScryptMemory = 1 << 30 // 128 * n * r bytes
+	maxKeystoreScryptP      = 16
+	maxKeystorePBKDF2C      = 1 << 22
+)
+
+// keystoreScryptN is the N parameter used by ExportKeystore, the tests lower it to keep the key derivation fast.
+var keystoreScryptN = KeystoreScryptN
+
+// ErrKeystoreWrongPassphrase is returned when the keystore can not be decrypted with the passphrase.
+var ErrKeystoreWrongPassphrase = keystore.ErrDecrypt
+
+// keystoreJSON is the part of the Ethereum keystore v3 format which is checked before decrypting it.
+type keystoreJSON struct {
+	Address string              `json:"address"`
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+	Version int                 `json:"version"`
+}
+
+// NewAccountFromKeystore - Create account instance from an Ethereum keystore v3 file.
+//
+// -path: The path of the keystore file, the file name without extension is used as the account name.
+//
+// -passphrase: The passphrase which encrypts the keystore.
+//
+// -ret1: The pointer of the created account instance.
+//
+// -ret2: Error message if the file can not be read or decrypted, otherwise returns nil.
+func NewAccountFromKeystore(path, passphrase string) (*Account, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return NewAccountFromKeystoreJSON(name, keyJSON, passphrase)
+}
+
+// NewAccountFromKeystoreJSON - Create account instance from the content of an Ethereum keystore v3 file.
+//
+// -name: Account name.
+//
+// -keyJSON: The content of the keystore file.
+//
+// -passphrase: The passphrase which encrypts the keystore.
+//
+// -ret1: The pointer of the created account instance.
+//
+// -ret2: Error message if the keystore can not be decrypted, otherwise returns nil.
+func NewAccountFromKeystoreJSON(name string, keyJSON []byte, passphrase string) (*Account, error) {
+	var ks keystoreJSON
+	if err := json.Unmarshal(keyJSON, &ks); err != nil {
+		return nil, err
+	}
+	if ks.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	if err := checkKeystoreCrypto(ks.Crypto); err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	km, err := keys.NewPrivateKeyManager(hex.EncodeToString(crypto.FromECDSA(key.PrivateKey)))
+	if err != nil {
+		return nil, err
+	}
+	if ks.Address != "" && !strings.EqualFold(strings.TrimPrefix(ks.Address, "0x"), hex.EncodeToString(km.GetAddr().Bytes())) {
+		return nil, errors.New("the address of the keystore does not match its private key")
+	}
+	return &Account{
+		name: name,
+		km:   km,
+	}, nil
+}
+
+// ExportKeystore - Export the private key of the account as an Ethereum keystore v3 file content encrypted by the
+// passphrase with scrypt and AES-128-CTR.
+//
+// -passphrase: The passphrase to encrypt the keystore.
+//
+// -ret1: The content of the keystore file.
+//
+// -ret2: Error message if the private key of the account is not available, otherwise returns nil.
+func (a *Account) ExportKeystore(passphrase string) ([]byte, error) {
+	privKey := a.km.GetPrivKey()
+	if privKey == nil || len(privKey.Bytes()) == 0 {
+		return nil, errors.New("the private key of the account is not available")
+	}
+	ecdsaKey, err := crypto.ToECDSA(privKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	key := &keystore.Key{
+		Address:    crypto.PubkeyToAddress(ecdsaKey.PublicKey),
+		PrivateKey: ecdsaKey,
+	}
+	// version 4 random uuid
+	if _, err = rand.Read(key.Id[:]); err != nil {
+		return nil, err
+	}
+	key.Id[6] = (key.Id[6] & 0x0f) | 0x40
+	key.Id[8] = (key.Id[8] & 0x3f) | 0x80
+
+	return keystore.EncryptKey(key, passphrase, keystoreScryptN, KeystoreScryptP)
+}
+
+// checkKeystoreCrypto checks the cipher parameters and bounds the key derivation parameters of a keystore before it
+// is decrypted, keystore.DecryptKey trusts them and panics or exhausts the resources on a malformed keystore.
+func checkKeystoreCrypto(c keystore.CryptoJSON) error {
+	if c.Cipher != "aes-128-ctr" {
+		return fmt.Errorf("unsupported keystore cipher %s", c.Cipher)
+	}
+	iv, err := hex.DecodeString(c.CipherParams.IV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return fmt.Errorf("invalid keystore iv %q", c.CipherParams.IV)
+	}
+	if _, ok := c.KDFParams["salt"].(string); !ok {
+		return errors.New("invalid keystore kdf salt")
+	}
+	if dkLen := kdfParamInt(c.KDFParams, "dklen"); dkLen != 32 {
+		return fmt.Errorf("invalid keystore kdf dklen %d", dkLen)
+	}
+
+	switch c.KDF {
+	case "scrypt":
+		n, r, p := kdfParamInt(c.KDFParams, "n"), kdfParamInt(c.KDFParams, "r"), kdfParamInt(c.KDFParams, "p")
+		if n <= 1 || n&(n-1) != 0 || r <= 0 || p <= 0 || p > maxKeystoreScryptP || int64(n)*int64(r) > maxKeystoreScryptMemory/128 {
+			return fmt.Errorf("unsupported keystore scrypt parameters n=%d r=%d p=%d", n, r, p)
+		}
+	case "pbkdf2":
+		if prf, _ := c.KDFParams["prf"].(string); prf != "hmac-sha256" {
+			return fmt.Errorf("unsupported keystore pbkdf2 prf %v", c.KDFParams["prf"])
+		}
+		if iterations := kdfParamInt(c.KDFParams, "c"); iterations <= 0 || iterations > maxKeystorePBKDF2C {
+			return fmt.Errorf("unsupported keystore pbkdf2 iterations %d", iterations)
+		}
+	default:
+		return fmt.Errorf("unsupported keystore kdf %s", c.KDF)
+	}
+	return nil
+}
+
+// kdfParamInt returns the integer kdf parameter, the json numbers are decoded as float64. It returns -1 if the
+// parameter is missing, not an integer or out of the range of int32.
+func kdfParamInt(params map[string]interface{}, key string) int {
+	v, ok := params[key].(float64)
+	if !ok || v != float64(int32(v)) {
+		return -1
+	}
+	return int(v)
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/require"
+)
+
+// useLightScrypt exports the keystores with the light scrypt parameters in the test.
+func useLightScrypt(t *testing.T) {
+	keystoreScryptN = keystore.LightScryptN
+	t.Cleanup(func() { keystoreScryptN = KeystoreScryptN })
+}
+
+func TestKeystore(t *testing.T) {
+	useLightScrypt(t)
+	account, _, err := NewAccount("keystore")
+	require.NoError(t, err)
+	keyJSON, err := account.ExportKeystore("passphrase")
+	require.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		imported, err := NewAccountFromKeystoreJSON("imported", keyJSON, "passphrase")
+		require.NoError(t, err)
+		require.Equal(t, account.GetAddress(), imported.GetAddress())
+		require.Equal(t, "imported", imported.name)
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		_, err := NewAccountFromKeystoreJSON("imported", keyJSON, "wrong")
+		require.ErrorIs(t, err, ErrKeystoreWrongPassphrase)
+	})
+
+	t.Run("corrupt file", func(t *testing.T) {
+		corrupt := func(modify func(ks map[string]interface{})) []byte {
+			var ks map[string]interface{}
+			require.NoError(t, json.Unmarshal(keyJSON, &ks))
+			modify(ks)
+			data, err := json.Marshal(ks)
+			require.NoError(t, err)
+			return data
+		}
+		cryptoOf := func(ks map[string]interface{}) map[string]interface{} {
+			return ks["crypto"].(map[string]interface{})
+		}
+		kdfParamsOf := func(ks map[string]interface{}) map[string]interface{} {
+			return cryptoOf(ks)["kdfparams"].(map[string]interface{})
+		}
+
+		cases := map[string][]byte{
+			"truncated": keyJSON[:len(keyJSON)/2],
+			"short iv": corrupt(func(ks map[string]interface{}) {
+				cryptoOf(ks)["cipherparams"] = map[string]interface{}{"iv": "00"}
+			}),
+			"huge scrypt n": corrupt(func(ks map[string]interface{}) {
+				kdfParamsOf(ks)["n"] = 1 << 40
+			}),
+			"huge scrypt p": corrupt(func(ks map[string]interface{}) {
+				kdfParamsOf(ks)["p"] = 1 << 20
+			}),
+			"short dklen": corrupt(func(ks map[string]interface{}) {
+				kdfParamsOf(ks)["dklen"] = 16
+			}),
+			"salt not string": corrupt(func(ks map[string]interface{}) {
+				kdfParamsOf(ks)["salt"] = 1
+			}),
+			"huge pbkdf2 c": corrupt(func(ks map[string]interface{}) {
+				cryptoOf(ks)["kdf"] = "pbkdf2"
+				kdfParamsOf(ks)["prf"] = "hmac-sha256"
+				kdfParamsOf(ks)["c"] = 1 << 40
+			}),
+			"unknown cipher": corrupt(func(ks map[string]interface{}) {
+				cryptoOf(ks)["cipher"] = "aes-256-gcm"
+			}),
+			"cipher text not hex": corrupt(func(ks map[string]interface{}) {
+				cryptoOf(ks)["ciphertext"] = "zz"
+			}),
+			"other address": corrupt(func(ks map[string]interface{}) {
+				ks["address"] = "0000000000000000000000000000000000000001"
+			}),
+		}
+		for name, data := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := NewAccountFromKeystoreJSON("imported", data, "passphrase")
+				require.Error(t, err)
+			})
+		}
+	})
+}
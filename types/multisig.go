@@ -0,0 +1,111 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MultisigAccount indicates the M-of-N multisig account composed of the public keys of its members, the
+// transactions of the account are valid when they are signed by at least M members.
+type MultisigAccount struct {
+	name   string
+	pubKey *kmultisig.LegacyAminoPubKey
+}
+
+// NewMultisigAccount - Create multisig account instance according to the public keys of the members and the threshold.
+//
+// -name: Account name.
+//
+// -threshold: The min number of the members to sign a transaction.
+//
+// -pubKeys: The public keys of the members, e.g. Account.GetPubKey(). They are sorted by address, so the address of the
+// multisig account does not depend on their order.
+//
+// -ret1: The pointer of the created multisig account instance.
+//
+// -ret2: Error message if the threshold or the public keys are not correct, otherwise returns nil.
+func NewMultisigAccount(name string, threshold int, pubKeys []cryptotypes.PubKey) (*MultisigAccount, error) {
+	if threshold <= 0 {
+		return nil, errors.New("the threshold of the multisig account should be positive")
+	}
+	if len(pubKeys) < threshold {
+		return nil, fmt.Errorf("the threshold %d exceeds the number of the public keys %d", threshold, len(pubKeys))
+	}
+
+	sorted := make([]cryptotypes.PubKey, len(pubKeys))
+	copy(sorted, pubKeys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Address(), sorted[j].Address()) < 0
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Equals(sorted[i-1]) {
+			return nil, fmt.Errorf("duplicate public key of %s", sdk.AccAddress(sorted[i].Address()).String())
+		}
+	}
+	return &MultisigAccount{
+		name:   name,
+		pubKey: kmultisig.NewLegacyAminoPubKey(threshold, sorted),
+	}, nil
+}
+
+// NewMultisigAccountFromPubKey - Create multisig account instance according to the multisig public key, e.g. the
+// public key of a multisig account on chain.
+//
+// -name: Account name.
+//
+// -pubKey: The multisig public key.
+//
+// -ret1: The pointer of the created multisig account instance.
+//
+// -ret2: Error message if the public key is not a multisig one, otherwise returns nil.
+func NewMultisigAccountFromPubKey(name string, pubKey cryptotypes.PubKey) (*MultisigAccount, error) {
+	multisigPubKey, ok := pubKey.(*kmultisig.LegacyAminoPubKey)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a multisig public key", pubKey)
+	}
+	return &MultisigAccount{
+		name:   name,
+		pubKey: multisigPubKey,
+	}, nil
+}
+
+// GetName - Get the name of the multisig account.
+func (m *MultisigAccount) GetName() string {
+	return m.name
+}
+
+// GetAddress - Get the address of the multisig account, it is derived from the public keys and the threshold.
+func (m *MultisigAccount) GetAddress() sdk.AccAddress {
+	return sdk.AccAddress(m.pubKey.Address())
+}
+
+// GetPubKey - Get the multisig public key of the account.
+func (m *MultisigAccount) GetPubKey() cryptotypes.PubKey {
+	return m.pubKey
+}
+
+// GetThreshold - Get the min number of the members to sign a transaction.
+func (m *MultisigAccount) GetThreshold() int {
+	return int(m.pubKey.Threshold)
+}
+
+// GetMemberPubKeys - Get the public keys of the members.
+func (m *MultisigAccount) GetMemberPubKeys() []cryptotypes.PubKey {
+	return m.pubKey.GetPubKeys()
+}
+
+// IsMember - Check whether the address is a member of the multisig account.
+func (m *MultisigAccount) IsMember(addr sdk.AccAddress) bool {
+	for _, pubKey := range m.pubKey.GetPubKeys() {
+		if addr.Equals(sdk.AccAddress(pubKey.Address())) {
+			return true
+		}
+	}
+	return false
+}
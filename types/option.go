@@ -250,6 +250,18 @@ type GetObjectOptions struct {
 	PartSize         uint64 // PartSize indicate the resumable download's part size, download a large file in multiple parts. The part size is an integer multiple of the segment size.
 }
 
+// PresignGetObjectOptions contains the options for `PresignGetObject` API.
+type PresignGetObjectOptions struct {
+	Expires time.Duration // Expires indicates how long the url is valid, the default expiry of the client is used if it is 0.
+	Range   string        // Range restricts the url to download the given range only, e.g. "bytes=0-1023". The request must carry the same Range header.
+}
+
+// PresignPutObjectOptions contains the options for `PresignPutObject` API.
+type PresignPutObjectOptions struct {
+	Expires     time.Duration // Expires indicates how long the url is valid, the default expiry of the client is used if it is 0.
+	ContentType string        // ContentType restricts the url to upload the given content type only. The request must carry the same Content-Type header.
+}
+
 // GetChallengeInfoOptions contains the options for querying challenge data.
 type GetChallengeInfoOptions struct {
 	Endpoint     string // Endpoint indicates the endpoint of sp
@@ -290,7 +302,7 @@ type GroupsOwnerPaginationOptions struct {
 	// If the limit exceeds 1000, only 1000 records will be returned.
 	Limit      int64
 	StartAfter string // StartAfter is used to input the group id for pagination purposes.
-	Owner      string // Owner defines the owner account address of groups, if owner is set to "", it will default to current user address.
+	Owner      string // Owner defines the owner account address of groups, if owner is set to "", it will default to the account set by client.WithAccount, or the default account.
 	Endpoint   string // Endpoint indicates the endpoint of sp.
 	SPAddress  string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
 }
@@ -302,7 +314,7 @@ type GroupsPaginationOptions struct {
 	// If the limit exceeds 1000, only 1000 records will be returned.
 	Limit      int64
 	StartAfter string // StartAfter is used to input the group id for pagination purposes.
-	Account    string // Account defines the user account address, if it is set to "", it will default to the current user address.
+	Account    string // Account defines the user account address, if it is set to "", it will default to the account set by client.WithAccount, or the default account.
 	Endpoint   string // Endpoint indicates the endpoint of sp.
 	SPAddress  string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
 }
@@ -333,7 +345,7 @@ type EndPointOptions struct {
 // ListBucketsOptions contains the options for `ListBuckets` API.
 type ListBucketsOptions struct {
 	ShowRemovedBucket bool   // ShowRemovedBucket determines whether to include buckets that have been marked as removed in the list. If set to false, these buckets will be skipped.
-	Account           string // Account defines the user account address, if it is set to "", it will default to the current user address.
+	Account           string // Account defines the user account address, if it is set to "", it will default to the account set by client.WithAccount, or the default account.
 	Endpoint          string // Endpoint indicates the endpoint of sp.
 	SPAddress         string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
 }
@@ -346,7 +358,7 @@ type ListBucketsByPaymentAccountOptions struct {
 
 // ListUserPaymentAccountsOptions contains the options for `ListUserPaymentAccounts` API.
 type ListUserPaymentAccountsOptions struct {
-	Account   string // Account defines the user account address, if it is set to "", it will default to the current user address.
+	Account   string // Account defines the user account address, if it is set to "", it will default to the account set by client.WithAccount, or the default account.
 	Endpoint  string // Endpoint indicates the endpoint of sp.
 	SPAddress string // SPAddress indicates the HEX-encoded string of the sp address to be challenged.
 }
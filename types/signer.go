@@ -0,0 +1,92 @@
+package types
+
+import (
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/evmos/evmos/v12/sdk/keys"
+)
+
+// Signer - The identity which signs the transactions and the SP requests on behalf of the user.
+//
+// Account implements Signer with an in-process key manager. The signers which keep the private key out of the
+// application memory, e.g. a remote signing daemon or a hardware wallet, can be used through NewAccountFromSigner.
+type Signer interface {
+	// GetAddress returns the address of the signer.
+	GetAddress() sdk.AccAddress
+	// GetPubKey returns the public key of the signer.
+	GetPubKey() cryptotypes.PubKey
+	// SignTx signs the sign bytes of a transaction.
+	SignTx(signBytes []byte) ([]byte, error)
+	// SignRequest signs the message of an SP request, e.g. the GNFD1 message of an HTTP request.
+	SignRequest(msg []byte) ([]byte, error)
+}
+
+// NewAccountFromSigner - Create account instance which delegates all the signing to the signer, the account does not
+// hold the private key.
+//
+// -name: Account name.
+//
+// -signer: The signer which holds the private key.
+//
+// -ret: The pointer of the created account instance.
+func NewAccountFromSigner(name string, signer Signer) *Account {
+	return &Account{
+		name: name,
+		km:   &signerKeyManager{signer: signer},
+	}
+}
+
+// signerKeyManager adapts a Signer to the keys.KeyManager used by the chain client.
+type signerKeyManager struct {
+	signer Signer
+}
+
+var _ keys.KeyManager = (*signerKeyManager)(nil)
+
+func (m *signerKeyManager) Sign(b []byte) ([]byte, error) {
+	return m.signer.SignRequest(b)
+}
+
+func (m *signerKeyManager) GetPrivKey() cryptotypes.PrivKey {
+	return &signerPrivKey{signer: m.signer}
+}
+
+func (m *signerKeyManager) GetAddr() sdk.AccAddress {
+	return m.signer.GetAddress()
+}
+
+// signerPrivKey is a cryptotypes.PrivKey which holds no key material, the signing is delegated to the Signer.
+type signerPrivKey struct {
+	signer Signer
+}
+
+var _ cryptotypes.PrivKey = (*signerPrivKey)(nil)
+
+// Bytes returns nil as the private key is not available.
+func (k *signerPrivKey) Bytes() []byte {
+	return nil
+}
+
+func (k *signerPrivKey) Sign(msg []byte) ([]byte, error) {
+	return k.signer.SignTx(msg)
+}
+
+func (k *signerPrivKey) PubKey() cryptotypes.PubKey {
+	return k.signer.GetPubKey()
+}
+
+func (k *signerPrivKey) Equals(other cryptotypes.LedgerPrivKey) bool {
+	return k.PubKey().Equals(other.PubKey())
+}
+
+func (k *signerPrivKey) Type() string {
+	return k.PubKey().Type()
+}
+
+func (k *signerPrivKey) Reset() {}
+
+func (k *signerPrivKey) String() string {
+	return "signer(" + k.signer.GetAddress().String() + ")"
+}
+
+func (k *signerPrivKey) ProtoMessage() {}
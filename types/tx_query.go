@@ -0,0 +1,126 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// TxQuery builds the query of SearchTxs, the conditions are joined by AND. The zero value matches all the
+// transactions.
+//
+// The strings of the CometBFT query language have no escape sequence, so a value containing a single quote is
+// rejected, the error is reported by Err and SearchTxs.
+type TxQuery struct {
+	conditions []string
+	err        error
+}
+
+// NewTxQuery - Create an empty query which matches all the transactions.
+func NewTxQuery() *TxQuery {
+	return &TxQuery{}
+}
+
+// MessageAction - Match the transactions which contain the message of the type URL, e.g.
+// sdk.MsgTypeURL(&storagetypes.MsgDeleteObject{}).
+func (q *TxQuery) MessageAction(typeURL string) *TxQuery {
+	return q.add("message.action=" + q.quote(typeURL))
+}
+
+// Sender - Match the transactions which contain the message sent by the address.
+//
+// -addr: The address of the sender. The chain emits the message.sender attribute in the format of
+// sdk.AccAddress.String(), the 0x-prefixed EIP-55 checksummed hex address, e.g.
+// "0x7B5Fe22B5446f7C62Ea27B8BD71CeF94e03f3dF2", the addr is converted to the format so that its case does not matter.
+func (q *TxQuery) Sender(addr string) *TxQuery {
+	sender, err := sdk.AccAddressFromHexUnsafe(addr)
+	if err != nil {
+		q.setErr(fmt.Errorf("invalid sender address %s: %w", addr, err))
+		return q
+	}
+	return q.add("message.sender=" + q.quote(sender.String()))
+}
+
+// EventAttribute - Match the transactions which emit the typed event whose field equals the value.
+//
+// -event: The typed event, e.g. &storagetypes.EventDeleteObject{}, only its type is used.
+//
+// -key: The JSON name of the field, e.g. "bucket_name".
+//
+// -value: The value of the string field, the IDs are strings as well, e.g. sdkmath.Uint.String().
+func (q *TxQuery) EventAttribute(event proto.Message, key string, value string) *TxQuery {
+	// the attributes of the typed events are encoded in JSON, so the strings are quoted
+	bz, _ := json.Marshal(value)
+	return q.add(fmt.Sprintf("%s.%s=%s", proto.MessageName(event), key, q.quote(string(bz))))
+}
+
+// BucketName - Match the transactions which emit the typed event of the bucket, e.g. &storagetypes.EventCreateBucket{}.
+func (q *TxQuery) BucketName(event proto.Message, bucketName string) *TxQuery {
+	return q.EventAttribute(event, "bucket_name", bucketName)
+}
+
+// ObjectName - Match the transactions which emit the typed event of the object, e.g. &storagetypes.EventDeleteObject{}.
+func (q *TxQuery) ObjectName(event proto.Message, objectName string) *TxQuery {
+	return q.EventAttribute(event, "object_name", objectName)
+}
+
+// MinHeight - Match the transactions included in the blocks at or after the height.
+func (q *TxQuery) MinHeight(height int64) *TxQuery {
+	return q.add(fmt.Sprintf("tx.height>=%d", height))
+}
+
+// MaxHeight - Match the transactions included in the blocks at or before the height.
+func (q *TxQuery) MaxHeight(height int64) *TxQuery {
+	return q.add(fmt.Sprintf("tx.height<=%d", height))
+}
+
+// String - Return the query in the CometBFT query language.
+func (q *TxQuery) String() string {
+	if q == nil || len(q.conditions) == 0 {
+		return "tx.height>=1"
+	}
+	return strings.Join(q.conditions, " AND ")
+}
+
+// Err - Return the error of the invalid condition of the query, e.g. a value containing a single quote.
+func (q *TxQuery) Err() error {
+	if q == nil {
+		return nil
+	}
+	return q.err
+}
+
+func (q *TxQuery) add(condition string) *TxQuery {
+	q.conditions = append(q.conditions, condition)
+	return q
+}
+
+// quote returns the value as a string of the CometBFT query language, which can not contain a single quote.
+func (q *TxQuery) quote(value string) string {
+	if strings.ContainsRune(value, '\'') {
+		q.setErr(fmt.Errorf("the query value %q contains a single quote", value))
+	}
+	return "'" + value + "'"
+}
+
+func (q *TxQuery) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// SearchTxsOptions indicates the pagination of SearchTxs.
+type SearchTxsOptions struct {
+	Page      int  // Page defines the page number starting from 1, the first page is returned if it is not set.
+	PerPage   int  // PerPage defines the number of transactions per page, 30 is used if it is not set, the max is 100.
+	OrderDesc bool // OrderDesc defines whether the transactions are sorted from the newest, they are sorted from the oldest by default.
+}
+
+// SearchTxsResult indicates a page of the transactions matching the query.
+type SearchTxsResult struct {
+	Txs        []*TxResult
+	TotalCount int // TotalCount is the number of the matching transactions in all the pages.
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+
+	storageTypes "github.com/evmos/evmos/v12/x/storage/types"
+)
+
+func TestTxQuery(t *testing.T) {
+	sender := sdk.AccAddress(make([]byte, 20))
+	sender[19] = 0xab
+	event := &storageTypes.EventCreateBucket{}
+
+	query := NewTxQuery().
+		MessageAction("/mechain.storage.MsgCreateBucket").
+		Sender(strings.ToLower(sender.String())).
+		BucketName(event, "bucket").
+		MinHeight(10)
+	require.NoError(t, query.Err())
+	require.Equal(t, "message.action='/mechain.storage.MsgCreateBucket' AND message.sender='"+sender.String()+"' AND "+
+		proto.MessageName(event)+".bucket_name='\"bucket\"' AND tx.height>=10", query.String())
+}
+
+func TestTxQueryRejectsQuotes(t *testing.T) {
+	event := &storageTypes.EventCreateBucket{}
+	require.Error(t, NewTxQuery().MessageAction("a' OR tx.height>='1").Err())
+	require.Error(t, NewTxQuery().BucketName(event, "bucket' OR 'a'='a").Err())
+	require.Error(t, NewTxQuery().Sender("0xnot-an-address").Err())
+}
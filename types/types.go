@@ -75,3 +75,16 @@ type StorageProvider struct {
 	Description     spTypes.Description
 	BlsKey          []byte
 }
+
+// TxSignerData is the signer data of the transaction built online by BuildUnsignedTx, which is required to sign it
+// offline by SignOfflineTx without network access.
+//
+// The transaction itself is the bare JSON of the Cosmos CLI generate-only output, so that it can also be signed by
+// "mechaind tx sign --offline --account-number <AccountNumber> --sequence <Sequence> --chain-id <ChainID>", the signer
+// data is kept apart from it, e.g. in a sidecar file next to the transaction file.
+type TxSignerData struct {
+	ChainID       string `json:"chain_id"`
+	AccountNumber uint64 `json:"account_number,string"`
+	Sequence      uint64 `json:"sequence,string"`
+	Signer        string `json:"signer"`
+}